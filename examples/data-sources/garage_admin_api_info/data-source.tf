@@ -0,0 +1,29 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+data "garage_admin_api_info" "current" {}
+
+locals {
+  # Only use K2V when the daemon was built with it
+  k2v_available = contains(data.garage_admin_api_info.current.garage_features, "k2v")
+
+  # Gate features on the running Garage version
+  is_garage_2_1_or_later = (
+    data.garage_admin_api_info.current.version_major > 2 ||
+    (data.garage_admin_api_info.current.version_major == 2 && data.garage_admin_api_info.current.version_minor >= 1)
+  )
+}
+
+output "garage_version" {
+  value = data.garage_admin_api_info.current.garage_version
+}
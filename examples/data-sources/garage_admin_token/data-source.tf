@@ -0,0 +1,36 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+# Look up a token by name
+data "garage_admin_token" "ci" {
+  name = "ci-pipeline"
+}
+
+# Or by ID
+data "garage_admin_token" "backup" {
+  id = "your-token-id-here"
+}
+
+# Fail the plan when the CI token can do more than it should
+resource "terraform_data" "ci_token_audit" {
+  lifecycle {
+    precondition {
+      condition     = !contains(data.garage_admin_token.ci.scope, "*")
+      error_message = "The CI token must not have access to every Admin API endpoint."
+    }
+    precondition {
+      condition     = !data.garage_admin_token.ci.never_expires
+      error_message = "The CI token must expire."
+    }
+  }
+}
@@ -0,0 +1,29 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+# Block errors of every node
+data "garage_block_errors" "all" {}
+
+# Alert when blocks keep failing to resynchronize
+check "block_errors" {
+  assert {
+    condition     = length(data.garage_block_errors.all.errors) == 0
+    error_message = "${length(data.garage_block_errors.all.errors)} blocks failed to resynchronize; consider a garage_repair with repair_type = \"blocks\"."
+  }
+}
+
+# Only referenced blocks that have been failing for more than a day
+data "garage_block_errors" "long_standing" {
+  min_error_age = "24h"
+  min_refcount  = 1
+}
@@ -21,6 +21,22 @@ data "garage_bucket" "by_id" {
   id = "8d7c3c6e-7b9d-4c3a-9f2e-1a5b6c7d8e9f"
 }
 
+# Audit owners: only allowlisted keys may own the bucket
+check "bucket_owners" {
+  assert {
+    condition = alltrue([
+      for key in data.garage_bucket.by_alias.keys : !key.owner || contains(["GK31c2f218a2e44f485b94239e"], key.access_key_id)
+    ])
+    error_message = "A key outside the allowlist owns my-bucket."
+  }
+}
+
+# Optional lookup: id is null when the bucket does not exist
+data "garage_bucket" "maybe" {
+  global_alias    = "may-not-exist"
+  fail_if_missing = false
+}
+
 # Use data source output
 output "bucket_info" {
   value = {
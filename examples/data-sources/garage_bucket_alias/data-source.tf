@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+data "garage_bucket_alias" "assets" {
+  global_alias = "assets"
+}
+
+# Only create the bucket when nobody else has created it yet
+resource "garage_bucket" "assets" {
+  count        = data.garage_bucket_alias.assets.exists ? 0 : 1
+  global_alias = "assets"
+}
+
+locals {
+  assets_bucket_id = data.garage_bucket_alias.assets.exists ? data.garage_bucket_alias.assets.bucket_id : garage_bucket.assets[0].id
+}
@@ -0,0 +1,27 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+data "garage_bucket_aliases" "all" {}
+
+# A key that can read every existing bucket, for instance for backups
+resource "garage_key" "backup" {
+  name = "backup"
+}
+
+resource "garage_bucket_permission" "backup" {
+  for_each = data.garage_bucket_aliases.all.bucket_ids
+
+  bucket_id     = each.value
+  access_key_id = garage_key.backup.id
+  read          = true
+}
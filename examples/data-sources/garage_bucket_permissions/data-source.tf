@@ -0,0 +1,49 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+data "garage_bucket_permissions" "production" {
+  global_alias = "production"
+}
+
+locals {
+  # Keys allowed to access the production bucket
+  allowed_keys = ["GK31c2f218a2e44f485b94239e"]
+
+  stray_grants = {
+    for id, permissions in data.garage_bucket_permissions.production.permissions : id => permissions
+    if !contains(local.allowed_keys, id)
+  }
+}
+
+# Drift report: the keys granted access outside the allowlist
+output "stray_grants" {
+  value = { for id, permissions in local.stray_grants : id => permissions.name }
+}
+
+# Revoke the stray grants: adopt each of them, then deny all its permissions
+# (import blocks with for_each require Terraform 1.7 or later)
+import {
+  for_each = local.stray_grants
+  to       = garage_bucket_permission.stray[each.key]
+  id       = "${data.garage_bucket_permissions.production.bucket_id}/${each.key}"
+}
+
+resource "garage_bucket_permission" "stray" {
+  for_each = local.stray_grants
+
+  bucket_id     = data.garage_bucket_permissions.production.bucket_id
+  access_key_id = each.key
+  read          = false
+  write         = false
+  owner         = false
+}
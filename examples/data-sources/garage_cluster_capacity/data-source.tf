@@ -0,0 +1,37 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+data "garage_cluster_capacity" "current" {}
+
+variable "bucket_quotas" {
+  type = map(number)
+  default = {
+    "backups" = 500000000000
+    "media"   = 200000000000
+  }
+}
+
+# Refuse to hand out more quota than the cluster can store
+resource "garage_bucket" "quota" {
+  for_each = var.bucket_quotas
+
+  global_alias = each.key
+  max_size     = each.value
+
+  lifecycle {
+    precondition {
+      condition     = sum(values(var.bucket_quotas)) <= data.garage_cluster_capacity.current.usable_capacity
+      error_message = "The bucket quotas exceed the usable capacity of the cluster."
+    }
+  }
+}
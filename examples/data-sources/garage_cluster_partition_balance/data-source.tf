@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+data "garage_cluster_partition_balance" "current" {}
+
+# Fail the run if any storage node holds more than half of the data
+check "partition_balance" {
+  assert {
+    condition     = alltrue([for node in data.garage_cluster_partition_balance.current.nodes : node.data_share <= 0.5])
+    error_message = "The cluster layout is unbalanced."
+  }
+}
+
+output "partitions_per_node" {
+  value = {
+    for node in data.garage_cluster_partition_balance.current.nodes : node.id => node.stored_partitions
+  }
+}
@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+data "garage_cluster_zones" "current" {}
+
+# Refuse to continue unless data is spread over three zones
+resource "terraform_data" "zone_redundancy" {
+  lifecycle {
+    precondition {
+      condition     = length([for zone in data.garage_cluster_zones.current.zones : zone if zone.storage_node_count > 0]) >= 3
+      error_message = "The cluster must store data in at least three zones."
+    }
+  }
+}
+
+output "zone_capacity" {
+  value = { for name, zone in data.garage_cluster_zones.current.zones : name => zone.capacity }
+}
@@ -0,0 +1,36 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+resource "garage_bucket" "site" {
+  global_alias           = "www.example.com"
+  website_enabled        = true
+  website_index_document = "index.html"
+}
+
+data "garage_domain_check" "site" {
+  domain = garage_bucket.site.full_global_alias
+
+  depends_on = [garage_bucket.site]
+}
+
+# Only publish the DNS record once Garage serves the domain
+resource "terraform_data" "dns" {
+  input = data.garage_domain_check.site.domain
+
+  lifecycle {
+    precondition {
+      condition     = data.garage_domain_check.site.served
+      error_message = "Garage does not serve a website for ${data.garage_domain_check.site.domain}."
+    }
+  }
+}
@@ -0,0 +1,47 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint     = "http://localhost:3903"
+  token        = "your-admin-token-here"
+  k2v_endpoint = "http://localhost:3904"
+}
+
+data "garage_bucket" "app_state" {
+  global_alias = "app-state"
+}
+
+resource "garage_key" "reader" {
+  name = "app-state-reader"
+}
+
+resource "garage_bucket_permission" "reader" {
+  bucket_id     = data.garage_bucket.app_state.id
+  access_key_id = garage_key.reader.id
+  read          = true
+}
+
+data "garage_k2v_items" "feature_flags" {
+  bucket            = "app-state"
+  partition_key     = "feature-flags"
+  prefix            = "checkout-"
+  access_key_id     = garage_bucket_permission.reader.access_key_id
+  secret_access_key = garage_key.reader.secret_access_key
+}
+
+# Fail the run when the flags were not bootstrapped
+check "feature_flags_seeded" {
+  assert {
+    condition     = length(data.garage_k2v_items.feature_flags.items) > 0
+    error_message = "No checkout feature flags were found in the app-state bucket."
+  }
+}
+
+output "feature_flags" {
+  value = { for item in data.garage_k2v_items.feature_flags.items : item.sort_key => item.value }
+}
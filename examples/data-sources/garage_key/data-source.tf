@@ -0,0 +1,33 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+# Look up a key created outside Terraform by its name
+data "garage_key" "legacy" {
+  search = "legacy-app"
+}
+
+# Grant it access to a bucket managed by Terraform
+resource "garage_bucket" "data" {
+  global_alias = "legacy-data"
+}
+
+resource "garage_bucket_permission" "legacy" {
+  bucket_id     = garage_bucket.data.id
+  access_key_id = data.garage_key.legacy.id
+  read          = true
+  write         = true
+}
+
+output "legacy_key_buckets" {
+  value = [for bucket in data.garage_key.legacy.buckets : bucket.id]
+}
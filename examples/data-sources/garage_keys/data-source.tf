@@ -0,0 +1,35 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+# All keys used by CI pipelines
+data "garage_keys" "ci" {
+  name_regex = "^ci-"
+}
+
+# The keys Garage matches for a name or an access key ID prefix
+data "garage_keys" "deploy" {
+  search = "deploy"
+}
+
+# Every expired key, for a credential hygiene report
+data "garage_keys" "expired" {
+  only_expired = true
+}
+
+output "ci_key_ids" {
+  value = data.garage_keys.ci.ids
+}
+
+output "expired_keys" {
+  value = { for key in data.garage_keys.expired.keys : key.id => key.name }
+}
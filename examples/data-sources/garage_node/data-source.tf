@@ -0,0 +1,40 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+variable "node_id" {
+  type        = string
+  description = "The node to give a storage role"
+}
+
+data "garage_node" "storage" {
+  node = var.node_id
+}
+
+# Only assign the role once the node runs the expected version, with the
+# assigned capacity fitting on its data partition
+resource "garage_cluster_node_role" "storage" {
+  node_id  = data.garage_node.storage.node_id
+  zone     = "dc1"
+  capacity = 500000000000
+
+  lifecycle {
+    precondition {
+      condition     = startswith(data.garage_node.storage.garage_version, "v2.")
+      error_message = "Node ${var.node_id} runs Garage ${data.garage_node.storage.garage_version}, expected v2."
+    }
+    precondition {
+      condition     = coalesce(data.garage_node.storage.data_total, 0) >= 500000000000
+      error_message = "The data partition of node ${var.node_id} is smaller than the assigned capacity."
+    }
+  }
+}
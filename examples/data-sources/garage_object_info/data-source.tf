@@ -0,0 +1,37 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+data "garage_bucket" "assets" {
+  global_alias = "assets"
+}
+
+data "garage_object_info" "index" {
+  bucket_id = data.garage_bucket.assets.id
+  key       = "index.html"
+}
+
+# Fail the run when the seeded object is missing
+check "index_seeded" {
+  assert {
+    condition     = data.garage_object_info.index.exists
+    error_message = "index.html was not seeded in the assets bucket."
+  }
+}
+
+output "index_size" {
+  value = data.garage_object_info.index.size
+}
+
+output "index_blocks" {
+  value = flatten(data.garage_object_info.index.versions[*].blocks[*].hash)
+}
@@ -0,0 +1,44 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint    = "http://localhost:3903"
+  token       = "your-admin-token-here"
+  s3_endpoint = "http://localhost:3900"
+}
+
+resource "garage_bucket" "app" {
+  global_alias = "app-data"
+}
+
+resource "garage_key" "app" {
+  name = "app"
+}
+
+resource "garage_bucket_permission" "app" {
+  bucket_id     = garage_bucket.app.id
+  access_key_id = garage_key.app.id
+  read          = true
+  write         = true
+}
+
+data "garage_s3_connection" "app" {
+  bucket        = garage_bucket.app.full_global_alias
+  access_key_id = garage_bucket_permission.app.access_key_id
+}
+
+# Connection settings for the application's Helm values
+output "s3_values" {
+  value = {
+    endpoint       = data.garage_s3_connection.app.endpoint
+    region         = data.garage_s3_connection.app.region
+    bucket         = data.garage_s3_connection.app.bucket
+    accessKeyId    = data.garage_s3_connection.app.access_key_id
+    forcePathStyle = data.garage_s3_connection.app.force_path_style
+  }
+}
@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+# Read a worker on the node the provider talks to
+data "garage_worker_info" "resync" {
+  worker_id = 1
+}
+
+# Alert when the worker keeps failing
+check "resync_worker" {
+  assert {
+    condition     = data.garage_worker_info.resync.consecutive_errors == 0
+    error_message = "Worker ${data.garage_worker_info.resync.name} is failing: ${coalesce(data.garage_worker_info.resync.last_error, "unknown error")}"
+  }
+}
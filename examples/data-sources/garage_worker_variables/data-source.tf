@@ -0,0 +1,29 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+# Read all worker variables on every node
+data "garage_worker_variables" "all" {}
+
+# Read a single variable on the node the provider talks to
+data "garage_worker_variables" "tranquility" {
+  node     = "self"
+  variable = "resync-tranquility"
+}
+
+# Detect tuning changed by hand on any node
+check "resync_tranquility" {
+  assert {
+    condition     = alltrue([for node_id, vars in data.garage_worker_variables.all.values : vars["resync-tranquility"] == "2"])
+    error_message = "resync-tranquility has been changed outside of Terraform."
+  }
+}
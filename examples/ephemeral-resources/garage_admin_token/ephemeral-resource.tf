@@ -0,0 +1,31 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+    http = {
+      source = "hashicorp/http"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+# Admin token limited to the health endpoint, revoked at the end of the run
+ephemeral "garage_admin_token" "health" {
+  name  = "ci-health-check"
+  scope = ["GetClusterHealth"]
+  ttl   = "15m"
+}
+
+# Call the Admin API with the short-lived token
+ephemeral "http" "health" {
+  url = "http://localhost:3903/v2/GetClusterHealth"
+
+  request_headers = {
+    Authorization = "Bearer ${ephemeral.garage_admin_token.health.secret_token}"
+  }
+}
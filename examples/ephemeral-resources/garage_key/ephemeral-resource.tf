@@ -0,0 +1,42 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+resource "garage_bucket" "artifacts" {
+  global_alias = "ci-artifacts"
+}
+
+# Short-lived access key for a CI job, deleted at the end of the run
+ephemeral "garage_key" "ci" {
+  name = "ci-deploy"
+  ttl  = "30m"
+
+  bucket_permissions = [{
+    bucket_id = garage_bucket.artifacts.id
+    read      = true
+    write     = true
+  }]
+}
+
+# Configure an S3 provider with the key without storing the secret in state
+provider "aws" {
+  access_key                  = ephemeral.garage_key.ci.id
+  secret_key                  = ephemeral.garage_key.ci.secret_access_key
+  region                      = "garage"
+  skip_credentials_validation = true
+  skip_requesting_account_id  = true
+  skip_region_validation      = true
+
+  endpoints {
+    s3 = "http://localhost:3900"
+  }
+}
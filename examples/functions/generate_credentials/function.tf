@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+    random = {
+      source = "hashicorp/random"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+resource "random_password" "app_key_seed" {
+  length = 64
+}
+
+locals {
+  app_credentials = provider::garage::generate_credentials(random_password.app_key_seed.result)
+}
+
+# The credentials can be handed to the application before the key exists
+resource "garage_key" "app" {
+  name              = "app"
+  access_key_id     = local.app_credentials.access_key_id
+  secret_access_key = local.app_credentials.secret_access_key
+}
@@ -0,0 +1,32 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+data "garage_bucket" "uploads" {
+  global_alias = "uploads"
+}
+
+locals {
+  uploads_size = provider::garage::quota_headroom(data.garage_bucket.uploads.bytes, data.garage_bucket.uploads.max_size)
+}
+
+output "uploads_used_percent" {
+  value = local.uploads_size.used_percent
+}
+
+# Warn when the bucket is almost full
+check "uploads_quota" {
+  assert {
+    condition     = local.uploads_size.used_percent == null || local.uploads_size.used_percent < 90
+    error_message = "The uploads bucket uses more than 90% of its size quota."
+  }
+}
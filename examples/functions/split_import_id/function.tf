@@ -0,0 +1,33 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+variable "existing_permissions" {
+  description = "Permissions granted outside Terraform, as bucket_id/access_key_id"
+  type        = set(string)
+  default     = []
+}
+
+# Adopt existing permissions
+import {
+  for_each = var.existing_permissions
+  to       = garage_bucket_permission.existing[each.value]
+  id       = each.value
+}
+
+resource "garage_bucket_permission" "existing" {
+  for_each = var.existing_permissions
+
+  bucket_id     = provider::garage::split_import_id(each.value).bucket_id
+  access_key_id = provider::garage::split_import_id(each.value).access_key_id
+  read          = true
+}
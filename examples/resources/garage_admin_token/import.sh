@@ -0,0 +1,4 @@
+#!/bin/bash
+
+# Garage admin tokens can be imported using the token ID
+terraform import garage_admin_token.example 2c8d7d1ff1a6d9d0b6d1f4ab
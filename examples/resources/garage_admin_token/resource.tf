@@ -0,0 +1,42 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+# A token for monitoring that only reads the cluster health
+resource "garage_admin_token" "monitoring" {
+  name  = "monitoring"
+  scope = ["GetClusterHealth", "GetClusterStatus", "Metrics"]
+}
+
+# A short-lived token for CI with full access
+resource "garage_admin_token" "ci" {
+  name       = "ci"
+  scope      = ["*"]
+  expiration = "2030-01-01T00:00:00Z"
+}
+
+# Replace the token every 30 days
+resource "garage_admin_token" "rotated" {
+  name         = "rotated"
+  scope        = ["GetClusterHealth"]
+  rotate_after = "720h"
+}
+
+output "monitoring_token" {
+  value     = garage_admin_token.monitoring.secret_token
+  sensitive = true
+}
+
+# Audit tokens that are past their expiration
+output "ci_token_expired" {
+  value = garage_admin_token.ci.expired
+}
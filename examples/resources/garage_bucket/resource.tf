@@ -31,6 +31,12 @@ resource "garage_bucket" "limited" {
   max_objects  = 10000
 }
 
+# Bucket whose abandoned uploads are aborted on every apply
+resource "garage_bucket" "uploads" {
+  global_alias                          = "uploads"
+  cleanup_incomplete_uploads_older_than = "24h"
+}
+
 # Bucket with all options
 resource "garage_bucket" "full" {
   global_alias           = "full-featured-bucket"
@@ -40,3 +46,28 @@ resource "garage_bucket" "full" {
   max_size               = 10737418240 # 10 GB in bytes
   max_objects            = 100000
 }
+
+# Bucket reachable under several names
+resource "garage_bucket" "assets" {
+  global_alias   = "assets"
+  global_aliases = ["assets", "static", "cdn.example.com"]
+}
+
+# Bucket only visible to one key under its own name
+resource "garage_key" "app" {
+  name = "my-application"
+}
+
+resource "garage_bucket" "private" {
+  local_alias = {
+    access_key_id   = garage_key.app.id
+    alias           = "private-data"
+    all_permissions = true
+  }
+}
+
+# Bucket whose objects are deleted along with it
+resource "garage_bucket" "scratch" {
+  global_alias  = "scratch"
+  force_destroy = true
+}
@@ -0,0 +1,44 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint    = "http://localhost:3903"
+  token       = "your-admin-token-here"
+  s3_endpoint = "http://localhost:3900"
+}
+
+resource "garage_bucket" "uploads" {
+  global_alias = "uploads"
+}
+
+resource "garage_key" "admin" {
+  name = "uploads-admin"
+}
+
+resource "garage_bucket_permission" "admin" {
+  bucket_id     = garage_bucket.uploads.id
+  access_key_id = garage_key.admin.id
+  owner         = true
+}
+
+# Let the web application upload files directly from the browser
+resource "garage_bucket_cors_configuration" "uploads" {
+  bucket            = garage_bucket.uploads.full_global_alias
+  access_key_id     = garage_bucket_permission.admin.access_key_id
+  secret_access_key = garage_key.admin.secret_access_key
+
+  cors_rule = [
+    {
+      allowed_origins = ["https://app.example.com"]
+      allowed_methods = ["GET", "PUT", "POST"]
+      allowed_headers = ["*"]
+      expose_headers  = ["ETag"]
+      max_age_seconds = 3600
+    },
+  ]
+}
@@ -0,0 +1,4 @@
+#!/bin/bash
+
+# Garage bucket policies can be imported using the bucket ID
+terraform import garage_bucket_policy.example bucket-id
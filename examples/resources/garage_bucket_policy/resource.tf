@@ -0,0 +1,48 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+resource "garage_bucket" "production" {
+  global_alias = "production"
+}
+
+resource "garage_key" "app" {
+  name = "production-app"
+}
+
+resource "garage_key" "backup" {
+  name = "production-backup"
+}
+
+resource "garage_key" "admin" {
+  name = "production-admin"
+}
+
+# Exactly these keys have access to the bucket; any other grant is revoked
+resource "garage_bucket_policy" "production" {
+  bucket_id = garage_bucket.production.id
+
+  statement = [
+    {
+      key    = garage_key.app.id
+      access = "rw"
+    },
+    {
+      key    = garage_key.backup.id
+      access = "ro"
+    },
+    {
+      key    = garage_key.admin.id
+      access = "owner"
+    },
+  ]
+}
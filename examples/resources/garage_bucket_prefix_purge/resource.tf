@@ -0,0 +1,33 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint    = "http://localhost:3903"
+  token       = "your-admin-token-here"
+  s3_endpoint = "http://localhost:3900"
+}
+
+variable "environment" {
+  type    = string
+  default = "staging"
+}
+
+data "garage_bucket" "shared" {
+  global_alias = "shared-artifacts"
+}
+
+# Delete the objects of this environment when it is torn down
+resource "garage_bucket_prefix_purge" "environment" {
+  bucket_id = data.garage_bucket.shared.id
+  prefix    = "${var.environment}/"
+  dry_run   = false
+}
+
+output "environment_objects" {
+  value = garage_bucket_prefix_purge.environment.object_count
+}
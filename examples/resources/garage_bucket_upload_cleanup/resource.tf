@@ -0,0 +1,34 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+    time = {
+      source = "hashicorp/time"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+resource "garage_bucket" "uploads" {
+  global_alias = "uploads"
+}
+
+# Changes every day, so that each apply after that runs the cleanup again
+resource "time_rotating" "daily" {
+  rotation_days = 1
+}
+
+# Abort multipart uploads that were started more than three days ago
+resource "garage_bucket_upload_cleanup" "uploads" {
+  bucket_id  = garage_bucket.uploads.id
+  older_than = "72h"
+
+  triggers = {
+    rotation = time_rotating.daily.id
+  }
+}
@@ -0,0 +1,4 @@
+#!/bin/bash
+
+# The website configuration of a bucket can be imported using the bucket ID
+terraform import garage_bucket_website.example bucket-id
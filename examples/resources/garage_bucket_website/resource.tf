@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+# Bucket created elsewhere, leaving website hosting to garage_bucket_website
+resource "garage_bucket" "site" {
+  global_alias = "www.example.com"
+
+  lifecycle {
+    ignore_changes = [website_enabled, website_index_document, website_error_document]
+  }
+}
+
+# Serve the bucket as a website
+resource "garage_bucket_website" "site" {
+  bucket_id      = garage_bucket.site.id
+  index_document = "index.html"
+  error_document = "404.html"
+}
@@ -0,0 +1,2 @@
+# The layout of the cluster can be adopted with any import ID
+terraform import garage_cluster_layout.cluster cluster
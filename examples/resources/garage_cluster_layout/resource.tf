@@ -0,0 +1,36 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+# Three storage nodes in three zones, and a gateway
+resource "garage_cluster_layout" "cluster" {
+  zone_redundancy = 2
+
+  roles = {
+    "563e1ac825ee3323aa441e72c26d1030d6d4414aeb3dd25287c531e7fc2bc95d" = {
+      zone     = "dc1"
+      capacity = 1000000000000
+      tags     = ["rack-a"]
+    }
+    "86f0f26ae4afbd59aaf9cfb059eefac844951efd5b8caeec0d53f4ed6c85f332" = {
+      zone     = "dc2"
+      capacity = 1000000000000
+    }
+    "a15cbf2e5d2ec1b4e5c6d4e3e0f9b8a7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1" = {
+      zone     = "dc3"
+      capacity = 1000000000000
+    }
+    "f2e3d4c5b6a7980f1e2d3c4b5a69788f7e6d5c4b3a29180f7e6d5c4b3a291807" = {
+      zone = "dc1"
+    }
+  }
+}
@@ -0,0 +1,2 @@
+# Cluster nodes can be imported using their full address
+terraform import garage_cluster_node.storage_4 86f0f26ae4afbd59aaf9cfb059eefac844951efd5b8caeec0d53f4ed6c85f332@10.0.0.4:3901
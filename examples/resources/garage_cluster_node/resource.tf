@@ -0,0 +1,37 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+# Join a new node to the cluster, using the output of `garage node id` on it
+resource "garage_cluster_node" "storage_4" {
+  address = "86f0f26ae4afbd59aaf9cfb059eefac844951efd5b8caeec0d53f4ed6c85f332@10.0.0.4:3901"
+}
+
+# Give it a role in the same apply
+resource "garage_cluster_node_role" "storage_4" {
+  node_id  = garage_cluster_node.storage_4.id
+  zone     = "dc1"
+  capacity = 1000000000000
+}
+
+# Connect several nodes in a single call. Unreachable nodes are reported in
+# `nodes` instead of failing the apply, and retried on the next apply.
+resource "garage_cluster_node" "edge" {
+  addresses = [
+    "5d2c1c2b7a3e4f2b8e8a1d9f0c6b4a3e2d1c0b9a8f7e6d5c4b3a2f1e0d9c8b7a@10.0.1.1:3901",
+    "a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90@10.0.1.2:3901",
+  ]
+}
+
+output "unreachable_edge_nodes" {
+  value = [for node in garage_cluster_node.edge.nodes : node.address if !node.connected]
+}
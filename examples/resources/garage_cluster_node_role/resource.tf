@@ -0,0 +1,34 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+# Storage node with 1 TB of capacity
+resource "garage_cluster_node_role" "storage" {
+  node_id  = "563e1ac825ee3323aa441e72c26d1030d6d4414aeb3dd25287c531e7fc2bc95d"
+  zone     = "dc1"
+  capacity = 1000000000000
+  tags     = ["rack-a", "room-1"]
+
+  # Wait for data to move to the other nodes when this node is decommissioned
+  wait_for_drain = true
+  drain_timeout  = "2h"
+}
+
+# Gateway node that stores no data
+resource "garage_cluster_node_role" "gateway" {
+  node_id = "a2f3e1d4c5b6978867564534231201fedcba9876543210fedcba9876543210"
+  zone    = "dc1"
+}
+
+output "storage_node_tags" {
+  value = garage_cluster_node_role.storage.tags
+}
@@ -2,3 +2,6 @@
 
 # Garage access keys can be imported using the access key ID
 terraform import garage_key.example GKxxxxxxxxxxxxxxxxxxxx
+
+# or by name, or by a prefix of the access key ID, as long as a single key matches
+terraform import garage_key.example my-app-key
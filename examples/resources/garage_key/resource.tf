@@ -20,6 +20,24 @@ resource "garage_key" "example" {
 resource "garage_key" "unnamed" {
 }
 
+# One key per application, with collision-free generated names
+resource "garage_key" "per_app" {
+  for_each    = toset(["api", "worker"])
+  name_prefix = "${each.key}-"
+}
+
+# Access key allowed to create its own buckets
+resource "garage_key" "provisioner" {
+  name          = "provisioner"
+  create_bucket = true
+}
+
+# Temporary access key that stops working at the end of the year
+resource "garage_key" "contractor" {
+  name       = "contractor"
+  expiration = "2026-12-31T23:59:59Z"
+}
+
 # Import an existing key with predefined credentials
 resource "garage_key" "imported" {
   id                = "GK31c2f218a2e44f485b94239e"
@@ -33,6 +51,19 @@ resource "garage_key" "imported_no_name" {
   secret_access_key = "3c7d8e9f0a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0c1d2e3f4a5b6c7d"
 }
 
+# Import a key without storing its secret in the state (Terraform >= 1.11)
+variable "imported_secret" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+resource "garage_key" "imported_write_only" {
+  id                        = "GK8b1e4c0f2a3d4e5f6a7b8c9d"
+  secret_access_key_wo      = var.imported_secret
+  secret_access_key_version = 1
+}
+
 # Output the credentials
 output "access_key_id" {
   value = garage_key.example.id
@@ -0,0 +1,41 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+locals {
+  storage_node = {
+    node_id  = "563e1ac825ee3323aa441e72c26d1030d6d4414aeb3dd25287c531e7fc2bc95d"
+    zone     = "dc1"
+    capacity = 1000000000000
+  }
+}
+
+# Snapshot the metadata of every node whenever the layout below is about to change
+resource "garage_metadata_snapshot" "before_layout_change" {
+  triggers = {
+    zone     = local.storage_node.zone
+    capacity = tostring(local.storage_node.capacity)
+  }
+}
+
+resource "garage_cluster_node_role" "storage" {
+  node_id  = local.storage_node.node_id
+  zone     = local.storage_node.zone
+  capacity = local.storage_node.capacity
+
+  depends_on = [garage_metadata_snapshot.before_layout_change]
+}
+
+# Snapshot only the node the provider talks to
+resource "garage_metadata_snapshot" "self" {
+  node = "self"
+}
@@ -0,0 +1,2 @@
+# Node maintenance settings can be imported using a node ID or self
+terraform import garage_node_maintenance.example self
@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+# Resynchronize blocks quickly after adding a node
+resource "garage_node_maintenance" "self" {
+  node                = "self"
+  resync_tranquility  = 0
+  resync_worker_count = 4
+}
+
+# Keep scrubs light on a node with slow disks
+resource "garage_node_maintenance" "archive" {
+  node              = "563e1ac825ee3323aa441e72c26d1030d6d4414aeb3dd25287c531e7fc2bc95d"
+  scrub_tranquility = 8
+}
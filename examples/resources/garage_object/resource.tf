@@ -0,0 +1,52 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint    = "http://localhost:3903"
+  token       = "your-admin-token-here"
+  s3_endpoint = "http://localhost:3900"
+}
+
+resource "garage_bucket" "config" {
+  global_alias = "app-config"
+}
+
+resource "garage_key" "deployer" {
+  name = "deployer"
+}
+
+resource "garage_bucket_permission" "deployer" {
+  bucket_id     = garage_bucket.config.id
+  access_key_id = garage_key.deployer.id
+  read          = true
+  write         = true
+}
+
+# Object with inline content
+resource "garage_object" "settings" {
+  bucket            = garage_bucket.config.full_global_alias
+  key               = "settings.json"
+  access_key_id     = garage_bucket_permission.deployer.access_key_id
+  secret_access_key = garage_key.deployer.secret_access_key
+  content           = jsonencode({ log_level = "info" })
+}
+
+# Object uploaded from a local file
+resource "garage_object" "logo" {
+  bucket            = garage_bucket.config.full_global_alias
+  key               = "assets/logo.png"
+  access_key_id     = garage_bucket_permission.deployer.access_key_id
+  secret_access_key = garage_key.deployer.secret_access_key
+  source            = "${path.module}/logo.png"
+  source_hash       = filemd5("${path.module}/logo.png")
+  cache_control     = "max-age=86400"
+
+  metadata = {
+    release = "2024-06"
+  }
+}
@@ -0,0 +1,47 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+    time = {
+      source = "hashicorp/time"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+# Changes every week, so that each apply after that launches the repairs again
+resource "time_rotating" "weekly" {
+  rotation_days = 7
+}
+
+# Verify the integrity of every data block on every node
+resource "garage_repair" "scrub" {
+  repair_type   = "scrub"
+  scrub_command = "start"
+
+  triggers = {
+    rotation = time_rotating.weekly.id
+  }
+}
+
+# Delete the versions of deleted objects on the node the provider talks to,
+# and wait for the repair to finish before dependent resources are applied
+resource "garage_repair" "versions" {
+  node                = "self"
+  repair_type         = "versions"
+  wait_for_completion = true
+
+  triggers = {
+    rotation = time_rotating.weekly.id
+  }
+
+  timeouts = {
+    create = "1h"
+    update = "1h"
+  }
+}
@@ -0,0 +1,36 @@
+terraform {
+  required_providers {
+    garage = {
+      source = "jkossis/garage"
+    }
+  }
+}
+
+provider "garage" {
+  endpoint = "http://localhost:3903"
+  token    = "your-admin-token-here"
+}
+
+# Host a static site on www.example.com
+resource "garage_static_website" "example" {
+  domain         = "www.example.com"
+  index_document = "index.html"
+  error_document = "404.html"
+}
+
+# Credentials for the CI job that publishes the site
+output "publish_access_key_id" {
+  value = garage_static_website.example.access_key_id
+}
+
+output "publish_secret_access_key" {
+  value     = garage_static_website.example.secret_access_key
+  sensitive = true
+}
+
+# Serve a website on its apex domain too, and check that Garage serves both
+resource "garage_static_website" "company" {
+  domain         = "www.company.com"
+  custom_domains = ["company.com"]
+  verify_domains = true
+}
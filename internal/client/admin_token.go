@@ -0,0 +1,172 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// AdminTokenInfo represents an admin API token.
+type AdminTokenInfo struct {
+	ID         *string  `json:"id,omitempty"`
+	Name       string   `json:"name"`
+	Created    *string  `json:"created,omitempty"`
+	Expiration *string  `json:"expiration,omitempty"`
+	Expired    bool     `json:"expired"`
+	Scope      []string `json:"scope"`
+}
+
+// CreateAdminTokenResponse represents a newly created admin API token. The
+// secret token is only returned on creation.
+type CreateAdminTokenResponse struct {
+	AdminTokenInfo
+	SecretToken string `json:"secretToken"`
+}
+
+// UpdateAdminTokenRequest represents the request to create or update an admin
+// API token. Setting NeverExpires removes the expiration of the token. A nil
+// Scope leaves the scope unchanged, while an empty one removes every endpoint
+// from it.
+type UpdateAdminTokenRequest struct {
+	Name         *string   `json:"name,omitempty"`
+	Expiration   *string   `json:"expiration,omitempty"`
+	NeverExpires bool      `json:"neverExpires,omitempty"`
+	Scope        *[]string `json:"scope,omitempty"`
+}
+
+// GetCurrentAdminTokenInfo gets information about the token used by the client.
+func (c *Client) GetCurrentAdminTokenInfo(ctx context.Context) (*AdminTokenInfo, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/v2/GetCurrentAdminTokenInfo", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var info AdminTokenInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &info, nil
+}
+
+// CreateAdminToken creates a new admin API token.
+func (c *Client) CreateAdminToken(ctx context.Context, req UpdateAdminTokenRequest) (*CreateAdminTokenResponse, error) {
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/CreateAdminToken", nil, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var token CreateAdminTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &token, nil
+}
+
+// ListAdminTokens lists all admin API tokens.
+func (c *Client) ListAdminTokens(ctx context.Context) ([]AdminTokenInfo, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/v2/ListAdminTokens", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var tokens []AdminTokenInfo
+	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return tokens, nil
+}
+
+// GetAdminTokenInfo gets information about an admin API token. It returns
+// nil if the token does not exist.
+func (c *Client) GetAdminTokenInfo(ctx context.Context, id string) (*AdminTokenInfo, error) {
+	if err := validateID("admin token ID", id); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodGet, "/v2/GetAdminTokenInfo", url.Values{"id": {id}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var info AdminTokenInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &info, nil
+}
+
+// UpdateAdminToken updates an admin API token. The secret token is unchanged.
+func (c *Client) UpdateAdminToken(ctx context.Context, id string, req UpdateAdminTokenRequest) (*AdminTokenInfo, error) {
+	if err := validateID("admin token ID", id); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/UpdateAdminToken", url.Values{"id": {id}}, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var info AdminTokenInfo
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &info, nil
+}
+
+// DeleteAdminToken deletes an admin API token.
+func (c *Client) DeleteAdminToken(ctx context.Context, id string) error {
+	if err := validateID("admin token ID", id); err != nil {
+		return err
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/DeleteAdminToken", url.Values{"id": {id}}, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return newAPIError(resp)
+	}
+
+	return nil
+}
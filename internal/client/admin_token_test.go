@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetCurrentAdminTokenInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		if r.URL.Path != "/v2/GetCurrentAdminTokenInfo" {
+			t.Errorf("Expected path /v2/GetCurrentAdminTokenInfo, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"id": "token-1",
+			"name": "terraform",
+			"created": "2025-01-01T00:00:00Z",
+			"expiration": null,
+			"expired": false,
+			"scope": ["ListBuckets", "GetBucketInfo"]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	info, err := client.GetCurrentAdminTokenInfo(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if info.ID == nil || *info.ID != "token-1" {
+		t.Errorf("Expected ID token-1, got %v", info.ID)
+	}
+
+	if info.Expiration != nil {
+		t.Errorf("Expected no expiration, got %s", *info.Expiration)
+	}
+
+	if len(info.Scope) != 2 || info.Scope[1] != "GetBucketInfo" {
+		t.Errorf("Expected scope [ListBuckets GetBucketInfo], got %v", info.Scope)
+	}
+}
+
+func TestCreateAdminToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/CreateAdminToken" {
+			t.Errorf("Expected path /v2/CreateAdminToken, got %s", r.URL.Path)
+		}
+
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+		if body["name"] != "ci" {
+			t.Errorf("Expected name ci, got %v", body["name"])
+		}
+		if _, ok := body["neverExpires"]; ok {
+			t.Errorf("Expected neverExpires to be omitted, got %v", body["neverExpires"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"id": "token-2",
+			"name": "ci",
+			"created": "2025-01-01T00:00:00Z",
+			"expiration": "2030-01-01T00:00:00Z",
+			"expired": false,
+			"scope": ["*"],
+			"secretToken": "token-2.secret"
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	name := "ci"
+	expiration := "2030-01-01T00:00:00Z"
+	token, err := client.CreateAdminToken(context.Background(), UpdateAdminTokenRequest{Name: &name, Expiration: &expiration})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if token.SecretToken != "token-2.secret" {
+		t.Errorf("Expected secret token, got %q", token.SecretToken)
+	}
+	if token.ID == nil || *token.ID != "token-2" {
+		t.Errorf("Expected ID token-2, got %v", token.ID)
+	}
+}
+
+func TestListAdminTokens(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		if r.URL.Path != "/v2/ListAdminTokens" {
+			t.Errorf("Expected path /v2/ListAdminTokens, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"id": "token-1", "name": "monitoring", "expired": false, "scope": ["Metrics"]},
+			{"name": "admin token from configuration file", "expired": false, "scope": ["*"]}
+		]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	tokens, err := client.ListAdminTokens(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(tokens) != 2 {
+		t.Fatalf("Expected 2 tokens, got %d", len(tokens))
+	}
+	if tokens[0].ID == nil || *tokens[0].ID != "token-1" || tokens[0].Name != "monitoring" {
+		t.Errorf("Unexpected first token: %+v", tokens[0])
+	}
+	if tokens[1].ID != nil {
+		t.Errorf("Expected the configuration file token to have no ID, got %s", *tokens[1].ID)
+	}
+}
+
+func TestGetAdminTokenInfo_notFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id := r.URL.Query().Get("id"); id != "missing" {
+			t.Errorf("Expected id missing, got %s", id)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	info, err := client.GetAdminTokenInfo(context.Background(), "missing")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if info != nil {
+		t.Errorf("Expected nil for a missing token, got %+v", info)
+	}
+}
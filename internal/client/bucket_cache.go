@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// BucketInfoCacheTTL is how long GetBucketInfoCached reuses bucket information.
+// It covers a refresh of many resources reading the same bucket while keeping
+// changes made outside of Terraform visible on the next run.
+var BucketInfoCacheTTL = 30 * time.Second
+
+// bucketCache holds recently fetched bucket information by bucket ID. Any
+// change made through the client to a bucket evicts it from the cache and
+// moves the bucket to a new generation, so that a fetch started before the
+// change does not store what it read once it completes.
+type bucketCache struct {
+	mu          sync.Mutex
+	entries     map[string]bucketCacheEntry
+	generations map[string]uint64
+	// epoch moves every bucket to a new generation at once
+	epoch uint64
+}
+
+type bucketCacheEntry struct {
+	bucket  Bucket
+	fetched time.Time
+}
+
+func (bc *bucketCache) get(id string) (*Bucket, bool) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	entry, ok := bc.entries[id]
+	if !ok || time.Since(entry.fetched) > BucketInfoCacheTTL {
+		return nil, false
+	}
+
+	return entry.bucket.clone(), true
+}
+
+// generation returns the current generation of a bucket. It grows with every
+// change to the bucket.
+func (bc *bucketCache) generation(id string) uint64 {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	return bc.epoch + bc.generations[id]
+}
+
+// put stores a bucket fetched at the given generation, unless the bucket has
+// changed since.
+func (bc *bucketCache) put(bucket *Bucket, generation uint64) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	if bc.epoch+bc.generations[bucket.ID] != generation {
+		return
+	}
+
+	if bc.entries == nil {
+		bc.entries = make(map[string]bucketCacheEntry)
+	}
+	bc.entries[bucket.ID] = bucketCacheEntry{bucket: *bucket.clone(), fetched: time.Now()}
+}
+
+func (bc *bucketCache) invalidate(id string) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	delete(bc.entries, id)
+	if bc.generations == nil {
+		bc.generations = make(map[string]uint64)
+	}
+	bc.generations[id]++
+}
+
+func (bc *bucketCache) clear() {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	bc.entries = nil
+	bc.epoch++
+}
+
+// GetBucketInfoCached gets information about a bucket by ID, reusing the
+// result of a recent call for the same bucket. It suits reads of the same
+// bucket by many resources, such as permissions refreshing in one plan.
+// Like GetBucketInfo, it returns nil if the bucket does not exist.
+func (c *Client) GetBucketInfoCached(ctx context.Context, id string) (*Bucket, error) {
+	if bucket, ok := c.bucketCache.get(id); ok {
+		return bucket, nil
+	}
+
+	if err := validateID("bucket ID", id); err != nil {
+		return nil, err
+	}
+
+	// Callers only share a fetch started at the same generation, so that
+	// none is handed a bucket read before its latest change
+	generation := c.bucketCache.generation(id)
+	query := url.Values{"id": {id}}
+	key := fmt.Sprintf("GetBucketInfoCached?%s&generation=%d", query.Encode(), generation)
+
+	v, err := c.sharedCall(ctx, key, func(ctx context.Context) (interface{}, error) {
+		bucket, err := c.getBucketInfo(ctx, query)
+		if err == nil && bucket != nil {
+			c.bucketCache.put(bucket, generation)
+		}
+		return bucket, err
+	})
+	if err != nil || v.(*Bucket) == nil {
+		return nil, err
+	}
+
+	// Callers sharing a request each get their own copy
+	return v.(*Bucket).clone(), nil
+}
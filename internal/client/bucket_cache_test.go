@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestGetBucketInfoCached(t *testing.T) {
+	var infoRequests int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/v2/GetBucketInfo":
+			infoRequests++
+			_ = json.NewEncoder(w).Encode(Bucket{ID: r.URL.Query().Get("id")})
+		case "/v2/AllowBucketKey":
+			_ = json.NewEncoder(w).Encode(Bucket{ID: "bucket-1"})
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		bucket, err := client.GetBucketInfoCached(ctx, "bucket-1")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if bucket.ID != "bucket-1" {
+			t.Errorf("Expected bucket-1, got %s", bucket.ID)
+		}
+	}
+
+	if infoRequests != 1 {
+		t.Errorf("Expected 1 GetBucketInfo request, got %d", infoRequests)
+	}
+
+	// Other buckets are fetched separately
+	if _, err := client.GetBucketInfoCached(ctx, "bucket-2"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if infoRequests != 2 {
+		t.Errorf("Expected 2 GetBucketInfo requests, got %d", infoRequests)
+	}
+
+	// Changing a bucket evicts it from the cache
+	_, err := client.AllowBucketKey(ctx, BucketKeyPermRequest{BucketID: "bucket-1", AccessKeyID: "key-1"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, err := client.GetBucketInfoCached(ctx, "bucket-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if infoRequests != 3 {
+		t.Errorf("Expected bucket-1 to be fetched again after a change, got %d requests", infoRequests)
+	}
+}
+
+func TestGetBucketInfoCached_changeDuringFetch(t *testing.T) {
+	var mu sync.Mutex
+	var infoRequests int
+	fetching := make(chan struct{})
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/v2/GetBucketInfo":
+			mu.Lock()
+			infoRequests++
+			first := infoRequests == 1
+			mu.Unlock()
+
+			// The first fetch completes only after the bucket has changed
+			if first {
+				close(fetching)
+				<-release
+			}
+			_ = json.NewEncoder(w).Encode(Bucket{ID: "bucket-1"})
+		case "/v2/AllowBucketKey":
+			_ = json.NewEncoder(w).Encode(Bucket{ID: "bucket-1"})
+		default:
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	ctx := context.Background()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.GetBucketInfoCached(ctx, "bucket-1")
+		done <- err
+	}()
+
+	<-fetching
+	if _, err := client.AllowBucketKey(ctx, BucketKeyPermRequest{BucketID: "bucket-1", AccessKeyID: "key-1"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	close(release)
+
+	if err := <-done; err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// The bucket read before the change is not cached
+	if _, err := client.GetBucketInfoCached(ctx, "bucket-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if infoRequests != 2 {
+		t.Errorf("Expected bucket-1 to be fetched again after a change during a fetch, got %d requests", infoRequests)
+	}
+}
@@ -6,11 +6,18 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"slices"
 	"strings"
+	"time"
+
+	"golang.org/x/sync/singleflight"
 )
 
 // Client is a Garage API client.
@@ -18,15 +25,185 @@ type Client struct {
 	endpoint   string
 	token      string
 	httpClient *http.Client
+	s3Endpoint string
+	s3Region   string
+
+	// s3AccessKeyID and s3SecretAccessKey authenticate S3 and K2V clients
+	// created without an access key of their own.
+	s3AccessKeyID     string
+	s3SecretAccessKey string
+
+	k2vEndpoint string
+
+	failoverEndpoints []string
+	order             endpointOrder
+
+	validateTokenScope bool
+
+	keyNamePrefix     string
+	bucketAliasPrefix string
+
+	// inflight deduplicates identical concurrent GET requests, so that
+	// resources refreshing the same bucket or key share one API call.
+	inflight singleflight.Group
+
+	bucketCache bucketCache
+
+	callStats *CallStats
+
+	retry retryPolicy
+
+	tlsConfig *tls.Config
+	proxyURL  *url.URL
+	headers   map[string]string
+
+	requestTimeout time.Duration
+
+	logHTTP bool
+}
+
+// Option configures optional behavior of a Client.
+type Option func(*Client)
+
+// WithS3Endpoint sets the Garage S3 API endpoint and region used by S3 clients
+// created with NewS3Client.
+func WithS3Endpoint(endpoint, region string) Option {
+	return func(c *Client) {
+		c.s3Endpoint = strings.TrimSuffix(endpoint, "/")
+		c.s3Region = region
+	}
+}
+
+// WithS3Credentials sets the access key used by S3 and K2V clients created
+// without one.
+func WithS3Credentials(accessKeyID, secretAccessKey string) Option {
+	return func(c *Client) {
+		c.s3AccessKeyID = accessKeyID
+		c.s3SecretAccessKey = secretAccessKey
+	}
+}
+
+// WithTokenScopeValidation makes ValidatesTokenScope report that callers
+// should check the scope of the admin token before making changes.
+func WithTokenScopeValidation(enabled bool) Option {
+	return func(c *Client) {
+		c.validateTokenScope = enabled
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for Admin API and S3 requests,
+// for instance to route them through a Recorder in tests.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used for Admin API and S3
+// requests, for instance to trust a private CA or present a client
+// certificate. It is ignored when an HTTP client is set with WithHTTPClient.
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(c *Client) {
+		c.tlsConfig = tlsConfig
+	}
+}
+
+// WithProxy sends Admin API and S3 requests through the HTTP(S) proxy at
+// proxyURL instead of the proxy set in the environment. It is ignored when an
+// HTTP client is set with WithHTTPClient.
+func WithProxy(proxyURL *url.URL) Option {
+	return func(c *Client) {
+		c.proxyURL = proxyURL
+	}
+}
+
+// WithHeaders adds headers to every Admin API request, for instance the
+// credentials expected by an authenticating proxy in front of the Admin API.
+// They cannot replace the headers set by the client, such as Authorization.
+func WithHeaders(headers map[string]string) Option {
+	return func(c *Client) {
+		c.headers = headers
+	}
+}
+
+// DefaultRequestTimeout is the default time limit of a single Admin API or
+// S3 request.
+const DefaultRequestTimeout = 1 * time.Minute
+
+// WithRequestTimeout limits how long a single request may take, including
+// reading the response body, so that an unresponsive Garage node fails the
+// request instead of hanging. Zero means no limit. It is ignored when an
+// HTTP client is set with WithHTTPClient.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.requestTimeout = timeout
+	}
+}
+
+// WithHTTPLogging logs every Admin API and S3 request and its response at
+// TRACE level through tflog, using a LoggingTransport. It is ignored when an
+// HTTP client is set with WithHTTPClient.
+func WithHTTPLogging(enabled bool) Option {
+	return func(c *Client) {
+		c.logHTTP = enabled
+	}
+}
+
+// WithNamePrefixes sets the prefixes the provider prepends to the names of the
+// keys and to the global aliases of the buckets it creates, so that several
+// environments can share a cluster. The client itself does not apply them.
+func WithNamePrefixes(keyName, bucketAlias string) Option {
+	return func(c *Client) {
+		c.keyNamePrefix = keyName
+		c.bucketAliasPrefix = bucketAlias
+	}
 }
 
 // NewClient creates a new Garage API client.
-func NewClient(endpoint, token string) *Client {
-	return &Client{
-		endpoint:   strings.TrimSuffix(endpoint, "/"),
-		token:      token,
-		httpClient: http.DefaultClient,
+func NewClient(endpoint, token string, opts ...Option) *Client {
+	c := &Client{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		token:    token,
+		s3Region: DefaultS3Region,
+	}
+
+	for _, opt := range opts {
+		opt(c)
 	}
+
+	if c.httpClient == nil {
+		transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+		if defaultTransport, ok := http.DefaultTransport.(*http.Transport); ok {
+			transport = defaultTransport.Clone()
+		}
+		transport.TLSClientConfig = c.tlsConfig
+		if c.proxyURL != nil {
+			transport.Proxy = http.ProxyURL(c.proxyURL)
+		}
+		var roundTripper http.RoundTripper = transport
+		if c.logHTTP {
+			roundTripper = NewLoggingTransport(transport)
+		}
+		c.httpClient = &http.Client{Transport: roundTripper, Timeout: c.requestTimeout}
+	}
+
+	return c
+}
+
+// ValidatesTokenScope reports whether token scope validation is enabled.
+func (c *Client) ValidatesTokenScope() bool {
+	return c.validateTokenScope
+}
+
+// KeyNamePrefix returns the prefix of the names of created keys.
+func (c *Client) KeyNamePrefix() string {
+	return c.keyNamePrefix
+}
+
+// BucketAliasPrefix returns the prefix of the global aliases of created
+// buckets.
+func (c *Client) BucketAliasPrefix() string {
+	return c.bucketAliasPrefix
 }
 
 // Bucket represents a Garage bucket.
@@ -42,6 +219,28 @@ type Bucket struct {
 	Quotas            *BucketQuotas   `json:"quotas,omitempty"`
 }
 
+// clone returns a deep copy of the bucket.
+func (b *Bucket) clone() *Bucket {
+	bucket := *b
+	bucket.GlobalAliases = slices.Clone(b.GlobalAliases)
+	if b.WebsiteConfig != nil {
+		config := *b.WebsiteConfig
+		bucket.WebsiteConfig = &config
+	}
+	if b.Quotas != nil {
+		quotas := BucketQuotas{MaxSize: clonePtr(b.Quotas.MaxSize), MaxObjects: clonePtr(b.Quotas.MaxObjects)}
+		bucket.Quotas = &quotas
+	}
+	if b.Keys != nil {
+		bucket.Keys = make([]BucketKeyInfo, len(b.Keys))
+		for i, key := range b.Keys {
+			key.BucketLocalAliases = slices.Clone(key.BucketLocalAliases)
+			bucket.Keys[i] = key
+		}
+	}
+	return &bucket
+}
+
 // WebsiteConfig represents website configuration for a bucket.
 type WebsiteConfig struct {
 	IndexDocument string `json:"indexDocument"`
@@ -50,9 +249,10 @@ type WebsiteConfig struct {
 
 // BucketKeyInfo represents key permissions on a bucket.
 type BucketKeyInfo struct {
-	AccessKeyID string      `json:"accessKeyId"`
-	Name        string      `json:"name"`
-	Permissions Permissions `json:"permissions"`
+	AccessKeyID        string      `json:"accessKeyId"`
+	Name               string      `json:"name"`
+	Permissions        Permissions `json:"permissions"`
+	BucketLocalAliases []string    `json:"bucketLocalAliases"`
 }
 
 // Permissions represents the permissions a key has on a bucket.
@@ -70,21 +270,29 @@ type BucketQuotas struct {
 
 // CreateBucketRequest represents the request to create a bucket.
 type CreateBucketRequest struct {
-	GlobalAlias *string `json:"globalAlias,omitempty"`
-	LocalAlias  *struct {
-		AccessKeyID string `json:"accessKeyId"`
-		Alias       string `json:"alias"`
-	} `json:"localAlias,omitempty"`
+	GlobalAlias *string                 `json:"globalAlias,omitempty"`
+	LocalAlias  *CreateBucketLocalAlias `json:"localAlias,omitempty"`
+}
+
+// CreateBucketLocalAlias represents an alias of a new bucket local to an
+// access key, and the permissions the key gets on the bucket.
+type CreateBucketLocalAlias struct {
+	AccessKeyID string      `json:"accessKeyId"`
+	Alias       string      `json:"alias"`
+	Allow       Permissions `json:"allow"`
 }
 
 // UpdateBucketRequest represents the request to update a bucket.
 type UpdateBucketRequest struct {
-	WebsiteAccess *struct {
-		Enabled       bool    `json:"enabled"`
-		IndexDocument *string `json:"indexDocument,omitempty"`
-		ErrorDocument *string `json:"errorDocument,omitempty"`
-	} `json:"websiteAccess,omitempty"`
-	Quotas *BucketQuotas `json:"quotas,omitempty"`
+	WebsiteAccess *UpdateBucketWebsiteAccess `json:"websiteAccess,omitempty"`
+	Quotas        *BucketQuotas              `json:"quotas,omitempty"`
+}
+
+// UpdateBucketWebsiteAccess represents the website settings of an update bucket request.
+type UpdateBucketWebsiteAccess struct {
+	Enabled       bool    `json:"enabled"`
+	IndexDocument *string `json:"indexDocument,omitempty"`
+	ErrorDocument *string `json:"errorDocument,omitempty"`
 }
 
 // DeleteBucketRequest represents the request to delete a bucket.
@@ -117,6 +325,23 @@ type AccessKey struct {
 	Buckets         []KeyBucketInfo `json:"buckets"`
 }
 
+// clone returns a deep copy of the access key.
+func (k *AccessKey) clone() *AccessKey {
+	key := *k
+	key.Created = clonePtr(k.Created)
+	key.Expiration = clonePtr(k.Expiration)
+	key.SecretAccessKey = clonePtr(k.SecretAccessKey)
+	if k.Buckets != nil {
+		key.Buckets = make([]KeyBucketInfo, len(k.Buckets))
+		for i, bucket := range k.Buckets {
+			bucket.GlobalAliases = slices.Clone(bucket.GlobalAliases)
+			bucket.LocalAliases = slices.Clone(bucket.LocalAliases)
+			key.Buckets[i] = bucket
+		}
+	}
+	return &key
+}
+
 // KeyPermissions represents the permissions a key has.
 type KeyPermissions struct {
 	CreateBucket bool `json:"createBucket"`
@@ -130,6 +355,15 @@ type KeyBucketInfo struct {
 	Permissions   Permissions `json:"permissions"`
 }
 
+// KeyListItem represents an access key as returned by ListKeys.
+type KeyListItem struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	Created    *string `json:"created,omitempty"`
+	Expiration *string `json:"expiration,omitempty"`
+	Expired    bool    `json:"expired"`
+}
+
 // CreateKeyRequest represents the request to create an access key.
 type CreateKeyRequest struct {
 	Name       *string `json:"name,omitempty"`
@@ -143,75 +377,227 @@ type ImportKeyRequest struct {
 	Name            *string `json:"name,omitempty"`
 }
 
+// UpdateKeyRequest represents the request to update an access key. Setting
+// NeverExpires removes the expiration of the key. Permissions set in Allow are
+// granted and permissions set in Deny are revoked.
+type UpdateKeyRequest struct {
+	Name         *string         `json:"name,omitempty"`
+	Expiration   *string         `json:"expiration,omitempty"`
+	NeverExpires bool            `json:"neverExpires,omitempty"`
+	Allow        *KeyPermissions `json:"allow,omitempty"`
+	Deny         *KeyPermissions `json:"deny,omitempty"`
+}
+
 // DeleteKeyRequest represents the request to delete an access key.
 type DeleteKeyRequest struct {
 	ID string `json:"id"`
 }
 
-// GetKeyInfoRequest represents the request to get key info.
+// GetKeyInfoRequest represents the request to get key info. Search looks the
+// key up by a prefix of its ID or by its name instead of by its exact ID.
 type GetKeyInfoRequest struct {
-	ID string `json:"id"`
+	ID     string `json:"id"`
+	Search string `json:"search,omitempty"`
 }
 
-// doRequest makes an HTTP request to the Garage API.
-func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
-	var reqBody io.Reader
+// validateID checks an ID before it is sent as a query parameter, so that an
+// empty or malformed ID fails early instead of selecting the wrong object.
+// Garage IDs are hexadecimal, optionally with a prefix such as GK for keys.
+func validateID(kind, id string) error {
+	if id == "" {
+		return fmt.Errorf("the %s must not be empty", kind)
+	}
+
+	for _, r := range id {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != '-' && r != '_' && r != '.' {
+			return fmt.Errorf("invalid %s %q: only letters, digits, '-', '_' and '.' are allowed", kind, id)
+		}
+	}
+
+	return nil
+}
+
+// validateNode checks the node parameter of node-scoped requests, which is a
+// node ID, LocalNode or AllNodes.
+func validateNode(node string) error {
+	if node == LocalNode || node == AllNodes {
+		return nil
+	}
+
+	return validateID("node ID", node)
+}
+
+// doRequest makes an HTTP request to the Garage API endpoint at path, with
+// the query parameters encoded from query.
+func (c *Client) doRequest(ctx context.Context, method, path string, query url.Values, body interface{}) (*http.Response, error) {
+	var jsonData []byte
 	if body != nil {
-		jsonData, err := json.Marshal(body)
+		var err error
+		jsonData, err = json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
-		reqBody = bytes.NewBuffer(jsonData)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	// The endpoint is chosen when sending, so that requests can fail over
+	requestURL := path
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("Content-Type", "application/json")
+	// Each attempt needs a new request, as sending one consumes its body
+	newRequest := func() (*http.Request, error) {
+		var reqBody io.Reader
+		if jsonData != nil {
+			reqBody = bytes.NewReader(jsonData)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, requestURL, reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
 
-	resp, err := c.httpClient.Do(req)
+		for name, value := range c.headers {
+			req.Header.Set(name, value)
+		}
+
+		req.Header.Set("Authorization", "Bearer "+c.token)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept-Encoding", "gzip")
+
+		return req, nil
+	}
+
+	record := func(duration time.Duration) {
+		c.recordCall(ctx, path, duration)
+	}
+
+	resp, err := c.retry.do(ctx, c.send, newRequest, record)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return nil, err
+	}
+
+	if err := decompressBody(resp); err != nil {
+		return nil, err
 	}
 
 	return resp, nil
 }
 
+// sharedCall runs fn once for all callers passing the same key at the same
+// time. fn runs with a context that is not canceled along with the caller's,
+// so that the first caller giving up does not fail the others, and that is
+// bounded by the time a request may take with its retries. Each caller still
+// stops waiting when its own context is done. The calls fn makes are
+// recorded in the statistics of the operation of every caller it served.
+func (c *Client) sharedCall(ctx context.Context, key string, fn func(context.Context) (interface{}, error)) (interface{}, error) {
+	ch := c.inflight.DoChan(key, func() (interface{}, error) {
+		stats := &CallStats{}
+		sharedCtx := ContextWithCallStats(context.WithoutCancel(ctx), stats)
+		sharedCtx, cancel := context.WithTimeout(sharedCtx, c.CallTimeout())
+		defer cancel()
+
+		v, err := fn(sharedCtx)
+		return sharedResult{value: v, stats: stats}, err
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-ch:
+		shared := result.Val.(sharedResult)
+		if stats, ok := ctx.Value(callStatsKey{}).(*CallStats); ok {
+			stats.add(shared.stats)
+		}
+		return shared.value, result.Err
+	}
+}
+
+// sharedResult is the result of a shared call, along with the calls made to
+// get it.
+type sharedResult struct {
+	value interface{}
+	stats *CallStats
+}
+
+// CallTimeout returns how long a request may take with its retries.
+func (c *Client) CallTimeout() time.Duration {
+	timeout := c.requestTimeout
+	if timeout == 0 {
+		timeout = DefaultRequestTimeout
+	}
+
+	retries := time.Duration(c.retry.maxRetries)
+	return (retries+1)*timeout + retries*c.retry.waitMax
+}
+
+// clonePtr returns a copy of the value p points to, or nil if p is nil.
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
 // ListBuckets lists all buckets.
 func (c *Client) ListBuckets(ctx context.Context) ([]Bucket, error) {
-	resp, err := c.doRequest(ctx, http.MethodGet, "/v2/ListBuckets", nil)
+	buckets := []Bucket{}
+	err := c.EachBucket(ctx, func(bucket Bucket) error {
+		buckets = append(buckets, bucket)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	return buckets, nil
+}
+
+// EachBucket calls fn for every bucket as the bucket list is received, without
+// holding the whole list in memory. Iteration stops at the first error from fn.
+func (c *Client) EachBucket(ctx context.Context, fn func(Bucket) error) error {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/v2/ListBuckets", nil, nil)
+	if err != nil {
+		return err
 	}
+	defer resp.Body.Close()
 
-	var buckets []Bucket
-	if err := json.NewDecoder(resp.Body).Decode(&buckets); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp)
 	}
 
-	return buckets, nil
+	return decodeJSONArray(resp.Body, fn)
 }
 
 // GetBucketInfo gets information about a specific bucket.
 func (c *Client) GetBucketInfo(ctx context.Context, req GetBucketInfoRequest) (*Bucket, error) {
-	// Build query parameters
-	path := "/v2/GetBucketInfo?"
-	if req.ID != nil {
-		path += "id=" + *req.ID
-	} else if req.GlobalAlias != nil {
-		path += "globalAlias=" + *req.GlobalAlias
+	query := url.Values{}
+	switch {
+	case req.ID != nil:
+		if err := validateID("bucket ID", *req.ID); err != nil {
+			return nil, err
+		}
+		query.Set("id", *req.ID)
+	case req.GlobalAlias != nil && *req.GlobalAlias != "":
+		query.Set("globalAlias", *req.GlobalAlias)
+	default:
+		return nil, errors.New("either the bucket ID or the global alias must be set")
+	}
+
+	v, err := c.sharedCall(ctx, "GetBucketInfo?"+query.Encode(), func(ctx context.Context) (interface{}, error) {
+		return c.getBucketInfo(ctx, query)
+	})
+	if err != nil || v.(*Bucket) == nil {
+		return nil, err
 	}
 
-	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+	// Callers sharing a request each get their own copy
+	return v.(*Bucket).clone(), nil
+}
+
+func (c *Client) getBucketInfo(ctx context.Context, query url.Values) (*Bucket, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/v2/GetBucketInfo", query, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -222,8 +608,7 @@ func (c *Client) GetBucketInfo(ctx context.Context, req GetBucketInfoRequest) (*
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp)
 	}
 
 	var bucket Bucket
@@ -236,15 +621,14 @@ func (c *Client) GetBucketInfo(ctx context.Context, req GetBucketInfoRequest) (*
 
 // CreateBucket creates a new bucket.
 func (c *Client) CreateBucket(ctx context.Context, req CreateBucketRequest) (*Bucket, error) {
-	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/CreateBucket", req)
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/CreateBucket", nil, req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp)
 	}
 
 	var bucket Bucket
@@ -257,18 +641,21 @@ func (c *Client) CreateBucket(ctx context.Context, req CreateBucketRequest) (*Bu
 
 // UpdateBucket updates an existing bucket.
 func (c *Client) UpdateBucket(ctx context.Context, bucketID string, req UpdateBucketRequest) (*Bucket, error) {
-	// The UpdateBucket endpoint requires the bucket ID as a query parameter
-	path := fmt.Sprintf("/v2/UpdateBucket?id=%s", bucketID)
+	defer c.bucketCache.invalidate(bucketID)
+
+	if err := validateID("bucket ID", bucketID); err != nil {
+		return nil, err
+	}
 
-	resp, err := c.doRequest(ctx, http.MethodPost, path, req)
+	// The UpdateBucket endpoint requires the bucket ID as a query parameter
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/UpdateBucket", url.Values{"id": {bucketID}}, req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp)
 	}
 
 	var bucket Bucket
@@ -281,60 +668,111 @@ func (c *Client) UpdateBucket(ctx context.Context, bucketID string, req UpdateBu
 
 // DeleteBucket deletes a bucket.
 func (c *Client) DeleteBucket(ctx context.Context, req DeleteBucketRequest) error {
-	// Build query parameters
-	path := fmt.Sprintf("/v2/DeleteBucket?id=%s", req.ID)
+	defer c.bucketCache.invalidate(req.ID)
 
-	resp, err := c.doRequest(ctx, http.MethodPost, path, nil)
+	if err := validateID("bucket ID", req.ID); err != nil {
+		return err
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/DeleteBucket", url.Values{"id": {req.ID}}, nil)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return newAPIError(resp)
 	}
 
 	return nil
 }
 
-// AddBucketAlias adds a global alias to a bucket.
-func (c *Client) AddBucketAlias(ctx context.Context, bucketID, alias string) error {
-	req := map[string]string{
-		"id":    bucketID,
-		"alias": alias,
-	}
+// CleanupIncompleteUploadsRequest represents the request to abort the
+// unfinished multipart uploads of a bucket.
+type CleanupIncompleteUploadsRequest struct {
+	BucketID      string `json:"bucketId"`
+	OlderThanSecs int64  `json:"olderThanSecs"`
+}
+
+// CleanupIncompleteUploadsResponse represents the result of an upload cleanup.
+type CleanupIncompleteUploadsResponse struct {
+	UploadsDeleted int64 `json:"uploadsDeleted"`
+}
+
+// CleanupIncompleteUploads aborts the multipart uploads of a bucket that were
+// started more than OlderThanSecs seconds ago.
+func (c *Client) CleanupIncompleteUploads(ctx context.Context, req CleanupIncompleteUploadsRequest) (*CleanupIncompleteUploadsResponse, error) {
+	defer c.bucketCache.invalidate(req.BucketID)
 
-	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/AddBucketAlias", req)
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/CleanupIncompleteUploads", nil, req)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
 	}
 
-	return nil
+	var result CleanupIncompleteUploadsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// AddBucketAlias adds a global alias to a bucket. Conflicts with concurrent
+// alias changes are retried, and the alias counts as added when the bucket
+// has it once the retries are exhausted.
+func (c *Client) AddBucketAlias(ctx context.Context, bucketID, alias string) error {
+	defer c.bucketCache.invalidate(bucketID)
+
+	err := retryOnConflict(ctx, func() error {
+		return c.bucketAliasRequest(ctx, "/v2/AddBucketAlias", bucketID, alias)
+	})
+	if errors.Is(err, ErrConflict) {
+		if has, readErr := c.bucketHasAlias(ctx, bucketID, alias); readErr == nil && has {
+			return nil
+		}
+	}
+
+	return err
 }
 
-// RemoveBucketAlias removes a global alias from a bucket.
+// RemoveBucketAlias removes a global alias from a bucket. Conflicts with
+// concurrent alias changes are retried, and the alias counts as removed when
+// the bucket no longer has it once the retries are exhausted.
 func (c *Client) RemoveBucketAlias(ctx context.Context, bucketID, alias string) error {
+	defer c.bucketCache.invalidate(bucketID)
+
+	err := retryOnConflict(ctx, func() error {
+		return c.bucketAliasRequest(ctx, "/v2/RemoveBucketAlias", bucketID, alias)
+	})
+	if errors.Is(err, ErrConflict) {
+		if has, readErr := c.bucketHasAlias(ctx, bucketID, alias); readErr == nil && !has {
+			return nil
+		}
+	}
+
+	return err
+}
+
+// bucketAliasRequest calls an alias endpoint once.
+func (c *Client) bucketAliasRequest(ctx context.Context, path, bucketID, alias string) error {
 	req := map[string]string{
 		"id":    bucketID,
 		"alias": alias,
 	}
 
-	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/RemoveBucketAlias", req)
+	resp, err := c.doRequest(ctx, http.MethodPost, path, nil, req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return newAPIError(resp)
 	}
 
 	return nil
@@ -342,15 +780,16 @@ func (c *Client) RemoveBucketAlias(ctx context.Context, bucketID, alias string)
 
 // AllowBucketKey grants permissions for an access key on a bucket.
 func (c *Client) AllowBucketKey(ctx context.Context, req BucketKeyPermRequest) (*Bucket, error) {
-	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/AllowBucketKey", req)
+	defer c.bucketCache.invalidate(req.BucketID)
+
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/AllowBucketKey", nil, req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp)
 	}
 
 	var bucket Bucket
@@ -363,15 +802,16 @@ func (c *Client) AllowBucketKey(ctx context.Context, req BucketKeyPermRequest) (
 
 // DenyBucketKey revokes permissions for an access key on a bucket.
 func (c *Client) DenyBucketKey(ctx context.Context, req BucketKeyPermRequest) (*Bucket, error) {
-	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/DenyBucketKey", req)
+	defer c.bucketCache.invalidate(req.BucketID)
+
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/DenyBucketKey", nil, req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp)
 	}
 
 	var bucket Bucket
@@ -382,17 +822,99 @@ func (c *Client) DenyBucketKey(ctx context.Context, req BucketKeyPermRequest) (*
 	return &bucket, nil
 }
 
+// ListKeys lists all access keys.
+func (c *Client) ListKeys(ctx context.Context) ([]KeyListItem, error) {
+	keys := []KeyListItem{}
+	err := c.EachKey(ctx, func(key KeyListItem) error {
+		keys = append(keys, key)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// EachKey calls fn for every access key as the key list is received, without
+// holding the whole list in memory. Iteration stops at the first error from fn.
+func (c *Client) EachKey(ctx context.Context, fn func(KeyListItem) error) error {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/v2/ListKeys", nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newAPIError(resp)
+	}
+
+	return decodeJSONArray(resp.Body, fn)
+}
+
+// SearchKeys lists the access keys matching pattern the way Garage resolves
+// key searches: a key matches if its ID starts with pattern or its name is
+// pattern. A key whose ID is exactly pattern is the only match.
+func (c *Client) SearchKeys(ctx context.Context, pattern string) ([]KeyListItem, error) {
+	if pattern == "" {
+		return nil, errors.New("the key search pattern must not be empty")
+	}
+
+	var exact *KeyListItem
+	matches := []KeyListItem{}
+	err := c.EachKey(ctx, func(key KeyListItem) error {
+		switch {
+		case key.ID == pattern:
+			exact = &key
+		case strings.HasPrefix(key.ID, pattern) || key.Name == pattern:
+			matches = append(matches, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if exact != nil {
+		return []KeyListItem{*exact}, nil
+	}
+
+	return matches, nil
+}
+
+// SearchKey gets the access key matching pattern, as described for
+// SearchKeys. It returns nil if no key matches, and an error listing the
+// matching key IDs if several do.
+func (c *Client) SearchKey(ctx context.Context, pattern string) (*AccessKey, error) {
+	matches, err := c.SearchKeys(ctx, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return c.GetKeyInfo(ctx, GetKeyInfoRequest{ID: matches[0].ID})
+	}
+
+	ids := make([]string, len(matches))
+	for i, key := range matches {
+		ids[i] = key.ID
+	}
+	return nil, fmt.Errorf("%q matches %d access keys (%s); use the access key ID instead", pattern, len(matches), strings.Join(ids, ", "))
+}
+
 // CreateKey creates a new access key.
 func (c *Client) CreateKey(ctx context.Context, req CreateKeyRequest) (*AccessKey, error) {
-	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/CreateKey", req)
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/CreateKey", nil, req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp)
 	}
 
 	var key AccessKey
@@ -405,15 +927,14 @@ func (c *Client) CreateKey(ctx context.Context, req CreateKeyRequest) (*AccessKe
 
 // ImportKey imports an existing access key with predefined credentials.
 func (c *Client) ImportKey(ctx context.Context, req ImportKeyRequest) (*AccessKey, error) {
-	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/ImportKey", req)
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/ImportKey", nil, req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp)
 	}
 
 	var key AccessKey
@@ -426,9 +947,27 @@ func (c *Client) ImportKey(ctx context.Context, req ImportKeyRequest) (*AccessKe
 
 // GetKeyInfo gets information about a specific access key.
 func (c *Client) GetKeyInfo(ctx context.Context, req GetKeyInfoRequest) (*AccessKey, error) {
-	path := fmt.Sprintf("/v2/GetKeyInfo?id=%s", req.ID)
+	query := url.Values{"search": {req.Search}}
+	if req.Search == "" {
+		if err := validateID("access key ID", req.ID); err != nil {
+			return nil, err
+		}
+		query = url.Values{"id": {req.ID}}
+	}
+
+	v, err := c.sharedCall(ctx, "GetKeyInfo?"+query.Encode(), func(ctx context.Context) (interface{}, error) {
+		return c.getKeyInfo(ctx, query)
+	})
+	if err != nil || v.(*AccessKey) == nil {
+		return nil, err
+	}
+
+	// Callers sharing a request each get their own copy
+	return v.(*AccessKey).clone(), nil
+}
 
-	resp, err := c.doRequest(ctx, http.MethodGet, path, nil)
+func (c *Client) getKeyInfo(ctx context.Context, query url.Values) (*AccessKey, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/v2/GetKeyInfo", query, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -439,8 +978,31 @@ func (c *Client) GetKeyInfo(ctx context.Context, req GetKeyInfoRequest) (*Access
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, newAPIError(resp)
+	}
+
+	var key AccessKey
+	if err := json.NewDecoder(resp.Body).Decode(&key); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &key, nil
+}
+
+// UpdateKey updates an access key. The secret access key is unchanged.
+func (c *Client) UpdateKey(ctx context.Context, id string, req UpdateKeyRequest) (*AccessKey, error) {
+	if err := validateID("access key ID", id); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/UpdateKey", url.Values{"id": {id}}, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
 	}
 
 	var key AccessKey
@@ -453,17 +1015,21 @@ func (c *Client) GetKeyInfo(ctx context.Context, req GetKeyInfoRequest) (*Access
 
 // DeleteKey deletes an access key.
 func (c *Client) DeleteKey(ctx context.Context, req DeleteKeyRequest) error {
-	path := fmt.Sprintf("/v2/DeleteKey?id=%s", req.ID)
+	// The key disappears from every bucket it had access to
+	defer c.bucketCache.clear()
+
+	if err := validateID("access key ID", req.ID); err != nil {
+		return err
+	}
 
-	resp, err := c.doRequest(ctx, http.MethodPost, path, nil)
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/DeleteKey", url.Values{"id": {req.ID}}, nil)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+		return newAPIError(resp)
 	}
 
 	return nil
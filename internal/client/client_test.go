@@ -4,11 +4,22 @@
 package client
 
 import (
+	"compress/gzip"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestNewClient(t *testing.T) {
@@ -95,6 +106,69 @@ func TestListBuckets(t *testing.T) {
 	}
 }
 
+func TestListBuckets_gzip(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			t.Errorf("Expected Accept-Encoding gzip, got %q", r.Header.Get("Accept-Encoding"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+
+		gz := gzip.NewWriter(w)
+		defer gz.Close()
+
+		buckets := make([]Bucket, 1000)
+		for i := range buckets {
+			buckets[i] = Bucket{ID: fmt.Sprintf("bucket-%d", i)}
+		}
+		_ = json.NewEncoder(gz).Encode(buckets)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	buckets, err := client.ListBuckets(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(buckets) != 1000 {
+		t.Fatalf("Expected 1000 buckets, got %d", len(buckets))
+	}
+
+	if buckets[999].ID != "bucket-999" {
+		t.Errorf("Expected last bucket ID 'bucket-999', got %s", buckets[999].ID)
+	}
+}
+
+func TestEachBucket_stopsOnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id": "bucket-1"}, {"id": "bucket-2"}, {"id": "bucket-3"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	errStop := errors.New("stop")
+
+	var seen []string
+	err := client.EachBucket(context.Background(), func(bucket Bucket) error {
+		seen = append(seen, bucket.ID)
+		if bucket.ID == "bucket-2" {
+			return errStop
+		}
+		return nil
+	})
+
+	if !errors.Is(err, errStop) {
+		t.Errorf("Expected stop error, got %v", err)
+	}
+
+	if len(seen) != 2 {
+		t.Errorf("Expected iteration to stop after 2 buckets, got %v", seen)
+	}
+}
+
 func TestGetBucketInfo_byID(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
@@ -168,6 +242,185 @@ func TestGetBucketInfo_notFound(t *testing.T) {
 	}
 }
 
+func TestGetBucketInfo_globalAliasEncoded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "globalAlias=a%26id%3Dother%2Bb" {
+			t.Errorf("Expected the global alias to be encoded, got %s", r.URL.RawQuery)
+		}
+		if got := r.URL.Query().Get("globalAlias"); got != "a&id=other+b" {
+			t.Errorf("Expected global alias 'a&id=other+b', got %s", got)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Bucket{ID: "bucket-123"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	alias := "a&id=other+b"
+	if _, err := client.GetBucketInfo(context.Background(), GetBucketInfoRequest{GlobalAlias: &alias}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestClient_invalidIDs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no request, got %s %s", r.Method, r.URL)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	ctx := context.Background()
+	empty, injected := "", "bucket-123&globalAlias=other"
+
+	if _, err := client.GetBucketInfo(ctx, GetBucketInfoRequest{ID: &empty}); err == nil {
+		t.Error("Expected an error for an empty bucket ID")
+	}
+	if _, err := client.GetBucketInfo(ctx, GetBucketInfoRequest{}); err == nil {
+		t.Error("Expected an error for a request without bucket ID or global alias")
+	}
+	if _, err := client.UpdateBucket(ctx, injected, UpdateBucketRequest{}); err == nil {
+		t.Error("Expected an error for an invalid bucket ID")
+	}
+	if err := client.DeleteBucket(ctx, DeleteBucketRequest{ID: injected}); err == nil {
+		t.Error("Expected an error for an invalid bucket ID")
+	}
+	if _, err := client.GetKeyInfo(ctx, GetKeyInfoRequest{ID: "GK1 2"}); err == nil {
+		t.Error("Expected an error for an invalid access key ID")
+	}
+	if err := client.DeleteKey(ctx, DeleteKeyRequest{ID: empty}); err == nil {
+		t.Error("Expected an error for an empty access key ID")
+	}
+	if err := client.DeleteAdminToken(ctx, "token/../1"); err == nil {
+		t.Error("Expected an error for an invalid admin token ID")
+	}
+	if _, err := client.GetNodeInfo(ctx, "node?x"); err == nil {
+		t.Error("Expected an error for an invalid node ID")
+	}
+}
+
+func TestGetBucketInfo_sharesConcurrentRequests(t *testing.T) {
+	var requests atomic.Int32
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Bucket{ID: "test-bucket-id"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	id := "test-bucket-id"
+
+	var wg sync.WaitGroup
+	buckets := make([]*Bucket, 5)
+	for i := range buckets {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			bucket, err := client.GetBucketInfo(context.Background(), GetBucketInfoRequest{ID: &id})
+			if err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			buckets[i] = bucket
+		}(i)
+	}
+
+	// Let the other callers join the in-flight request before answering it
+	<-started
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := requests.Load(); n != 1 {
+		t.Errorf("Expected 1 request, got %d", n)
+	}
+
+	for i, bucket := range buckets {
+		if bucket == nil || bucket.ID != id {
+			t.Fatalf("Expected bucket %s for caller %d, got %+v", id, i, bucket)
+		}
+	}
+
+	if buckets[0] == buckets[1] {
+		t.Error("Expected each caller to get its own copy of the bucket")
+	}
+}
+
+func TestGetBucketInfo_sharedRequestOutlivesFirstCaller(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Bucket{ID: "test-bucket-id", GlobalAliases: []string{"assets"}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	id := "test-bucket-id"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := client.GetBucketInfo(ctx, GetBucketInfoRequest{ID: &id})
+		first <- err
+	}()
+	<-started
+
+	second := make(chan *Bucket)
+	go func() {
+		bucket, err := client.GetBucketInfo(context.Background(), GetBucketInfoRequest{ID: &id})
+		if err != nil {
+			t.Errorf("Expected no error for the second caller, got %v", err)
+		}
+		second <- bucket
+	}()
+
+	// The first caller gives up while the second one waits for the response
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the first caller to be canceled, got %v", err)
+	}
+	close(release)
+
+	bucket := <-second
+	if bucket == nil || len(bucket.GlobalAliases) != 1 {
+		t.Fatalf("Expected the bucket for the second caller, got %+v", bucket)
+	}
+}
+
+func TestBucketClone(t *testing.T) {
+	maxSize := int64(100)
+	bucket := &Bucket{
+		ID:            "bucket-1",
+		GlobalAliases: []string{"assets"},
+		Keys:          []BucketKeyInfo{{AccessKeyID: "GK1", BucketLocalAliases: []string{"local"}}},
+		Quotas:        &BucketQuotas{MaxSize: &maxSize},
+	}
+
+	clone := bucket.clone()
+	clone.GlobalAliases[0] = "changed"
+	clone.Keys[0].BucketLocalAliases[0] = "changed"
+	*clone.Quotas.MaxSize = 200
+
+	if bucket.GlobalAliases[0] != "assets" || bucket.Keys[0].BucketLocalAliases[0] != "local" || *bucket.Quotas.MaxSize != 100 {
+		t.Errorf("Expected changes to the clone not to affect the bucket, got %+v", bucket)
+	}
+}
+
 func TestCreateBucket(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
@@ -219,6 +472,49 @@ func TestCreateBucket(t *testing.T) {
 	}
 }
 
+func TestCreateBucket_localAlias(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req CreateBucketRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+
+		if req.GlobalAlias != nil {
+			t.Errorf("Expected no global alias, got %s", *req.GlobalAlias)
+		}
+		if req.LocalAlias == nil {
+			t.Fatal("Expected a local alias")
+		}
+		if req.LocalAlias.AccessKeyID != "GK123" || req.LocalAlias.Alias != "my-bucket" {
+			t.Errorf("Unexpected local alias: %+v", req.LocalAlias)
+		}
+		if !req.LocalAlias.Allow.Read || !req.LocalAlias.Allow.Write || !req.LocalAlias.Allow.Owner {
+			t.Errorf("Expected all permissions to be allowed, got %+v", req.LocalAlias.Allow)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Bucket{ID: "bucket-new-123"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	bucket, err := client.CreateBucket(context.Background(), CreateBucketRequest{
+		LocalAlias: &CreateBucketLocalAlias{
+			AccessKeyID: "GK123",
+			Alias:       "my-bucket",
+			Allow:       Permissions{Read: true, Write: true, Owner: true},
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if bucket.ID != "bucket-new-123" {
+		t.Errorf("Expected bucket ID 'bucket-new-123', got %s", bucket.ID)
+	}
+}
+
 func TestUpdateBucket(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
@@ -300,6 +596,42 @@ func TestDeleteBucket(t *testing.T) {
 	}
 }
 
+func TestCleanupIncompleteUploads(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+		if r.URL.Path != "/v2/CleanupIncompleteUploads" {
+			t.Errorf("Expected path /v2/CleanupIncompleteUploads, got %s", r.URL.Path)
+		}
+
+		var req CleanupIncompleteUploadsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+		if req.BucketID != "bucket-123" || req.OlderThanSecs != 86400 {
+			t.Errorf("Unexpected request: %+v", req)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"uploadsDeleted": 3}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	result, err := client.CleanupIncompleteUploads(context.Background(), CleanupIncompleteUploadsRequest{
+		BucketID:      "bucket-123",
+		OlderThanSecs: 86400,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result.UploadsDeleted != 3 {
+		t.Errorf("Expected 3 uploads deleted, got %d", result.UploadsDeleted)
+	}
+}
+
 func TestAddBucketAlias(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
@@ -370,6 +702,216 @@ func TestRemoveBucketAlias(t *testing.T) {
 	}
 }
 
+func TestEachKey_stopsOnError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id": "GK1", "name": "a"}, {"id": "GK2", "name": "b"}, {"id": "GK3", "name": "c"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	errStop := errors.New("stop")
+
+	var seen []string
+	err := client.EachKey(context.Background(), func(key KeyListItem) error {
+		seen = append(seen, key.ID)
+		if key.ID == "GK2" {
+			return errStop
+		}
+		return nil
+	})
+
+	if !errors.Is(err, errStop) {
+		t.Errorf("Expected stop error, got %v", err)
+	}
+
+	if len(seen) != 2 {
+		t.Errorf("Expected iteration to stop after 2 keys, got %v", seen)
+	}
+}
+
+func TestListKeys(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		if r.URL.Path != "/v2/ListKeys" {
+			t.Errorf("Expected path /v2/ListKeys, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"id": "GK1", "name": "app", "created": "2026-01-01T00:00:00Z", "expiration": null, "expired": false},
+			{"id": "GK2", "name": "old", "created": null, "expiration": "2025-01-01T00:00:00Z", "expired": true}
+		]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	keys, err := client.ListKeys(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(keys) != 2 {
+		t.Fatalf("Expected 2 keys, got %d", len(keys))
+	}
+
+	if keys[0].ID != "GK1" || keys[0].Name != "app" || keys[0].Expiration != nil {
+		t.Errorf("Unexpected first key %+v", keys[0])
+	}
+
+	if !keys[1].Expired || keys[1].Expiration == nil || keys[1].Created != nil {
+		t.Errorf("Unexpected second key %+v", keys[1])
+	}
+}
+
+func TestGetKeyInfo_search(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/GetKeyInfo" {
+			t.Errorf("Expected path /v2/GetKeyInfo, got %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("search"); got != "ci deploy" {
+			t.Errorf("Expected search 'ci deploy', got %q", got)
+		}
+		if r.URL.Query().Has("id") {
+			t.Error("Expected no id parameter when searching")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"accessKeyId": "GK1", "name": "ci deploy", "expired": false, "permissions": {"createBucket": true}, "buckets": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	key, err := client.GetKeyInfo(context.Background(), GetKeyInfoRequest{Search: "ci deploy"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if key == nil || key.AccessKeyID != "GK1" || !key.Permissions.CreateBucket {
+		t.Errorf("Unexpected key %+v", key)
+	}
+}
+
+func TestSearchKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v2/ListKeys":
+			_, _ = w.Write([]byte(`[
+				{"id": "GK1a", "name": "ci", "expired": false},
+				{"id": "GK1b", "name": "web", "expired": false},
+				{"id": "GK2", "name": "GK1", "expired": false}
+			]`))
+		case "/v2/GetKeyInfo":
+			id := r.URL.Query().Get("id")
+			_, _ = w.Write([]byte(`{"accessKeyId": "` + id + `", "name": "web", "expired": false, "permissions": {}, "buckets": []}`))
+		default:
+			t.Errorf("Unexpected request %s", r.URL)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	ctx := context.Background()
+
+	keys, err := client.SearchKeys(ctx, "GK1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(keys) != 3 {
+		t.Errorf("Expected the ID prefix and name matches, got %+v", keys)
+	}
+
+	keys, err = client.SearchKeys(ctx, "GK1a")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(keys) != 1 || keys[0].ID != "GK1a" {
+		t.Errorf("Expected only the exact ID match, got %+v", keys)
+	}
+
+	key, err := client.SearchKey(ctx, "web")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if key == nil || key.AccessKeyID != "GK1b" {
+		t.Errorf("Expected key GK1b, got %+v", key)
+	}
+
+	key, err = client.SearchKey(ctx, "missing")
+	if err != nil || key != nil {
+		t.Errorf("Expected no key and no error, got %+v, %v", key, err)
+	}
+
+	if _, err := client.SearchKey(ctx, "GK1"); err == nil || !strings.Contains(err.Error(), "GK1a, GK1b, GK2") {
+		t.Errorf("Expected an error listing the matching keys, got %v", err)
+	}
+}
+
+func TestUpdateKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+		if r.URL.Path != "/v2/UpdateKey" {
+			t.Errorf("Expected path /v2/UpdateKey, got %s", r.URL.Path)
+		}
+		if id := r.URL.Query().Get("id"); id != "GK1" {
+			t.Errorf("Expected id GK1, got %s", id)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		expected := `{"neverExpires":true}`
+		if strings.TrimSpace(string(body)) != expected {
+			t.Errorf("Expected body %s, got %s", expected, string(body))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"accessKeyId": "GK1", "name": "app", "expired": false, "expiration": null, "permissions": {"createBucket": false}, "buckets": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	key, err := client.UpdateKey(context.Background(), "GK1", UpdateKeyRequest{NeverExpires: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if key.AccessKeyID != "GK1" || key.Expiration != nil {
+		t.Errorf("Unexpected key %+v", key)
+	}
+}
+
+func TestUpdateKey_renameAndAllow(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		expected := `{"name":"renamed","allow":{"createBucket":true}}`
+		if strings.TrimSpace(string(body)) != expected {
+			t.Errorf("Expected body %s, got %s", expected, string(body))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"accessKeyId": "GK1", "name": "renamed", "expired": false, "permissions": {"createBucket": true}, "buckets": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	name := "renamed"
+	key, err := client.UpdateKey(context.Background(), "GK1", UpdateKeyRequest{
+		Name:  &name,
+		Allow: &KeyPermissions{CreateBucket: true},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if key.Name != "renamed" || !key.Permissions.CreateBucket {
+		t.Errorf("Unexpected key %+v", key)
+	}
+}
+
 func TestClient_errorHandling(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -411,3 +953,85 @@ func TestClient_errorHandling(t *testing.T) {
 		t.Error("Expected error for 500 response")
 	}
 }
+
+func TestWithTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	// The test server certificate is not trusted by default
+	if _, err := NewClient(server.URL, "test-token").ListAdminTokens(context.Background()); err == nil {
+		t.Fatal("Expected an error for an untrusted certificate")
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	client := NewClient(server.URL, "test-token", WithTLSConfig(&tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}))
+	if _, err := client.ListAdminTokens(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestWithHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("CF-Access-Client-Id"); got != "client-id" {
+			t.Errorf("Expected the extra header to be sent, got %q", got)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("Expected the admin token to be kept, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", WithHeaders(map[string]string{
+		"CF-Access-Client-Id": "client-id",
+		"Authorization":       "Basic overridden",
+	}))
+	if _, err := client.ListAdminTokens(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestWithProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Requests sent through a proxy carry the absolute URL of the target
+		proxied = r.URL.String()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer proxy.Close()
+
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	client := NewClient("http://garage.invalid:3903", "test-token", WithProxy(proxyURL))
+	if _, err := client.ListAdminTokens(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if proxied != "http://garage.invalid:3903/v2/ListAdminTokens" {
+		t.Errorf("Expected the request to go through the proxy, got %q", proxied)
+	}
+}
+
+func TestWithRequestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(server.URL, "test-token", WithRequestTimeout(50*time.Millisecond))
+	if _, err := client.ListAdminTokens(context.Background()); err == nil {
+		t.Fatal("Expected an error for a request exceeding the timeout")
+	}
+}
@@ -0,0 +1,340 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ClusterStatus represents the status of the cluster nodes.
+type ClusterStatus struct {
+	LayoutVersion int64        `json:"layoutVersion"`
+	Nodes         []NodeStatus `json:"nodes"`
+}
+
+// NodeStatus represents the status of a node as seen by the cluster.
+type NodeStatus struct {
+	ID                string             `json:"id"`
+	GarageVersion     *string            `json:"garageVersion,omitempty"`
+	Addr              *string            `json:"addr,omitempty"`
+	Hostname          *string            `json:"hostname,omitempty"`
+	IsUp              bool               `json:"isUp"`
+	LastSeenSecsAgo   *int64             `json:"lastSeenSecsAgo,omitempty"`
+	Role              *NodeAssignedRole  `json:"role,omitempty"`
+	Draining          bool               `json:"draining"`
+	DataPartition     *FreeSpaceResponse `json:"dataPartition,omitempty"`
+	MetadataPartition *FreeSpaceResponse `json:"metadataPartition,omitempty"`
+}
+
+// NodeAssignedRole represents the role of a node in the current layout.
+type NodeAssignedRole struct {
+	Zone     string   `json:"zone"`
+	Tags     []string `json:"tags"`
+	Capacity *int64   `json:"capacity,omitempty"`
+}
+
+// FreeSpaceResponse represents the available and total space of a partition, in bytes.
+type FreeSpaceResponse struct {
+	Available int64 `json:"available"`
+	Total     int64 `json:"total"`
+}
+
+// ClusterLayout represents the current cluster layout and its staged changes.
+type ClusterLayout struct {
+	Version           int64             `json:"version"`
+	Roles             []LayoutNodeRole  `json:"roles"`
+	Parameters        LayoutParameters  `json:"parameters"`
+	PartitionSize     int64             `json:"partitionSize"`
+	StagedRoleChanges []NodeRoleChange  `json:"stagedRoleChanges"`
+	StagedParameters  *LayoutParameters `json:"stagedParameters,omitempty"`
+}
+
+// NextVersion returns the version the staged changes are applied as, which
+// ApplyClusterLayout expects to guard against concurrent layout changes.
+func (l *ClusterLayout) NextVersion() int64 {
+	return l.Version + 1
+}
+
+// HasStagedChanges reports whether the layout has staged role or parameter
+// changes waiting to be applied.
+func (l *ClusterLayout) HasStagedChanges() bool {
+	return len(l.StagedRoleChanges) > 0 || l.StagedParameters != nil
+}
+
+// LayoutNodeRole represents the role of a node in a layout.
+type LayoutNodeRole struct {
+	ID               string   `json:"id"`
+	Zone             string   `json:"zone"`
+	Tags             []string `json:"tags"`
+	Capacity         *int64   `json:"capacity,omitempty"`
+	StoredPartitions *int64   `json:"storedPartitions,omitempty"`
+	UsableCapacity   *int64   `json:"usableCapacity,omitempty"`
+}
+
+// LayoutParameters represents the parameters used when computing a layout.
+type LayoutParameters struct {
+	ZoneRedundancy ZoneRedundancy `json:"zoneRedundancy"`
+}
+
+// ZoneRedundancy is either "maximum" or a minimum number of zones ("atLeast").
+type ZoneRedundancy struct {
+	AtLeast *int64
+}
+
+// MarshalJSON encodes the zone redundancy the way the Garage API expects it.
+func (z ZoneRedundancy) MarshalJSON() ([]byte, error) {
+	if z.AtLeast == nil {
+		return json.Marshal("maximum")
+	}
+	return json.Marshal(map[string]int64{"atLeast": *z.AtLeast})
+}
+
+// UnmarshalJSON decodes the zone redundancy returned by the Garage API.
+func (z *ZoneRedundancy) UnmarshalJSON(data []byte) error {
+	var maximum string
+	if err := json.Unmarshal(data, &maximum); err == nil {
+		if maximum != "maximum" {
+			return fmt.Errorf("unknown zone redundancy %q", maximum)
+		}
+		z.AtLeast = nil
+		return nil
+	}
+
+	var atLeast struct {
+		AtLeast int64 `json:"atLeast"`
+	}
+	if err := json.Unmarshal(data, &atLeast); err != nil {
+		return err
+	}
+	z.AtLeast = &atLeast.AtLeast
+	return nil
+}
+
+// NodeRoleChange represents a staged change to the role of a node. When Remove
+// is set, the node is removed from the layout and the other fields are ignored.
+type NodeRoleChange struct {
+	ID       string
+	Remove   bool
+	Zone     string
+	Capacity *int64
+	Tags     []string
+}
+
+// MarshalJSON encodes the change as either a removal or a role assignment.
+func (c NodeRoleChange) MarshalJSON() ([]byte, error) {
+	if c.Remove {
+		return json.Marshal(struct {
+			ID     string `json:"id"`
+			Remove bool   `json:"remove"`
+		}{ID: c.ID, Remove: true})
+	}
+
+	tags := c.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return json.Marshal(struct {
+		ID       string   `json:"id"`
+		Zone     string   `json:"zone"`
+		Capacity *int64   `json:"capacity"`
+		Tags     []string `json:"tags"`
+	}{ID: c.ID, Zone: c.Zone, Capacity: c.Capacity, Tags: tags})
+}
+
+// UnmarshalJSON decodes either form of a staged role change.
+func (c *NodeRoleChange) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		ID       string   `json:"id"`
+		Remove   bool     `json:"remove"`
+		Zone     string   `json:"zone"`
+		Capacity *int64   `json:"capacity"`
+		Tags     []string `json:"tags"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*c = NodeRoleChange(raw)
+	return nil
+}
+
+// UpdateClusterLayoutRequest represents the request to stage layout changes.
+type UpdateClusterLayoutRequest struct {
+	Roles      []NodeRoleChange  `json:"roles,omitempty"`
+	Parameters *LayoutParameters `json:"parameters,omitempty"`
+}
+
+// ApplyClusterLayoutRequest represents the request to apply the staged layout changes.
+type ApplyClusterLayoutRequest struct {
+	Version int64 `json:"version"`
+}
+
+// ApplyClusterLayoutResponse represents the result of applying a layout.
+type ApplyClusterLayoutResponse struct {
+	Message []string      `json:"message"`
+	Layout  ClusterLayout `json:"layout"`
+}
+
+// PreviewClusterLayoutChangesResponse represents the layout that applying the
+// staged changes would produce. When the staged changes cannot be applied,
+// Error explains why and NewLayout is nil.
+type PreviewClusterLayoutChangesResponse struct {
+	Error     *string        `json:"error,omitempty"`
+	Message   []string       `json:"message,omitempty"`
+	NewLayout *ClusterLayout `json:"newLayout,omitempty"`
+}
+
+// ConnectNodeResult represents the outcome of connecting to one node.
+type ConnectNodeResult struct {
+	Success bool    `json:"success"`
+	Error   *string `json:"error,omitempty"`
+}
+
+// GetClusterStatus gets the status of all nodes known to the cluster.
+func (c *Client) GetClusterStatus(ctx context.Context) (*ClusterStatus, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/v2/GetClusterStatus", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var status ClusterStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &status, nil
+}
+
+// GetClusterLayout gets the current cluster layout and staged changes.
+func (c *Client) GetClusterLayout(ctx context.Context) (*ClusterLayout, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/v2/GetClusterLayout", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var layout ClusterLayout
+	if err := json.NewDecoder(resp.Body).Decode(&layout); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &layout, nil
+}
+
+// UpdateClusterLayout stages changes to the cluster layout.
+func (c *Client) UpdateClusterLayout(ctx context.Context, req UpdateClusterLayoutRequest) (*ClusterLayout, error) {
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/UpdateClusterLayout", nil, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var layout ClusterLayout
+	if err := json.NewDecoder(resp.Body).Decode(&layout); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &layout, nil
+}
+
+// ApplyClusterLayout applies the staged layout changes as the given layout version.
+func (c *Client) ApplyClusterLayout(ctx context.Context, req ApplyClusterLayoutRequest) (*ApplyClusterLayoutResponse, error) {
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/ApplyClusterLayout", nil, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result ApplyClusterLayoutResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// RevertClusterLayout discards the staged layout changes and returns the
+// resulting layout.
+func (c *Client) RevertClusterLayout(ctx context.Context) (*ClusterLayout, error) {
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/RevertClusterLayout", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var layout ClusterLayout
+	if err := json.NewDecoder(resp.Body).Decode(&layout); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &layout, nil
+}
+
+// PreviewClusterLayoutChanges computes the layout that applying the staged
+// changes would produce, including the partition assignment messages, without
+// applying them.
+func (c *Client) PreviewClusterLayoutChanges(ctx context.Context) (*PreviewClusterLayoutChangesResponse, error) {
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/PreviewClusterLayoutChanges", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var preview PreviewClusterLayoutChangesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&preview); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &preview, nil
+}
+
+// ConnectClusterNodes instructs the cluster to connect to the given nodes, each
+// given as <node ID>@<host>:<port>. The results are in the order of the nodes.
+func (c *Client) ConnectClusterNodes(ctx context.Context, nodes []string) ([]ConnectNodeResult, error) {
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/ConnectClusterNodes", nil, nodes)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var results []ConnectNodeResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,308 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetClusterLayout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		if r.URL.Path != "/v2/GetClusterLayout" {
+			t.Errorf("Expected path /v2/GetClusterLayout, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"version": 3,
+			"roles": [
+				{"id": "node-1", "zone": "dc1", "tags": ["rack1"], "capacity": 1000000000, "storedPartitions": 256, "usableCapacity": 900000000},
+				{"id": "node-2", "zone": "dc2", "tags": [], "capacity": null}
+			],
+			"parameters": {"zoneRedundancy": {"atLeast": 2}},
+			"partitionSize": 3515625,
+			"stagedRoleChanges": [{"id": "node-3", "remove": true}],
+			"stagedParameters": {"zoneRedundancy": "maximum"}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	layout, err := client.GetClusterLayout(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if layout.Version != 3 {
+		t.Errorf("Expected version 3, got %d", layout.Version)
+	}
+
+	if len(layout.Roles) != 2 {
+		t.Fatalf("Expected 2 roles, got %d", len(layout.Roles))
+	}
+
+	if layout.Roles[0].Capacity == nil || *layout.Roles[0].Capacity != 1000000000 {
+		t.Errorf("Expected capacity 1000000000 for node-1, got %v", layout.Roles[0].Capacity)
+	}
+
+	if layout.Roles[1].Capacity != nil {
+		t.Errorf("Expected gateway node-2 to have no capacity, got %v", *layout.Roles[1].Capacity)
+	}
+
+	if layout.Parameters.ZoneRedundancy.AtLeast == nil || *layout.Parameters.ZoneRedundancy.AtLeast != 2 {
+		t.Errorf("Expected zone redundancy atLeast 2, got %v", layout.Parameters.ZoneRedundancy.AtLeast)
+	}
+
+	if layout.StagedParameters == nil || layout.StagedParameters.ZoneRedundancy.AtLeast != nil {
+		t.Error("Expected staged zone redundancy maximum")
+	}
+
+	if len(layout.StagedRoleChanges) != 1 || !layout.StagedRoleChanges[0].Remove {
+		t.Errorf("Expected one staged removal, got %+v", layout.StagedRoleChanges)
+	}
+}
+
+func TestUpdateClusterLayout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+		if r.URL.Path != "/v2/UpdateClusterLayout" {
+			t.Errorf("Expected path /v2/UpdateClusterLayout, got %s", r.URL.Path)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		expected := `{"roles":[{"id":"node-1","zone":"dc1","capacity":null,"tags":[]},{"id":"node-2","remove":true}]}`
+		if string(body) != expected {
+			t.Errorf("Expected body %s, got %s", expected, string(body))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(ClusterLayout{Version: 4})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	layout, err := client.UpdateClusterLayout(context.Background(), UpdateClusterLayoutRequest{
+		Roles: []NodeRoleChange{
+			{ID: "node-1", Zone: "dc1"},
+			{ID: "node-2", Remove: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if layout.Version != 4 {
+		t.Errorf("Expected version 4, got %d", layout.Version)
+	}
+}
+
+func TestApplyClusterLayout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/ApplyClusterLayout" {
+			t.Errorf("Expected path /v2/ApplyClusterLayout, got %s", r.URL.Path)
+		}
+
+		var req ApplyClusterLayoutRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.Version != 5 {
+			t.Errorf("Expected version 5, got %d", req.Version)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"message": ["Layout applied"], "layout": {"version": 5, "roles": [], "parameters": {"zoneRedundancy": "maximum"}, "partitionSize": 0, "stagedRoleChanges": []}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	result, err := client.ApplyClusterLayout(context.Background(), ApplyClusterLayoutRequest{Version: 5})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if result.Layout.Version != 5 {
+		t.Errorf("Expected layout version 5, got %d", result.Layout.Version)
+	}
+
+	if len(result.Message) != 1 {
+		t.Errorf("Expected 1 message, got %d", len(result.Message))
+	}
+}
+
+func TestRevertClusterLayout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+		if r.URL.Path != "/v2/RevertClusterLayout" {
+			t.Errorf("Expected path /v2/RevertClusterLayout, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"version": 4, "roles": [], "parameters": {"zoneRedundancy": "maximum"}, "partitionSize": 0, "stagedRoleChanges": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	layout, err := client.RevertClusterLayout(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if layout.Version != 4 || layout.HasStagedChanges() {
+		t.Errorf("Expected version 4 without staged changes, got %+v", layout)
+	}
+}
+
+func TestPreviewClusterLayoutChanges(t *testing.T) {
+	tests := []struct {
+		name      string
+		response  string
+		wantError bool
+		wantNext  int64
+	}{
+		{
+			name:     "preview",
+			response: `{"message": ["Optimal partition size: 1024"], "newLayout": {"version": 5, "roles": [{"id": "node-1", "zone": "dc1", "tags": [], "capacity": 1024}], "parameters": {"zoneRedundancy": "maximum"}, "partitionSize": 4, "stagedRoleChanges": []}}`,
+			wantNext: 6,
+		},
+		{
+			name:      "error",
+			response:  `{"error": "Layout has no capacity"}`,
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/v2/PreviewClusterLayoutChanges" {
+					t.Errorf("Expected path /v2/PreviewClusterLayoutChanges, got %s", r.URL.Path)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token")
+			preview, err := client.PreviewClusterLayoutChanges(context.Background())
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if tt.wantError {
+				if preview.Error == nil || preview.NewLayout != nil {
+					t.Errorf("Expected an error without a layout, got %+v", preview)
+				}
+				return
+			}
+
+			if preview.NewLayout == nil || preview.NewLayout.NextVersion() != tt.wantNext || len(preview.Message) != 1 {
+				t.Errorf("Unexpected preview %+v", preview)
+			}
+		})
+	}
+}
+
+func TestClusterLayout_HasStagedChanges(t *testing.T) {
+	if (&ClusterLayout{}).HasStagedChanges() {
+		t.Error("Expected no staged changes")
+	}
+	if !(&ClusterLayout{StagedRoleChanges: []NodeRoleChange{{ID: "node-1", Remove: true}}}).HasStagedChanges() {
+		t.Error("Expected staged role changes")
+	}
+	if !(&ClusterLayout{StagedParameters: &LayoutParameters{}}).HasStagedChanges() {
+		t.Error("Expected staged parameters")
+	}
+}
+
+func TestGetClusterStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/GetClusterStatus" {
+			t.Errorf("Expected path /v2/GetClusterStatus, got %s", r.URL.Path)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"layoutVersion": 2,
+			"nodes": [
+				{"id": "node-1", "isUp": true, "draining": false, "role": {"zone": "dc1", "tags": [], "capacity": 1000}, "dataPartition": {"available": 600, "total": 1000}},
+				{"id": "node-2", "isUp": false, "draining": true, "lastSeenSecsAgo": 42}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	status, err := client.GetClusterStatus(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if status.LayoutVersion != 2 {
+		t.Errorf("Expected layout version 2, got %d", status.LayoutVersion)
+	}
+
+	if len(status.Nodes) != 2 {
+		t.Fatalf("Expected 2 nodes, got %d", len(status.Nodes))
+	}
+
+	if status.Nodes[0].DataPartition == nil || status.Nodes[0].DataPartition.Available != 600 {
+		t.Errorf("Expected node-1 data partition available 600, got %+v", status.Nodes[0].DataPartition)
+	}
+
+	if !status.Nodes[1].Draining {
+		t.Error("Expected node-2 to be draining")
+	}
+}
+
+func TestConnectClusterNodes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+		if r.URL.Path != "/v2/ConnectClusterNodes" {
+			t.Errorf("Expected path /v2/ConnectClusterNodes, got %s", r.URL.Path)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		expected := `["node-1@10.0.0.1:3901","node-2@10.0.0.2:3901"]`
+		if strings.TrimSpace(string(body)) != expected {
+			t.Errorf("Expected body %s, got %s", expected, string(body))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"success": true, "error": null}, {"success": false, "error": "connection refused"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	results, err := client.ConnectClusterNodes(context.Background(), []string{"node-1@10.0.0.1:3901", "node-2@10.0.0.2:3901"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+
+	if !results[0].Success || results[0].Error != nil {
+		t.Errorf("Expected node-1 to connect, got %+v", results[0])
+	}
+
+	if results[1].Success || results[1].Error == nil || *results[1].Error != "connection refused" {
+		t.Errorf("Expected node-2 to fail with connection refused, got %+v", results[1])
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// CheckDomain asks Garage whether it serves a website for the given domain,
+// that is whether the domain is the global alias of a bucket with website
+// access enabled, or a subdomain of the configured web root domain naming
+// such a bucket. This is the endpoint reverse proxies use to decide whether
+// to request a TLS certificate for a domain.
+func (c *Client) CheckDomain(ctx context.Context, domain string) (bool, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, "/check", url.Values{"domain": {domain}}, nil)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusBadRequest:
+		return false, nil
+	default:
+		return false, newAPIError(resp)
+	}
+}
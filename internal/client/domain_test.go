@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCheckDomain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		if r.URL.Path != "/check" {
+			t.Errorf("Expected path /check, got %s", r.URL.Path)
+		}
+
+		switch domain := r.URL.Query().Get("domain"); domain {
+		case "www.example.com":
+			_, _ = w.Write([]byte("Domain 'www.example.com' is managed by Garage"))
+		case "unknown.example.com":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code":"BadRequest","message":"Domain 'unknown.example.com' is not managed by Garage","region":"garage","path":"/check"}`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"code":"InternalError","message":"unexpected domain","region":"garage","path":"/check"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+
+	served, err := client.CheckDomain(context.Background(), "www.example.com")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !served {
+		t.Error("Expected www.example.com to be served")
+	}
+
+	served, err = client.CheckDomain(context.Background(), "unknown.example.com")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if served {
+		t.Error("Expected unknown.example.com not to be served")
+	}
+
+	if _, err := client.CheckDomain(context.Background(), "broken.example.com"); err == nil {
+		t.Error("Expected an error for a server failure")
+	}
+}
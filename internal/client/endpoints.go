@@ -0,0 +1,184 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// healthCheckTimeout bounds the health checks ordering the admin endpoints.
+const healthCheckTimeout = 5 * time.Second
+
+// Health ranks of an admin endpoint, the lowest being tried first.
+const (
+	endpointHealthy = iota
+	endpointDegraded
+	endpointUnreachable
+)
+
+// endpointOrder is the order the admin endpoints are tried in.
+type endpointOrder struct {
+	once sync.Once
+	mu   sync.Mutex
+	urls []string
+}
+
+// WithFailoverEndpoints adds the admin endpoints of other nodes of the
+// cluster. Before the first request, the endpoints are ordered by the health
+// they report, and requests then fail over to the next endpoint whenever one
+// cannot be reached.
+func WithFailoverEndpoints(endpoints ...string) Option {
+	return func(c *Client) {
+		for _, endpoint := range endpoints {
+			c.failoverEndpoints = append(c.failoverEndpoints, strings.TrimSuffix(endpoint, "/"))
+		}
+	}
+}
+
+// endpoints returns the admin endpoints in the order they are tried in.
+func (c *Client) endpoints(ctx context.Context) []string {
+	if len(c.failoverEndpoints) == 0 {
+		return []string{c.endpoint}
+	}
+
+	c.order.once.Do(func() {
+		urls := c.rankEndpoints(ctx)
+
+		c.order.mu.Lock()
+		c.order.urls = urls
+		c.order.mu.Unlock()
+	})
+
+	c.order.mu.Lock()
+	defer c.order.mu.Unlock()
+	return slices.Clone(c.order.urls)
+}
+
+// rankEndpoints checks the health of every admin endpoint, and orders them
+// healthy first, then reachable, then unreachable, keeping the configured
+// order among endpoints of the same health.
+func (c *Client) rankEndpoints(ctx context.Context) []string {
+	all := append([]string{c.endpoint}, c.failoverEndpoints...)
+	ranks := make(map[string]int, len(all))
+
+	checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, endpoint := range all {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rank := c.healthRank(checkCtx, endpoint)
+
+			mu.Lock()
+			ranks[endpoint] = rank
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	slices.SortStableFunc(all, func(a, b string) int {
+		return ranks[a] - ranks[b]
+	})
+
+	tflog.Debug(ctx, "Ordered admin endpoints by health", map[string]interface{}{
+		"endpoints": all,
+	})
+
+	return all
+}
+
+// healthRank calls the unauthenticated health endpoint of the admin API,
+// which answers 200 when the cluster is fully available.
+func (c *Client) healthRank(ctx context.Context, endpoint string) int {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/health", nil)
+	if err != nil {
+		return endpointUnreachable
+	}
+
+	// A proxy in front of the admin API may require its headers
+	for name, value := range c.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return endpointUnreachable
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return endpointHealthy
+	}
+	return endpointDegraded
+}
+
+// demoteEndpoint moves an endpoint that could not be reached after the
+// others, so that the following requests try it last.
+func (c *Client) demoteEndpoint(endpoint string) {
+	c.order.mu.Lock()
+	defer c.order.mu.Unlock()
+
+	i := slices.Index(c.order.urls, endpoint)
+	if i < 0 {
+		return
+	}
+	c.order.urls = append(slices.Delete(c.order.urls, i, i+1), endpoint)
+}
+
+// send sends a request, whose URL holds only the path and query, to each
+// admin endpoint in turn until one of them can be reached. Requests that are
+// not idempotent only fail over when the connection could not be
+// established, so that two nodes never apply them.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	endpoints := c.endpoints(ctx)
+
+	var resp *http.Response
+	var err error
+	for i, endpoint := range endpoints {
+		target, parseErr := url.Parse(endpoint + req.URL.String())
+		if parseErr != nil {
+			return nil, parseErr
+		}
+
+		attempt := req.Clone(ctx)
+		attempt.URL = target
+
+		// The body of the previous attempt may have been consumed
+		if i > 0 && req.GetBody != nil {
+			attempt.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err = c.httpClient.Do(attempt)
+		if err == nil || ctx.Err() != nil || i == len(endpoints)-1 || !canResend(req, err) {
+			break
+		}
+
+		tflog.Warn(ctx, "Admin endpoint unreachable, failing over to the next one", map[string]interface{}{
+			"endpoint": endpoint,
+			"next":     endpoints[i+1],
+			"error":    err.Error(),
+		})
+
+		c.demoteEndpoint(endpoint)
+	}
+
+	return resp, err
+}
@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// newEndpointServer returns a server answering health checks with health and
+// counting the other requests it receives.
+func newEndpointServer(t *testing.T, health int, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			w.WriteHeader(health)
+			return
+		}
+
+		calls.Add(1)
+
+		var req CreateBucketRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.GlobalAlias == nil || *req.GlobalAlias != "assets" {
+			t.Errorf("Expected the request body to be sent, got %+v", req)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"bucket-123","globalAliases":["assets"]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestWithFailoverEndpoints_healthOrder(t *testing.T) {
+	var degradedCalls, healthyCalls atomic.Int32
+	degraded := newEndpointServer(t, http.StatusServiceUnavailable, &degradedCalls)
+	healthy := newEndpointServer(t, http.StatusOK, &healthyCalls)
+
+	unreachable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	unreachable.Close()
+
+	client := NewClient(unreachable.URL, "test-token", WithFailoverEndpoints(degraded.URL, healthy.URL+"/"))
+
+	alias := "assets"
+	if _, err := client.CreateBucket(context.Background(), CreateBucketRequest{GlobalAlias: &alias}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if healthyCalls.Load() != 1 || degradedCalls.Load() != 0 {
+		t.Errorf("Expected the request to go to the healthy endpoint, got %d healthy and %d degraded calls", healthyCalls.Load(), degradedCalls.Load())
+	}
+
+	want := []string{healthy.URL, degraded.URL, unreachable.URL}
+	if got := client.endpoints(context.Background()); len(got) != 3 || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("Expected endpoints ordered %v, got %v", want, got)
+	}
+}
+
+func TestWithFailoverEndpoints_failover(t *testing.T) {
+	var firstCalls, secondCalls atomic.Int32
+	first := newEndpointServer(t, http.StatusOK, &firstCalls)
+	second := newEndpointServer(t, http.StatusOK, &secondCalls)
+
+	client := NewClient(first.URL, "test-token", WithFailoverEndpoints(second.URL))
+
+	alias := "assets"
+	if _, err := client.CreateBucket(context.Background(), CreateBucketRequest{GlobalAlias: &alias}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if firstCalls.Load() != 1 {
+		t.Fatalf("Expected the first request to go to the first endpoint, got %d calls", firstCalls.Load())
+	}
+
+	// The node goes down after the endpoints were ordered, so that new
+	// connections to it are refused
+	first.Close()
+	client.httpClient.CloseIdleConnections()
+
+	for range 2 {
+		if _, err := client.CreateBucket(context.Background(), CreateBucketRequest{GlobalAlias: &alias}); err != nil {
+			t.Fatalf("Expected the request to fail over, got %v", err)
+		}
+	}
+
+	if secondCalls.Load() != 2 {
+		t.Errorf("Expected 2 calls to the second endpoint, got %d", secondCalls.Load())
+	}
+
+	if got := client.endpoints(context.Background()); got[0] != second.URL {
+		t.Errorf("Expected the unreachable endpoint to be tried last, got %v", got)
+	}
+}
+
+func TestWithFailoverEndpoints_noFailoverOnceSent(t *testing.T) {
+	var secondCalls atomic.Int32
+	second := newEndpointServer(t, http.StatusOK, &secondCalls)
+
+	// The first node drops the connection after receiving the request, which
+	// it may have applied
+	first := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			return
+		}
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err == nil {
+			conn.Close()
+		}
+	}))
+	t.Cleanup(first.Close)
+
+	client := NewClient(first.URL, "test-token", WithFailoverEndpoints(second.URL))
+
+	alias := "assets"
+	if _, err := client.CreateBucket(context.Background(), CreateBucketRequest{GlobalAlias: &alias}); err == nil {
+		t.Fatal("Expected an error")
+	}
+	if secondCalls.Load() != 0 {
+		t.Errorf("Expected CreateBucket not to fail over once sent, got %d calls to the second endpoint", secondCalls.Load())
+	}
+}
+
+func TestNewClient_singleEndpointSkipsHealthCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/health" {
+			t.Error("Expected no health check with a single endpoint")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	if _, err := client.ListBuckets(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
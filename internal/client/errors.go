@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Errors matched by APIError, to check the kind of a failure with errors.Is
+// whatever the Garage error code.
+var (
+	// ErrNotFound is matched by 404 responses: the object is gone.
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is matched by 409 responses: the request conflicts with
+	// the current state of the object, or with a concurrent change.
+	ErrConflict = errors.New("conflict")
+	// ErrUnauthorized is matched by 401 and 403 responses: the credentials
+	// are invalid or not allowed to perform the request.
+	ErrUnauthorized = errors.New("unauthorized")
+)
+
+// APIError is an error response from the Garage Admin or S3 API. Code and
+// Message are set when Garage returned its structured error body; otherwise
+// Body holds whatever the server sent.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Path       string
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, e.Body)
+	}
+	return fmt.Sprintf("%s (status %d): %s", e.Code, e.StatusCode, e.Message)
+}
+
+// Is reports whether the response matches one of ErrNotFound, ErrConflict and
+// ErrUnauthorized.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	default:
+		return false
+	}
+}
+
+// newAPIError reads an error response and parses Garage's structured error
+// body when there is one.
+func newAPIError(resp *http.Response) error {
+	body, _ := io.ReadAll(resp.Body)
+
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+	}
+
+	var garageErr struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Path    string `json:"path"`
+	}
+	if err := json.Unmarshal(body, &garageErr); err == nil && garageErr.Code != "" {
+		apiErr.Code = garageErr.Code
+		apiErr.Message = garageErr.Message
+		apiErr.Path = garageErr.Path
+	}
+
+	return apiErr
+}
+
+// newS3Error reads an error response of the S3 API and parses its XML error
+// body when there is one.
+func newS3Error(resp *http.Response) error {
+	body, _ := io.ReadAll(resp.Body)
+
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+	}
+
+	var s3Err struct {
+		Code     string `xml:"Code"`
+		Message  string `xml:"Message"`
+		Resource string `xml:"Resource"`
+	}
+	if err := xml.Unmarshal(body, &s3Err); err == nil && s3Err.Code != "" {
+		apiErr.Code = s3Err.Code
+		apiErr.Message = s3Err.Message
+		apiErr.Path = s3Err.Resource
+	}
+
+	return apiErr
+}
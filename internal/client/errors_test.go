@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIError_structured(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"code": "BucketNotEmpty", "message": "Bucket is not empty", "region": "garage", "path": "/v2/DeleteBucket"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	err := client.DeleteBucket(context.Background(), DeleteBucketRequest{ID: "test"})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, got %T: %v", err, err)
+	}
+
+	if apiErr.StatusCode != http.StatusConflict {
+		t.Errorf("Expected status 409, got %d", apiErr.StatusCode)
+	}
+
+	if apiErr.Code != "BucketNotEmpty" || apiErr.Message != "Bucket is not empty" {
+		t.Errorf("Expected BucketNotEmpty error, got %+v", apiErr)
+	}
+
+	if want := "BucketNotEmpty (status 409): Bucket is not empty"; err.Error() != want {
+		t.Errorf("Expected error %q, got %q", want, err.Error())
+	}
+}
+
+func TestAPIError_unstructured(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte("upstream unavailable"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	_, err := client.ListBuckets(context.Background())
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, got %T: %v", err, err)
+	}
+
+	if apiErr.Code != "" {
+		t.Errorf("Expected no error code, got %s", apiErr.Code)
+	}
+
+	if want := "API request failed with status 502: upstream unavailable"; err.Error() != want {
+		t.Errorf("Expected error %q, got %q", want, err.Error())
+	}
+}
+
+func TestAPIError_is(t *testing.T) {
+	tests := []struct {
+		status int
+		target error
+		want   bool
+	}{
+		{status: http.StatusNotFound, target: ErrNotFound, want: true},
+		{status: http.StatusConflict, target: ErrConflict, want: true},
+		{status: http.StatusUnauthorized, target: ErrUnauthorized, want: true},
+		{status: http.StatusForbidden, target: ErrUnauthorized, want: true},
+		{status: http.StatusBadRequest, target: ErrNotFound, want: false},
+		{status: http.StatusNotFound, target: ErrConflict, want: false},
+	}
+
+	for _, tt := range tests {
+		err := fmt.Errorf("wrapped: %w", &APIError{StatusCode: tt.status})
+		if got := errors.Is(err, tt.target); got != tt.want {
+			t.Errorf("errors.Is(%d, %v) = %v, expected %v", tt.status, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestS3Error_structured(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>AccessDenied</Code><Message>Forbidden: no write permission</Message><Resource>/site/index.html</Resource></Error>`))
+	}))
+	defer server.Close()
+
+	s3, err := NewClient("http://localhost:3903", "test-token", WithS3Endpoint(server.URL, DefaultS3Region)).NewS3Client("GK123", "secret")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	_, err = s3.PutObject(context.Background(), PutObjectRequest{Bucket: "site", Key: "index.html", Body: []byte("hello")})
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.Code != "AccessDenied" || apiErr.Path != "/site/index.html" {
+		t.Errorf("Expected AccessDenied error on /site/index.html, got %+v", apiErr)
+	}
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Error("Expected the error to match ErrUnauthorized")
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// K2VClient is a minimal client for the Garage K2V API, authenticated with an
+// access key like the S3 API.
+type K2VClient struct {
+	sigV4Client
+}
+
+// ErrK2VEndpointNotConfigured is returned when a K2V client is requested but no K2V endpoint was configured.
+var ErrK2VEndpointNotConfigured = errors.New("no K2V endpoint configured")
+
+// WithK2VEndpoint sets the Garage K2V API endpoint used by K2V clients created
+// with NewK2VClient. Requests are signed for the S3 region.
+func WithK2VEndpoint(endpoint string) Option {
+	return func(c *Client) {
+		c.k2vEndpoint = strings.TrimSuffix(endpoint, "/")
+	}
+}
+
+// NewK2VClient creates a client for the K2V API configured on c,
+// authenticated with the given access key, or the one configured with
+// WithS3Credentials when accessKeyID is empty.
+func (c *Client) NewK2VClient(accessKeyID, secretAccessKey string) (*K2VClient, error) {
+	if c.k2vEndpoint == "" {
+		return nil, ErrK2VEndpointNotConfigured
+	}
+
+	accessKeyID, secretAccessKey, err := c.s3Credentials(accessKeyID, secretAccessKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &K2VClient{sigV4Client{
+		endpoint:        c.k2vEndpoint,
+		region:          c.s3Region,
+		service:         "k2v",
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+		httpClient:      c.httpClient,
+	}}, nil
+}
+
+// K2VRangeQuery selects the items of a partition to read. Start is inclusive
+// and End exclusive. A zero Limit lets Garage choose how many items to return.
+type K2VRangeQuery struct {
+	PartitionKey string  `json:"partitionKey"`
+	Prefix       *string `json:"prefix"`
+	Start        *string `json:"start"`
+	End          *string `json:"end"`
+	Limit        *int64  `json:"limit"`
+}
+
+// K2VItem represents an item of a partition. Values holds the concurrent
+// values of the item, base64-encoded, with nil for deletions.
+type K2VItem struct {
+	SortKey        string    `json:"sk"`
+	CausalityToken string    `json:"ct"`
+	Values         []*string `json:"v"`
+}
+
+// K2VRangeResult represents one page of the items of a partition. When More is
+// set, the next page starts at NextStart.
+type K2VRangeResult struct {
+	Items     []K2VItem `json:"items"`
+	More      bool      `json:"more"`
+	NextStart *string   `json:"nextStart"`
+}
+
+// ReadItems reads one page of the items of a partition.
+func (k *K2VClient) ReadItems(ctx context.Context, bucket string, query K2VRangeQuery) (*K2VRangeResult, error) {
+	body, err := json.Marshal([]K2VRangeQuery{query})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	headers := http.Header{}
+	headers.Set("Content-Type", "application/json")
+
+	resp, err := k.doRequest(ctx, http.MethodPost, bucket, "", url.Values{"search": {""}}, headers, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var results []K2VRangeResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(results) != 1 {
+		return nil, fmt.Errorf("expected the result of 1 query, got %d", len(results))
+	}
+
+	return &results[0], nil
+}
+
+// EachItem calls fn for every item of a partition selected by query, following
+// pagination, until fn returns an error or query.Limit items were returned.
+func (k *K2VClient) EachItem(ctx context.Context, bucket string, query K2VRangeQuery, fn func(K2VItem) error) error {
+	var remaining int64
+	if query.Limit != nil {
+		remaining = *query.Limit
+	}
+
+	for {
+		if query.Limit != nil {
+			limit := remaining
+			query.Limit = &limit
+		}
+
+		result, err := k.ReadItems(ctx, bucket, query)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range result.Items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+
+		remaining -= int64(len(result.Items))
+		if !result.More || result.NextStart == nil || (query.Limit != nil && remaining <= 0) {
+			return nil
+		}
+
+		query.Start = result.NextStart
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewK2VClient_notConfigured(t *testing.T) {
+	client := NewClient("http://localhost:3903", "test-token")
+	if _, err := client.NewK2VClient("GKtest", "secret"); !errors.Is(err, ErrK2VEndpointNotConfigured) {
+		t.Errorf("Expected ErrK2VEndpointNotConfigured, got %v", err)
+	}
+}
+
+func TestEachItem(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+		if r.URL.Path != "/app-state" || r.URL.RawQuery != "search=" {
+			t.Errorf("Expected /app-state?search=, got %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		if !strings.Contains(r.Header.Get("Authorization"), "/garage/k2v/aws4_request") {
+			t.Errorf("Expected the k2v service in the credential scope, got %s", r.Header.Get("Authorization"))
+		}
+
+		var queries []K2VRangeQuery
+		if err := json.NewDecoder(r.Body).Decode(&queries); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+		if len(queries) != 1 || queries[0].PartitionKey != "users" || queries[0].Prefix == nil || *queries[0].Prefix != "user-" {
+			t.Fatalf("Unexpected queries %+v", queries)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if queries[0].Start == nil {
+			if queries[0].Limit == nil || *queries[0].Limit != 3 {
+				t.Errorf("Expected a limit of 3, got %v", queries[0].Limit)
+			}
+			_, _ = w.Write([]byte(`[{"partitionKey": "users", "items": [
+				{"sk": "user-1", "ct": "ct1", "v": ["YWxpY2U="]},
+				{"sk": "user-2", "ct": "ct2", "v": ["Ym9i", null]}
+			], "more": true, "nextStart": "user-3"}]`))
+			return
+		}
+
+		if *queries[0].Start != "user-3" || queries[0].Limit == nil || *queries[0].Limit != 1 {
+			t.Errorf("Expected the second page to start at user-3 with a limit of 1, got %+v", queries[0])
+		}
+		_, _ = w.Write([]byte(`[{"partitionKey": "users", "items": [
+			{"sk": "user-3", "ct": "ct3", "v": ["Y2Fyb2w="]}
+		], "more": true, "nextStart": "user-4"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient("http://localhost:3903", "test-token", WithK2VEndpoint(server.URL+"/"))
+	k2v, err := client.NewK2VClient("GKtest", "secret")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	prefix := "user-"
+	limit := int64(3)
+	var items []K2VItem
+	err = k2v.EachItem(context.Background(), "app-state", K2VRangeQuery{PartitionKey: "users", Prefix: &prefix, Limit: &limit}, func(item K2VItem) error {
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if calls != 2 {
+		t.Errorf("Expected 2 requests, got %d", calls)
+	}
+	if len(items) != 3 || items[2].SortKey != "user-3" {
+		t.Fatalf("Expected 3 items ending with user-3, got %+v", items)
+	}
+	if len(items[1].Values) != 2 || items[1].Values[1] != nil {
+		t.Errorf("Expected a value and a deletion for user-2, got %+v", items[1].Values)
+	}
+}
+
+func TestReadItems_error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"code": "AccessDenied", "message": "Forbidden: Operation is not allowed for this key.", "region": "garage", "path": "/app-state"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("http://localhost:3903", "test-token", WithK2VEndpoint(server.URL))
+	k2v, err := client.NewK2VClient("GKtest", "secret")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	_, err = k2v.ReadItems(context.Background(), "app-state", K2VRangeQuery{PartitionKey: "users"})
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected an unauthorized error, got %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// maxLoggedBody is the number of bytes of a request or response body that
+// LoggingTransport logs. Longer bodies are truncated.
+const maxLoggedBody = 4096
+
+// redactedValue replaces secrets in logged bodies, like tflog masks fields.
+const redactedValue = "***"
+
+// secretFields are the JSON fields of Admin API bodies holding secrets.
+var secretFields = map[string]bool{
+	"secretAccessKey": true,
+	"secretToken":     true,
+}
+
+// LoggingTransport is an http.RoundTripper that logs the method, path,
+// status, duration and body of each request and its response at TRACE level
+// through tflog, so that failing Admin API calls can be troubleshot from the
+// Terraform logs. Headers are never logged, and secrets are redacted from
+// JSON bodies.
+type LoggingTransport struct {
+	transport http.RoundTripper
+}
+
+// NewLoggingTransport wraps transport, or http.DefaultTransport if nil, in a
+// LoggingTransport.
+func NewLoggingTransport(transport http.RoundTripper) *LoggingTransport {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &LoggingTransport{transport: transport}
+}
+
+// RoundTrip sends req through the wrapped transport and logs the exchange.
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	fields := map[string]interface{}{
+		"http_method": req.Method,
+		"http_path":   req.URL.RequestURI(),
+	}
+
+	// GetBody returns a copy, leaving the body that is sent untouched
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(io.LimitReader(body, maxLoggedBody+1))
+			body.Close()
+			fields["http_request_body"] = loggedBody(data, req.Header)
+		}
+	}
+	tflog.Trace(ctx, "Sending HTTP request", fields)
+
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+	fields["duration"] = time.Since(start).String()
+	delete(fields, "http_request_body")
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Trace(ctx, "HTTP request failed", fields)
+		return nil, err
+	}
+
+	fields["http_status"] = resp.StatusCode
+	if resp.Body != nil {
+		// Only the logged prefix is buffered; the rest is streamed as usual
+		data, readErr := io.ReadAll(io.LimitReader(resp.Body, maxLoggedBody+1))
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(data), resp.Body), resp.Body}
+
+		if readErr == nil {
+			fields["http_response_body"] = loggedBody(data, resp.Header)
+		}
+	}
+	tflog.Trace(ctx, "Received HTTP response", fields)
+
+	return resp, nil
+}
+
+// loggedBody returns the loggable form of a body prefix read with a limit of
+// maxLoggedBody+1 bytes. Compressed and binary bodies are only described.
+func loggedBody(data []byte, header http.Header) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	if encoding := header.Get("Content-Encoding"); encoding != "" {
+		return fmt.Sprintf("(%s-encoded body)", encoding)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	switch mediaType {
+	case "", "application/json", "application/xml", "text/xml", "text/plain":
+	default:
+		return fmt.Sprintf("(%s body)", mediaType)
+	}
+
+	// A truncated JSON body cannot be decoded, so its secrets cannot be redacted
+	isJSON := mediaType == "application/json" || json.Valid(data)
+	if len(data) > maxLoggedBody {
+		if isJSON || mediaType == "" {
+			return fmt.Sprintf("(body longer than %d bytes)", maxLoggedBody)
+		}
+		return string(data[:maxLoggedBody]) + "... (truncated)"
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		if isJSON {
+			return "(invalid JSON body)"
+		}
+		return string(data)
+	}
+
+	redacted, err := json.Marshal(redactSecrets(value))
+	if err != nil {
+		return redactedValue
+	}
+
+	return string(redacted)
+}
+
+// redactSecrets replaces the values of secretFields anywhere in a decoded
+// JSON value.
+func redactSecrets(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, field := range v {
+			if secretFields[name] {
+				v[name] = redactedValue
+			} else {
+				v[name] = redactSecrets(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactSecrets(item)
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestWithHTTPLogging(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"accessKeyId":"GK1","secretAccessKey":"response-secret"}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client := NewClient(server.URL, "test-token", WithHTTPLogging(true))
+	key, err := client.ImportKey(ctx, ImportKeyRequest{AccessKeyID: "GK1", SecretAccessKey: "request-secret"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if key.SecretAccessKey == nil || *key.SecretAccessKey != "response-secret" {
+		t.Error("Expected the response body to be passed on unchanged")
+	}
+
+	logged := output.String()
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected a request and a response entry, got %v", entries)
+	}
+
+	request, response := entries[0], entries[1]
+	if request["http_method"] != http.MethodPost || request["http_path"] != "/v2/ImportKey" {
+		t.Errorf("Expected the method and path to be logged, got %v", request)
+	}
+	if response["http_status"] != float64(http.StatusOK) || response["duration"] == nil {
+		t.Errorf("Expected the status and duration to be logged, got %v", response)
+	}
+
+	for _, secret := range []string{"test-token", "request-secret", "response-secret"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("Expected %s to be redacted, got %s", secret, logged)
+		}
+	}
+	if body, _ := response["http_response_body"].(string); !strings.Contains(body, `"accessKeyId":"GK1"`) {
+		t.Errorf("Expected the response body to be logged, got %v", response["http_response_body"])
+	}
+}
+
+func TestLoggedBody(t *testing.T) {
+	jsonHeader := http.Header{"Content-Type": {"application/json"}}
+
+	tests := []struct {
+		name   string
+		data   string
+		header http.Header
+		want   string
+	}{
+		{name: "empty", header: jsonHeader, want: ""},
+		{name: "nested secret", data: `[{"secretToken":"s","name":"a"}]`, header: jsonHeader, want: `[{"name":"a","secretToken":"***"}]`},
+		{name: "compressed", data: "\x1f\x8b", header: http.Header{"Content-Encoding": {"gzip"}}, want: "(gzip-encoded body)"},
+		{name: "binary", data: "\x89PNG", header: http.Header{"Content-Type": {"image/png"}}, want: "(image/png body)"},
+		{name: "truncated JSON", data: strings.Repeat("a", maxLoggedBody+1), header: jsonHeader, want: "(body longer than 4096 bytes)"},
+		{name: "plain text", data: "Bucket not found", header: http.Header{"Content-Type": {"text/plain"}}, want: "Bucket not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := loggedBody([]byte(tt.data), tt.header); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// NodeInfo represents information about the Garage daemon running on a node.
+type NodeInfo struct {
+	NodeID         string   `json:"nodeId"`
+	GarageVersion  string   `json:"garageVersion"`
+	GarageFeatures []string `json:"garageFeatures"`
+	RustVersion    string   `json:"rustVersion"`
+	DBEngine       string   `json:"dbEngine"`
+}
+
+// NodeInfoResponse represents the information of each node that answered, and
+// the error returned by each node that did not.
+type NodeInfoResponse struct {
+	Success map[string]NodeInfo `json:"success"`
+	Error   map[string]string   `json:"error"`
+}
+
+// GetNodeInfo gets information about the daemon on the given node, which may be
+// a node ID, LocalNode or AllNodes.
+func (c *Client) GetNodeInfo(ctx context.Context, node string) (*NodeInfoResponse, error) {
+	if err := validateNode(node); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodGet, "/v2/GetNodeInfo", url.Values{"node": {node}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result NodeInfoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// NodeStatistics represents the statistics of a node, as the human-readable
+// report printed by `garage stats`.
+type NodeStatistics struct {
+	Freeform string `json:"freeform"`
+}
+
+// NodeStatisticsResponse represents the statistics of each node that answered,
+// and the error returned by each node that did not.
+type NodeStatisticsResponse struct {
+	Success map[string]NodeStatistics `json:"success"`
+	Error   map[string]string         `json:"error"`
+}
+
+// GetNodeStatistics gets the statistics of the given node, which may be a node
+// ID, LocalNode or AllNodes.
+func (c *Client) GetNodeStatistics(ctx context.Context, node string) (*NodeStatisticsResponse, error) {
+	if err := validateNode(node); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodGet, "/v2/GetNodeStatistics", url.Values{"node": {node}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result NodeStatisticsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// CreateMetadataSnapshotResponse represents the nodes that snapshotted their
+// metadata database, and the error returned by each node that did not.
+type CreateMetadataSnapshotResponse struct {
+	Success map[string]json.RawMessage `json:"success"`
+	Error   map[string]string          `json:"error"`
+}
+
+// CreateMetadataSnapshot snapshots the metadata database of the given node,
+// which may be a node ID, LocalNode or AllNodes, like `garage meta snapshot`.
+func (c *Client) CreateMetadataSnapshot(ctx context.Context, node string) (*CreateMetadataSnapshotResponse, error) {
+	if err := validateNode(node); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/CreateMetadataSnapshot", url.Values{"node": {node}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result CreateMetadataSnapshotResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
+}
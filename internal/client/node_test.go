@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetNodeInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		if r.URL.Path != "/v2/GetNodeInfo" {
+			t.Errorf("Expected path /v2/GetNodeInfo, got %s", r.URL.Path)
+		}
+		if node := r.URL.Query().Get("node"); node != "self" {
+			t.Errorf("Expected node self, got %s", node)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"success": {
+				"node-1": {
+					"nodeId": "node-1",
+					"garageVersion": "v2.1.0",
+					"garageFeatures": ["k2v", "lmdb", "metrics"],
+					"rustVersion": "1.84.0",
+					"dbEngine": "LMDB (using Heed crate)"
+				}
+			},
+			"error": {}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	result, err := client.GetNodeInfo(context.Background(), LocalNode)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	info, ok := result.Success["node-1"]
+	if !ok {
+		t.Fatalf("Expected info for node-1, got %+v", result.Success)
+	}
+
+	if info.GarageVersion != "v2.1.0" {
+		t.Errorf("Expected version v2.1.0, got %s", info.GarageVersion)
+	}
+
+	if len(info.GarageFeatures) != 3 || info.GarageFeatures[0] != "k2v" {
+		t.Errorf("Expected features [k2v lmdb metrics], got %v", info.GarageFeatures)
+	}
+}
+
+func TestGetNodeStatistics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/GetNodeStatistics" {
+			t.Errorf("Expected path /v2/GetNodeStatistics, got %s", r.URL.Path)
+		}
+		if node := r.URL.Query().Get("node"); node != "node-1" {
+			t.Errorf("Expected node node-1, got %s", node)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"success": {"node-1": {"freeform": "Garage version: v2.1.0\nDatabase engine: LMDB"}},
+			"error": {}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	result, err := client.GetNodeStatistics(context.Background(), "node-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	stats, ok := result.Success["node-1"]
+	if !ok {
+		t.Fatalf("Expected statistics for node-1, got %+v", result.Success)
+	}
+
+	if stats.Freeform != "Garage version: v2.1.0\nDatabase engine: LMDB" {
+		t.Errorf("Unexpected statistics %q", stats.Freeform)
+	}
+}
+
+func TestCreateMetadataSnapshot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+		if r.URL.Path != "/v2/CreateMetadataSnapshot" {
+			t.Errorf("Expected path /v2/CreateMetadataSnapshot, got %s", r.URL.Path)
+		}
+		if node := r.URL.Query().Get("node"); node != "*" {
+			t.Errorf("Expected node *, got %s", node)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"success": {"node-1": null},
+			"error": {"node-2": "snapshot directory is not writable"}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	result, err := client.CreateMetadataSnapshot(context.Background(), AllNodes)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if _, ok := result.Success["node-1"]; !ok {
+		t.Errorf("Expected node-1 to snapshot its metadata, got %+v", result.Success)
+	}
+
+	if got := result.Error["node-2"]; got != "snapshot directory is not writable" {
+		t.Errorf("Expected error for node-2, got %q", got)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ObjectBlock represents a data block of an object version.
+type ObjectBlock struct {
+	PartNumber int64  `json:"partNumber"`
+	Offset     int64  `json:"offset"`
+	Hash       string `json:"hash"`
+	Size       int64  `json:"size"`
+}
+
+// ObjectVersion represents a version of an object, as stored by Garage.
+type ObjectVersion struct {
+	UUID         string        `json:"uuid"`
+	Timestamp    string        `json:"timestamp"`
+	Aborted      bool          `json:"aborted"`
+	DeleteMarker bool          `json:"deleteMarker"`
+	Inline       bool          `json:"inline"`
+	Uploading    bool          `json:"uploading"`
+	Encrypted    bool          `json:"encrypted"`
+	Size         *int64        `json:"size,omitempty"`
+	ETag         *string       `json:"etag,omitempty"`
+	Headers      [][2]string   `json:"headers"`
+	Blocks       []ObjectBlock `json:"blocks"`
+}
+
+// Complete reports whether the version was fully uploaded.
+func (v ObjectVersion) Complete() bool {
+	return !v.Aborted && !v.Uploading
+}
+
+// InspectObjectResponse represents the versions Garage stores for an object.
+type InspectObjectResponse struct {
+	BucketID string          `json:"bucketId"`
+	Key      string          `json:"key"`
+	Versions []ObjectVersion `json:"versions"`
+}
+
+// CurrentVersion returns the most recent complete version of the object, or
+// nil if it has none. Versions are listed from the oldest to the newest.
+func (r *InspectObjectResponse) CurrentVersion() *ObjectVersion {
+	for i := len(r.Versions) - 1; i >= 0; i-- {
+		if r.Versions[i].Complete() {
+			return &r.Versions[i]
+		}
+	}
+	return nil
+}
+
+// InspectObject gets the versions and data blocks of an object, whether or not
+// it was deleted. It returns an error matching ErrNotFound when the bucket
+// holds no version of the key.
+func (c *Client) InspectObject(ctx context.Context, bucketID, key string) (*InspectObjectResponse, error) {
+	if err := validateID("bucket", bucketID); err != nil {
+		return nil, err
+	}
+
+	if key == "" {
+		return nil, errors.New("the object key must not be empty")
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodGet, "/v2/InspectObject", url.Values{"bucketId": {bucketID}, "key": {key}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result InspectObjectResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInspectObject(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		if r.URL.Path != "/v2/InspectObject" {
+			t.Errorf("Expected path /v2/InspectObject, got %s", r.URL.Path)
+		}
+		if bucketID := r.URL.Query().Get("bucketId"); bucketID != "bucket-1" {
+			t.Errorf("Expected bucketId bucket-1, got %s", bucketID)
+		}
+		if key := r.URL.Query().Get("key"); key != "dir/a b&c.txt" {
+			t.Errorf("Expected key %q, got %q", "dir/a b&c.txt", key)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"bucketId": "bucket-1",
+			"key": "dir/a b&c.txt",
+			"versions": [
+				{
+					"uuid": "v1", "timestamp": "2025-01-01T00:00:00Z", "aborted": false, "deleteMarker": false,
+					"inline": false, "uploading": false, "encrypted": true, "size": 2097152, "etag": "abc",
+					"headers": [["content-type", "text/plain"]],
+					"blocks": [{"partNumber": 1, "offset": 0, "hash": "h1", "size": 1048576}, {"partNumber": 1, "offset": 1048576, "hash": "h2", "size": 1048576}]
+				},
+				{
+					"uuid": "v2", "timestamp": "2025-01-02T00:00:00Z", "aborted": false, "deleteMarker": false,
+					"inline": false, "uploading": true, "encrypted": false, "headers": [], "blocks": []
+				}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	result, err := client.InspectObject(context.Background(), "bucket-1", "dir/a b&c.txt")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(result.Versions) != 2 {
+		t.Fatalf("Expected 2 versions, got %d", len(result.Versions))
+	}
+
+	current := result.CurrentVersion()
+	if current == nil || current.UUID != "v1" {
+		t.Fatalf("Expected the uploading version to be skipped, got %+v", current)
+	}
+
+	if !current.Encrypted || current.Size == nil || *current.Size != 2097152 || current.ETag == nil || *current.ETag != "abc" {
+		t.Errorf("Unexpected current version %+v", current)
+	}
+
+	if len(current.Headers) != 1 || current.Headers[0] != [2]string{"content-type", "text/plain"} {
+		t.Errorf("Unexpected headers %v", current.Headers)
+	}
+
+	if len(current.Blocks) != 2 || current.Blocks[1].Offset != 1048576 || current.Blocks[1].Hash != "h2" {
+		t.Errorf("Unexpected blocks %+v", current.Blocks)
+	}
+}
+
+func TestInspectObject_notFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code": "NoSuchKey", "message": "Key not found", "region": "garage", "path": "/v2/InspectObject"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	_, err := client.InspectObject(context.Background(), "bucket-1", "missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
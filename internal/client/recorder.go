@@ -0,0 +1,235 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"sync"
+)
+
+// RecorderMode selects whether a Recorder talks to a real cluster or replays
+// a previous recording.
+type RecorderMode int
+
+const (
+	// RecorderModeReplay answers requests from the cassette without any
+	// network access, failing on requests that differ from the recording.
+	RecorderModeReplay RecorderMode = iota
+	// RecorderModeRecord forwards requests to the cluster and records them.
+	RecorderModeRecord
+)
+
+// Cassette is a recorded sequence of HTTP interactions.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and the response it received.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the part of a request replays are matched on. The
+// Authorization header is never recorded.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URI    string `json:"uri"`
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse is a recorded response with its decompressed body.
+type RecordedResponse struct {
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body"`
+}
+
+// Recorder is an http.RoundTripper that records interactions with a Garage
+// cluster to a cassette file and replays them later, so client behavior can be
+// captured once against a real cluster and tested without one.
+type Recorder struct {
+	mode      RecorderMode
+	path      string
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	next     int
+}
+
+// NewRecorder creates a recorder for the cassette at path. In replay mode the
+// cassette is loaded immediately. In record mode requests are sent through
+// transport, or http.DefaultTransport if nil, and the cassette is written by
+// Save.
+func NewRecorder(path string, mode RecorderMode, transport http.RoundTripper) (*Recorder, error) {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	r := &Recorder{mode: mode, path: path, transport: transport}
+
+	if mode == RecorderModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("failed to decode cassette %s: %w", path, err)
+		}
+	}
+
+	return r, nil
+}
+
+// RoundTrip records or replays a single request.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := recordRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.mode == RecorderModeRecord {
+		return r.record(req, recorded)
+	}
+	return r.replay(req, recorded)
+}
+
+// Remaining returns the number of recorded interactions that were not
+// replayed yet. A replay that ends with interactions left means the client
+// made fewer requests than when the cassette was recorded.
+func (r *Recorder) Remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.cassette.Interactions) - r.next
+}
+
+// Save writes the recorded interactions to the cassette file.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode cassette: %w", err)
+	}
+
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+func (r *Recorder) record(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	// Let the transport negotiate compression so the recorded body is plain text
+	req = req.Clone(req.Context())
+	req.Header.Del("Accept-Encoding")
+	if recorded.Body != "" {
+		req.Body = io.NopCloser(bytes.NewReader([]byte(recorded.Body)))
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	interaction := Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode:  resp.StatusCode,
+			ContentType: resp.Header.Get("Content-Type"),
+			Body:        string(body),
+		},
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	return interaction.Response.toHTTP(req), nil
+}
+
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.next >= len(r.cassette.Interactions) {
+		return nil, fmt.Errorf("unexpected request %s %s: all %d recorded interactions were replayed", recorded.Method, recorded.URI, len(r.cassette.Interactions))
+	}
+
+	interaction := r.cassette.Interactions[r.next]
+	if err := interaction.Request.match(recorded); err != nil {
+		return nil, fmt.Errorf("request %d does not match the recording: %w", r.next, err)
+	}
+	r.next++
+
+	return interaction.Response.toHTTP(req), nil
+}
+
+// recordRequest captures the matched fields of req, restoring its body.
+func recordRequest(req *http.Request) (RecordedRequest, error) {
+	recorded := RecordedRequest{Method: req.Method, URI: req.URL.RequestURI()}
+
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return recorded, fmt.Errorf("failed to read request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		recorded.Body = string(body)
+	}
+
+	return recorded, nil
+}
+
+// match reports how got differs from the recorded request. JSON bodies are
+// compared by value, so field order does not matter.
+func (want RecordedRequest) match(got RecordedRequest) error {
+	if got.Method != want.Method || got.URI != want.URI {
+		return fmt.Errorf("got %s %s, want %s %s", got.Method, got.URI, want.Method, want.URI)
+	}
+
+	if got.Body == want.Body {
+		return nil
+	}
+
+	var gotJSON, wantJSON interface{}
+	if json.Unmarshal([]byte(got.Body), &gotJSON) == nil &&
+		json.Unmarshal([]byte(want.Body), &wantJSON) == nil &&
+		reflect.DeepEqual(gotJSON, wantJSON) {
+		return nil
+	}
+
+	return fmt.Errorf("%s %s: got body %s, want %s", got.Method, got.URI, got.Body, want.Body)
+}
+
+func (r RecordedResponse) toHTTP(req *http.Request) *http.Response {
+	header := http.Header{}
+	if r.ContentType != "" {
+		header.Set("Content-Type", r.ContentType)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(r.Body))),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
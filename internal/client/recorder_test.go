@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newRecordingClient returns a client that replays the cassette at path, or
+// records it against the cluster in GARAGE_ENDPOINT when GARAGE_RECORD is set.
+// The cassette is written when the test finishes successfully.
+func newRecordingClient(t *testing.T, path string) (*Client, *Recorder) {
+	t.Helper()
+
+	mode := RecorderModeReplay
+	endpoint := "http://garage.invalid"
+	token := "replay-token"
+
+	if os.Getenv("GARAGE_RECORD") != "" {
+		mode = RecorderModeRecord
+		endpoint = os.Getenv("GARAGE_ENDPOINT")
+		token = os.Getenv("GARAGE_TOKEN")
+		if endpoint == "" || token == "" {
+			t.Fatal("GARAGE_ENDPOINT and GARAGE_TOKEN must be set to record cassettes")
+		}
+	}
+
+	recorder, err := NewRecorder(path, mode, nil)
+	if err != nil {
+		t.Fatalf("Failed to create recorder: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if t.Failed() {
+			return
+		}
+		if mode == RecorderModeRecord {
+			if err := recorder.Save(); err != nil {
+				t.Errorf("Failed to save cassette: %v", err)
+			}
+		} else if n := recorder.Remaining(); n > 0 {
+			t.Errorf("Expected all recorded interactions to be replayed, %d left", n)
+		}
+	})
+
+	return NewClient(endpoint, token, WithHTTPClient(&http.Client{Transport: recorder})), recorder
+}
+
+func TestReplay_bucketLifecycle(t *testing.T) {
+	client, _ := newRecordingClient(t, filepath.Join("testdata", "bucket_lifecycle.json"))
+	ctx := context.Background()
+
+	alias := "vcr-test-bucket"
+	bucket, err := client.CreateBucket(ctx, CreateBucketRequest{GlobalAlias: &alias})
+	if err != nil {
+		t.Fatalf("CreateBucket: %v", err)
+	}
+
+	info, err := client.GetBucketInfo(ctx, GetBucketInfoRequest{ID: &bucket.ID})
+	if err != nil {
+		t.Fatalf("GetBucketInfo: %v", err)
+	}
+	if len(info.GlobalAliases) != 1 || info.GlobalAliases[0] != alias {
+		t.Errorf("Expected global alias %s, got %v", alias, info.GlobalAliases)
+	}
+
+	name := "vcr-test-key"
+	key, err := client.CreateKey(ctx, CreateKeyRequest{Name: &name})
+	if err != nil {
+		t.Fatalf("CreateKey: %v", err)
+	}
+	if key.SecretAccessKey == nil {
+		t.Error("Expected CreateKey to return the secret access key")
+	}
+
+	updated, err := client.AllowBucketKey(ctx, BucketKeyPermRequest{
+		BucketID:    bucket.ID,
+		AccessKeyID: key.AccessKeyID,
+		Permissions: Permissions{Read: true, Write: true},
+	})
+	if err != nil {
+		t.Fatalf("AllowBucketKey: %v", err)
+	}
+	if len(updated.Keys) != 1 || !updated.Keys[0].Permissions.Write {
+		t.Errorf("Expected key with write permission, got %+v", updated.Keys)
+	}
+
+	if err := client.DeleteKey(ctx, DeleteKeyRequest{ID: key.AccessKeyID}); err != nil {
+		t.Fatalf("DeleteKey: %v", err)
+	}
+
+	if err := client.DeleteBucket(ctx, DeleteBucketRequest{ID: bucket.ID}); err != nil {
+		t.Fatalf("DeleteBucket: %v", err)
+	}
+}
+
+func TestRecorder_recordThenReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			t.Error("Expected the Authorization header to reach the server")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"bucket-1","globalAliases":["recorded"],"keys":[]}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder, err := NewRecorder(path, RecorderModeRecord, nil)
+	if err != nil {
+		t.Fatalf("Failed to create recorder: %v", err)
+	}
+	client := NewClient(server.URL, "secret-token", WithHTTPClient(&http.Client{Transport: recorder}))
+
+	id := "bucket-1"
+	if _, err := client.GetBucketInfo(context.Background(), GetBucketInfoRequest{ID: &id}); err != nil {
+		t.Fatalf("Expected no error while recording, got %v", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Failed to save cassette: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read cassette: %v", err)
+	}
+	if strings.Contains(string(data), "secret-token") {
+		t.Error("Expected the admin token to be left out of the cassette")
+	}
+
+	replayer, err := NewRecorder(path, RecorderModeReplay, nil)
+	if err != nil {
+		t.Fatalf("Failed to load cassette: %v", err)
+	}
+	client = NewClient("http://garage.invalid", "other-token", WithHTTPClient(&http.Client{Transport: replayer}))
+
+	bucket, err := client.GetBucketInfo(context.Background(), GetBucketInfoRequest{ID: &id})
+	if err != nil {
+		t.Fatalf("Expected no error while replaying, got %v", err)
+	}
+	if bucket.GlobalAliases[0] != "recorded" {
+		t.Errorf("Expected the recorded bucket, got %+v", bucket)
+	}
+	if replayer.Remaining() != 0 {
+		t.Errorf("Expected no interactions left, got %d", replayer.Remaining())
+	}
+}
+
+func TestRecorder_replayMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	cassette := `{"interactions": [{
+		"request": {"method": "POST", "uri": "/v2/CreateBucket", "body": "{\"globalAlias\":\"expected\"}"},
+		"response": {"status_code": 200, "body": "{}"}
+	}]}`
+	if err := os.WriteFile(path, []byte(cassette), 0o644); err != nil {
+		t.Fatalf("Failed to write cassette: %v", err)
+	}
+
+	replayer, err := NewRecorder(path, RecorderModeReplay, nil)
+	if err != nil {
+		t.Fatalf("Failed to load cassette: %v", err)
+	}
+	client := NewClient("http://garage.invalid", "token", WithHTTPClient(&http.Client{Transport: replayer}))
+
+	alias := "different"
+	_, err = client.CreateBucket(context.Background(), CreateBucketRequest{GlobalAlias: &alias})
+	if err == nil || !strings.Contains(err.Error(), "does not match the recording") {
+		t.Errorf("Expected a mismatch error, got %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Repair operations accepted by LaunchRepairOperation.
+const (
+	RepairTables           = "tables"
+	RepairBlocks           = "blocks"
+	RepairVersions         = "versions"
+	RepairMultipartUploads = "multipartUploads"
+	RepairBlockRefs        = "blockRefs"
+	RepairBlockRc          = "blockRc"
+	RepairRebalance        = "rebalance"
+	RepairScrub            = "scrub"
+)
+
+// Commands of the scrub repair operation.
+const (
+	ScrubStart  = "start"
+	ScrubPause  = "pause"
+	ScrubResume = "resume"
+	ScrubCancel = "cancel"
+)
+
+// BlockError represents a data block a node failed to resynchronize.
+type BlockError struct {
+	BlockHash      string `json:"blockHash"`
+	Refcount       int64  `json:"refcount"`
+	ErrorCount     int64  `json:"errorCount"`
+	LastTrySecsAgo int64  `json:"lastTrySecsAgo"`
+	NextTryInSecs  int64  `json:"nextTryInSecs"`
+}
+
+// ListBlockErrorsResponse represents the block errors of each node that
+// answered, and the error returned by each node that did not.
+type ListBlockErrorsResponse struct {
+	Success map[string][]BlockError `json:"success"`
+	Error   map[string]string       `json:"error"`
+}
+
+// LaunchRepairOperationRequest represents the request to launch a repair
+// operation. ScrubCommand is only used, and required, for RepairScrub.
+type LaunchRepairOperationRequest struct {
+	RepairType   string
+	ScrubCommand string
+}
+
+// MarshalJSON encodes the repair type, which is a plain string except for
+// scrubs, sent as {"scrub": <command>}.
+func (r LaunchRepairOperationRequest) MarshalJSON() ([]byte, error) {
+	var repairType interface{} = r.RepairType
+	if r.RepairType == RepairScrub {
+		repairType = map[string]string{RepairScrub: r.ScrubCommand}
+	}
+
+	return json.Marshal(struct {
+		RepairType interface{} `json:"repairType"`
+	}{RepairType: repairType})
+}
+
+// LaunchRepairOperationResponse represents the nodes that launched the repair
+// operation, and the error returned by each node that did not.
+type LaunchRepairOperationResponse struct {
+	Success map[string]json.RawMessage `json:"success"`
+	Error   map[string]string          `json:"error"`
+}
+
+// ListBlockErrors lists the data blocks the given node, which may be a node
+// ID, LocalNode or AllNodes, failed to resynchronize.
+func (c *Client) ListBlockErrors(ctx context.Context, node string) (*ListBlockErrorsResponse, error) {
+	if err := validateNode(node); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodGet, "/v2/ListBlockErrors", url.Values{"node": {node}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result ListBlockErrorsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// LaunchRepairOperation launches a repair operation on the given node, which
+// may be a node ID, LocalNode or AllNodes. The operation runs in the
+// background; the call returns once the nodes have started it.
+func (c *Client) LaunchRepairOperation(ctx context.Context, node string, req LaunchRepairOperationRequest) (*LaunchRepairOperationResponse, error) {
+	if err := validateNode(node); err != nil {
+		return nil, err
+	}
+
+	if req.RepairType == RepairScrub && req.ScrubCommand == "" {
+		return nil, fmt.Errorf("the %s repair operation requires a scrub command", RepairScrub)
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/LaunchRepairOperation", url.Values{"node": {node}}, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result LaunchRepairOperationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListBlockErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+		if r.URL.Path != "/v2/ListBlockErrors" {
+			t.Errorf("Expected path /v2/ListBlockErrors, got %s", r.URL.Path)
+		}
+		if node := r.URL.Query().Get("node"); node != "*" {
+			t.Errorf("Expected node *, got %s", node)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"success": {
+				"node-1": [{"blockHash": "ab12", "refcount": 2, "errorCount": 3, "lastTrySecsAgo": 60, "nextTryInSecs": 120}],
+				"node-2": []
+			},
+			"error": {"node-3": "node is down"}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	result, err := client.ListBlockErrors(context.Background(), AllNodes)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	blockErrors := result.Success["node-1"]
+	if len(blockErrors) != 1 || blockErrors[0].BlockHash != "ab12" || blockErrors[0].ErrorCount != 3 || blockErrors[0].NextTryInSecs != 120 {
+		t.Errorf("Unexpected block errors on node-1: %+v", blockErrors)
+	}
+
+	if len(result.Success["node-2"]) != 0 {
+		t.Errorf("Expected no block errors on node-2, got %+v", result.Success["node-2"])
+	}
+
+	if got := result.Error["node-3"]; got != "node is down" {
+		t.Errorf("Expected error for node-3, got %q", got)
+	}
+}
+
+func TestLaunchRepairOperation(t *testing.T) {
+	tests := []struct {
+		name string
+		req  LaunchRepairOperationRequest
+		want string
+	}{
+		{
+			name: "blocks",
+			req:  LaunchRepairOperationRequest{RepairType: RepairBlocks},
+			want: `{"repairType":"blocks"}`,
+		},
+		{
+			name: "scrub",
+			req:  LaunchRepairOperationRequest{RepairType: RepairScrub, ScrubCommand: ScrubStart},
+			want: `{"repairType":{"scrub":"start"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("Expected POST request, got %s", r.Method)
+				}
+				if r.URL.Path != "/v2/LaunchRepairOperation" {
+					t.Errorf("Expected path /v2/LaunchRepairOperation, got %s", r.URL.Path)
+				}
+				if node := r.URL.Query().Get("node"); node != "self" {
+					t.Errorf("Expected node self, got %s", node)
+				}
+
+				body, _ := io.ReadAll(r.Body)
+				if string(body) != tt.want {
+					t.Errorf("Expected body %s, got %s", tt.want, body)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"success": {"node-1": null}, "error": {}}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token")
+			result, err := client.LaunchRepairOperation(context.Background(), LocalNode, tt.req)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if _, ok := result.Success["node-1"]; !ok || len(result.Error) != 0 {
+				t.Errorf("Expected node-1 to launch the repair, got %+v", result)
+			}
+		})
+	}
+}
+
+func TestLaunchRepairOperation_scrubWithoutCommand(t *testing.T) {
+	client := NewClient("http://garage.invalid:3903", "test-token")
+	if _, err := client.LaunchRepairOperation(context.Background(), LocalNode, LaunchRepairOperationRequest{RepairType: RepairScrub}); err == nil {
+		t.Fatal("Expected an error for a scrub without command")
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// Defaults of the retry settings exposed by the provider.
+const (
+	DefaultMaxRetries   = 3
+	DefaultRetryWaitMin = 1 * time.Second
+	DefaultRetryWaitMax = 30 * time.Second
+)
+
+// retryPolicy describes how requests failing with a transient error are
+// retried. The zero value disables retries.
+type retryPolicy struct {
+	maxRetries int
+	waitMin    time.Duration
+	waitMax    time.Duration
+}
+
+// WithRetries retries Admin API requests failing with a transient error (the
+// endpoint being unreachable, 429 Too Many Requests or a 5xx response) up to
+// maxRetries times. Requests that create something, such as CreateKey, are
+// only retried when they could not be sent or were rejected with 429, so that
+// Garage never applies them twice. The wait between attempts grows
+// exponentially from waitMin to waitMax, with jitter so that concurrent
+// requests do not retry in lockstep.
+func WithRetries(maxRetries int, waitMin, waitMax time.Duration) Option {
+	return func(c *Client) {
+		c.retry = retryPolicy{
+			maxRetries: maxRetries,
+			waitMin:    waitMin,
+			waitMax:    max(waitMin, waitMax),
+		}
+	}
+}
+
+// idempotentEndpoints are the Admin API endpoints called with POST that leave
+// Garage in the same state however many times they are called.
+var idempotentEndpoints = map[string]bool{
+	"AddBucketAlias":              true,
+	"AllowBucketKey":              true,
+	"CleanupIncompleteUploads":    true,
+	"ConnectClusterNodes":         true,
+	"DenyBucketKey":               true,
+	"GetWorkerInfo":               true,
+	"GetWorkerVariable":           true,
+	"ListWorkers":                 true,
+	"PreviewClusterLayoutChanges": true,
+	"SetWorkerVariable":           true,
+	"UpdateAdminToken":            true,
+	"UpdateBucket":                true,
+	"UpdateClusterLayout":         true,
+	"UpdateKey":                   true,
+}
+
+// isIdempotent reports whether sending req more than once has the same effect
+// as sending it once.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		return idempotentEndpoints[endpointName(req.URL.Path)]
+	}
+	return false
+}
+
+// canResend reports whether a request that failed with the transport error
+// err may be sent again, to the same or another endpoint. Other requests than
+// idempotent ones are only sent again when the connection could not be
+// established, as Garage may otherwise have applied them before the response
+// was lost or the request timed out.
+func canResend(req *http.Request, err error) bool {
+	if isIdempotent(req) {
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// isTransient reports whether req, which returned resp and err, may succeed
+// when sent again.
+func isTransient(ctx context.Context, req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		// Requests canceled by the caller must not be retried
+		return ctx.Err() == nil && canResend(req, err)
+	}
+
+	// Garage did not process requests rejected with 429
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	return isIdempotent(req) && resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented
+}
+
+// wait returns how long to wait before the given retry (starting at 0). A
+// Retry-After header in resp takes precedence over the backoff, within
+// waitMax.
+func (p retryPolicy) wait(retry int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, p.waitMax)
+		}
+	}
+
+	backoff := p.waitMax
+	if retry < 32 {
+		backoff = min(p.waitMin<<retry, p.waitMax)
+	}
+	if backoff <= 0 {
+		return 0
+	}
+
+	// Pick a random wait in the upper half of the backoff
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+// do sends the request built by newRequest with send, sending a new one while
+// the previous attempt failed with a transient error and retries are left.
+func (p retryPolicy) do(ctx context.Context, send func(*http.Request) (*http.Response, error), newRequest func() (*http.Request, error), record func(time.Duration)) (*http.Response, error) {
+	for retry := 0; ; retry++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		start := time.Now()
+		resp, err := send(req)
+		record(time.Since(start))
+
+		if retry >= p.maxRetries || !isTransient(ctx, req, resp, err) {
+			if err != nil {
+				return nil, fmt.Errorf("failed to execute request: %w", err)
+			}
+			return resp, nil
+		}
+
+		delay := p.wait(retry, resp)
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// conflictBackoff is the wait before each retry of a request that conflicted
+// with a concurrent change. It is a variable so tests can shorten it.
+var conflictBackoff = []time.Duration{
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	1 * time.Second,
+	2 * time.Second,
+}
+
+// retryOnConflict calls fn until it succeeds, fails with an error other than
+// a conflict, or the backoff is exhausted. It returns the last error.
+func retryOnConflict(ctx context.Context, fn func() error) error {
+	err := fn()
+
+	for _, delay := range conflictBackoff {
+		if !errors.Is(err, ErrConflict) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+
+		err = fn()
+	}
+
+	return err
+}
+
+// bucketHasAlias reports whether the bucket currently has the global alias.
+func (c *Client) bucketHasAlias(ctx context.Context, bucketID, alias string) (bool, error) {
+	bucket, err := c.GetBucketInfo(ctx, GetBucketInfoRequest{ID: &bucketID})
+	if err != nil || bucket == nil {
+		return false, err
+	}
+
+	return slices.Contains(bucket.GlobalAliases, alias), nil
+}
@@ -0,0 +1,301 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// shortenConflictBackoff makes conflict retries immediate for the duration of the test.
+func shortenConflictBackoff(t *testing.T) {
+	t.Helper()
+	saved := conflictBackoff
+	conflictBackoff = []time.Duration{0, 0, 0}
+	t.Cleanup(func() { conflictBackoff = saved })
+}
+
+func conflictResponse(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusConflict)
+	_, _ = w.Write([]byte(`{"code":"Conflict","message":"concurrent update","region":"garage","path":"/v2/AddBucketAlias"}`))
+}
+
+func TestAddBucketAlias_retriesConflicts(t *testing.T) {
+	shortenConflictBackoff(t)
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			conflictResponse(w)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	if err := client.AddBucketAlias(context.Background(), "bucket-123", "new-alias"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+}
+
+func TestAddBucketAlias_conflictAlreadyApplied(t *testing.T) {
+	shortenConflictBackoff(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/GetBucketInfo" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"bucket-123","globalAliases":["new-alias"]}`))
+			return
+		}
+		conflictResponse(w)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	if err := client.AddBucketAlias(context.Background(), "bucket-123", "new-alias"); err != nil {
+		t.Fatalf("Expected no error once the alias is present, got %v", err)
+	}
+}
+
+func TestRemoveBucketAlias_conflictNotApplied(t *testing.T) {
+	shortenConflictBackoff(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/GetBucketInfo" {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"bucket-123","globalAliases":["old-alias"]}`))
+			return
+		}
+		conflictResponse(w)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	err := client.RemoveBucketAlias(context.Background(), "bucket-123", "old-alias")
+	if !errors.Is(err, ErrConflict) {
+		t.Fatalf("Expected a conflict error, got %v", err)
+	}
+}
+
+func TestRetryOnConflict_otherErrors(t *testing.T) {
+	shortenConflictBackoff(t)
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	if err := client.AddBucketAlias(context.Background(), "bucket-123", "new-alias"); err == nil {
+		t.Fatal("Expected an error")
+	}
+
+	if calls != 1 {
+		t.Errorf("Expected errors other than conflicts not to be retried, got %d calls", calls)
+	}
+}
+
+func TestWithRetries_transientErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		wantCalls int
+	}{
+		{name: "service unavailable", status: http.StatusServiceUnavailable, wantCalls: 3},
+		{name: "internal error", status: http.StatusInternalServerError, wantCalls: 3},
+		{name: "too many requests", status: http.StatusTooManyRequests, wantCalls: 3},
+		{name: "not implemented", status: http.StatusNotImplemented, wantCalls: 1},
+		{name: "bad request", status: http.StatusBadRequest, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if calls < 3 {
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`[]`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "test-token", WithRetries(5, time.Millisecond, time.Millisecond))
+			_, _ = client.ListAdminTokens(context.Background())
+
+			if calls != tt.wantCalls {
+				t.Errorf("Expected %d calls, got %d", tt.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestWithRetries_nonIdempotent(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		wantCalls int
+	}{
+		{name: "service unavailable", status: http.StatusServiceUnavailable, wantCalls: 1},
+		{name: "internal error", status: http.StatusInternalServerError, wantCalls: 1},
+		{name: "too many requests", status: http.StatusTooManyRequests, wantCalls: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				if calls < 3 {
+					w.WriteHeader(tt.status)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"accessKeyId":"GK123","name":"app"}`))
+			}))
+			defer server.Close()
+
+			name := "app"
+			client := NewClient(server.URL, "test-token", WithRetries(5, time.Millisecond, time.Millisecond))
+			_, _ = client.CreateKey(context.Background(), CreateKeyRequest{Name: &name})
+
+			if calls != tt.wantCalls {
+				t.Errorf("Expected %d calls, got %d", tt.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestCanResend(t *testing.T) {
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+	readErr := &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+
+	tests := []struct {
+		method, path string
+		err          error
+		want         bool
+	}{
+		{method: http.MethodGet, path: "/v2/GetKeyInfo", err: readErr, want: true},
+		{method: http.MethodPost, path: "/v2/UpdateBucket", err: context.DeadlineExceeded, want: true},
+		{method: http.MethodPost, path: "/v2/CreateKey", err: dialErr, want: true},
+		{method: http.MethodPost, path: "/v2/CreateKey", err: readErr, want: false},
+		{method: http.MethodPost, path: "/v2/CreateAdminToken", err: context.DeadlineExceeded, want: false},
+		{method: http.MethodPost, path: "/v2/CreateBucket?x=1", err: &url.Error{Op: "Post", Err: dialErr}, want: true},
+	}
+
+	for _, tt := range tests {
+		req, err := http.NewRequest(tt.method, tt.path, nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if got := canResend(req, tt.err); got != tt.want {
+			t.Errorf("canResend(%s %s, %v) = %t, expected %t", tt.method, tt.path, tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestWithRetries_resendsBody(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", WithRetries(1, time.Millisecond, time.Millisecond))
+	if err := client.AddBucketAlias(context.Background(), "bucket-123", "new-alias"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(bodies) != 2 || bodies[0] != bodies[1] || bodies[1] == "" {
+		t.Errorf("Expected the same body to be sent twice, got %q", bodies)
+	}
+}
+
+func TestWithRetries_exhausted(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token", WithRetries(2, time.Millisecond, time.Millisecond))
+	_, err := client.ListAdminTokens(context.Background())
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Expected the last 503 response as error, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+}
+
+func TestWithRetries_unreachable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	endpoint := server.URL
+	server.Close()
+
+	client := NewClient(endpoint, "test-token", WithRetries(2, time.Millisecond, time.Millisecond))
+	_, err := client.ListAdminTokens(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "failed to execute request") {
+		t.Fatalf("Expected a connection error, got %v", err)
+	}
+}
+
+func TestRetryPolicy_wait(t *testing.T) {
+	policy := retryPolicy{maxRetries: 10, waitMin: time.Second, waitMax: 10 * time.Second}
+
+	tests := []struct {
+		retry    int
+		min, max time.Duration
+	}{
+		{retry: 0, min: 500 * time.Millisecond, max: time.Second},
+		{retry: 2, min: 2 * time.Second, max: 4 * time.Second},
+		{retry: 5, min: 5 * time.Second, max: 10 * time.Second},
+		{retry: 100, min: 5 * time.Second, max: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := policy.wait(tt.retry, nil); got < tt.min || got > tt.max {
+			t.Errorf("wait(%d) = %s, expected between %s and %s", tt.retry, got, tt.min, tt.max)
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+	if got := policy.wait(0, resp); got != 3*time.Second {
+		t.Errorf("Expected Retry-After to be honored, got %s", got)
+	}
+
+	resp.Header.Set("Retry-After", "3600")
+	if got := policy.wait(0, resp); got != 10*time.Second {
+		t.Errorf("Expected Retry-After to be capped at the maximum wait, got %s", got)
+	}
+}
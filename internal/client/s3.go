@@ -0,0 +1,544 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultS3Region is the region Garage uses when s3_region is not set in its configuration.
+const DefaultS3Region = "garage"
+
+// S3Client is a minimal client for the Garage S3 API, authenticated with an access key.
+type S3Client struct {
+	sigV4Client
+}
+
+// sigV4Client makes requests signed with an access key to one of the Garage
+// APIs authenticated like S3.
+type sigV4Client struct {
+	endpoint        string
+	region          string
+	service         string
+	accessKeyID     string
+	secretAccessKey string
+	httpClient      *http.Client
+}
+
+// ErrS3EndpointNotConfigured is returned when an S3 client is requested but no S3 endpoint was configured.
+var ErrS3EndpointNotConfigured = errors.New("no S3 endpoint configured")
+
+// ErrS3CredentialsNotConfigured is returned when an S3 or K2V client is
+// requested without an access key and none was configured.
+var ErrS3CredentialsNotConfigured = errors.New("no access key given and no S3 access key configured")
+
+// NewS3Client creates a client for the S3 API configured on c, authenticated
+// with the given access key, or the one configured with WithS3Credentials
+// when accessKeyID is empty.
+func (c *Client) NewS3Client(accessKeyID, secretAccessKey string) (*S3Client, error) {
+	if c.s3Endpoint == "" {
+		return nil, ErrS3EndpointNotConfigured
+	}
+
+	accessKeyID, secretAccessKey, err := c.s3Credentials(accessKeyID, secretAccessKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &S3Client{sigV4Client{
+		endpoint:        c.s3Endpoint,
+		region:          c.s3Region,
+		service:         "s3",
+		accessKeyID:     accessKeyID,
+		secretAccessKey: secretAccessKey,
+		httpClient:      c.httpClient,
+	}}, nil
+}
+
+// s3Credentials returns the given access key, or the configured one when
+// accessKeyID is empty.
+func (c *Client) s3Credentials(accessKeyID, secretAccessKey string) (string, string, error) {
+	if accessKeyID != "" {
+		return accessKeyID, secretAccessKey, nil
+	}
+	if c.s3AccessKeyID == "" {
+		return "", "", ErrS3CredentialsNotConfigured
+	}
+	return c.s3AccessKeyID, c.s3SecretAccessKey, nil
+}
+
+// HasS3Endpoint reports whether an S3 endpoint was configured on the client.
+func (c *Client) HasS3Endpoint() bool {
+	return c.s3Endpoint != ""
+}
+
+// S3Endpoint returns the configured S3 endpoint URL.
+func (c *Client) S3Endpoint() string {
+	return c.s3Endpoint
+}
+
+// S3Region returns the configured S3 region.
+func (c *Client) S3Region() string {
+	return c.s3Region
+}
+
+// PutObjectRequest represents the request to upload an object.
+type PutObjectRequest struct {
+	Bucket          string
+	Key             string
+	Body            []byte
+	ContentType     string
+	CacheControl    string
+	ContentEncoding string
+	Metadata        map[string]string
+}
+
+// ObjectInfo represents the metadata returned for an object.
+type ObjectInfo struct {
+	ContentType     string
+	ContentLength   int64
+	ETag            string
+	CacheControl    string
+	ContentEncoding string
+	Metadata        map[string]string
+}
+
+// objectMetadataHeader prefixes the headers holding user-defined metadata.
+const objectMetadataHeader = "X-Amz-Meta-"
+
+// PutObject uploads an object.
+func (s *S3Client) PutObject(ctx context.Context, req PutObjectRequest) (*ObjectInfo, error) {
+	headers := http.Header{}
+	if req.ContentType != "" {
+		headers.Set("Content-Type", req.ContentType)
+	}
+	if req.CacheControl != "" {
+		headers.Set("Cache-Control", req.CacheControl)
+	}
+	if req.ContentEncoding != "" {
+		headers.Set("Content-Encoding", req.ContentEncoding)
+	}
+	for name, value := range req.Metadata {
+		headers.Set(objectMetadataHeader+name, value)
+	}
+
+	resp, err := s.doRequest(ctx, http.MethodPut, req.Bucket, req.Key, nil, headers, req.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newS3Error(resp)
+	}
+
+	return &ObjectInfo{
+		ContentType:     req.ContentType,
+		ContentLength:   int64(len(req.Body)),
+		ETag:            strings.Trim(resp.Header.Get("ETag"), `"`),
+		CacheControl:    req.CacheControl,
+		ContentEncoding: req.ContentEncoding,
+		Metadata:        req.Metadata,
+	}, nil
+}
+
+// HeadObject gets the metadata of an object. It returns nil if the object does not exist.
+func (s *S3Client) HeadObject(ctx context.Context, bucket, key string) (*ObjectInfo, error) {
+	resp, err := s.doRequest(ctx, http.MethodHead, bucket, key, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newS3Error(resp)
+	}
+
+	// Metadata names are case-insensitive and returned in lowercase by S3
+	metadata := map[string]string{}
+	for name, values := range resp.Header {
+		if strings.HasPrefix(name, objectMetadataHeader) && len(values) > 0 {
+			metadata[strings.ToLower(strings.TrimPrefix(name, objectMetadataHeader))] = values[0]
+		}
+	}
+
+	return &ObjectInfo{
+		ContentType:     resp.Header.Get("Content-Type"),
+		ContentLength:   resp.ContentLength,
+		ETag:            strings.Trim(resp.Header.Get("ETag"), `"`),
+		CacheControl:    resp.Header.Get("Cache-Control"),
+		ContentEncoding: resp.Header.Get("Content-Encoding"),
+		Metadata:        metadata,
+	}, nil
+}
+
+// ListObjectsResult represents one page of objects returned by ListObjectsV2.
+type ListObjectsResult struct {
+	Objects               []ListedObject `xml:"Contents"`
+	IsTruncated           bool           `xml:"IsTruncated"`
+	NextContinuationToken string         `xml:"NextContinuationToken"`
+}
+
+// ListedObject represents an object in a ListObjectsV2 result.
+type ListedObject struct {
+	Key  string `xml:"Key"`
+	Size int64  `xml:"Size"`
+}
+
+// ListObjects lists one page of the objects whose key starts with prefix. Pass
+// the NextContinuationToken of the previous page to get the next one.
+func (s *S3Client) ListObjects(ctx context.Context, bucket, prefix, continuationToken string) (*ListObjectsResult, error) {
+	query := url.Values{"list-type": {"2"}}
+	if prefix != "" {
+		query.Set("prefix", prefix)
+	}
+	if continuationToken != "" {
+		query.Set("continuation-token", continuationToken)
+	}
+
+	resp, err := s.doRequest(ctx, http.MethodGet, bucket, "", query, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newS3Error(resp)
+	}
+
+	var result ListObjectsResult
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// EachObject calls fn for every object whose key starts with prefix, fetching
+// pages as needed. Iteration stops at the first error returned by fn.
+func (s *S3Client) EachObject(ctx context.Context, bucket, prefix string, fn func(ListedObject) error) error {
+	token := ""
+	for {
+		page, err := s.ListObjects(ctx, bucket, prefix, token)
+		if err != nil {
+			return err
+		}
+
+		for _, object := range page.Objects {
+			if err := fn(object); err != nil {
+				return err
+			}
+		}
+
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+// MaxDeleteObjects is the maximum number of keys DeleteObjects accepts at once.
+const MaxDeleteObjects = 1000
+
+// DeleteObjects deletes up to MaxDeleteObjects objects in one request. Keys
+// that do not exist are not an error.
+func (s *S3Client) DeleteObjects(ctx context.Context, bucket string, keys []string) error {
+	type object struct {
+		Key string `xml:"Key"`
+	}
+	request := struct {
+		XMLName xml.Name `xml:"Delete"`
+		Quiet   bool     `xml:"Quiet"`
+		Objects []object `xml:"Object"`
+	}{Quiet: true}
+	for _, key := range keys {
+		request.Objects = append(request.Objects, object{Key: key})
+	}
+
+	body, err := xml.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	checksum := md5.Sum(body)
+	headers := http.Header{}
+	headers.Set("Content-Type", "application/xml")
+	headers.Set("Content-MD5", base64.StdEncoding.EncodeToString(checksum[:]))
+
+	resp, err := s.doRequest(ctx, http.MethodPost, bucket, "", url.Values{"delete": {""}}, headers, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newS3Error(resp)
+	}
+
+	// In quiet mode, only the keys that could not be deleted are returned
+	var result struct {
+		Errors []struct {
+			Key     string `xml:"Key"`
+			Code    string `xml:"Code"`
+			Message string `xml:"Message"`
+		} `xml:"Error"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(result.Errors) > 0 {
+		first := result.Errors[0]
+		return fmt.Errorf("unable to delete %d objects, first failure on %s: %s: %s", len(result.Errors), first.Key, first.Code, first.Message)
+	}
+
+	return nil
+}
+
+// CORSRule represents a rule of the CORS configuration of a bucket.
+type CORSRule struct {
+	ID             string   `xml:"ID,omitempty"`
+	AllowedOrigins []string `xml:"AllowedOrigin"`
+	AllowedMethods []string `xml:"AllowedMethod"`
+	AllowedHeaders []string `xml:"AllowedHeader,omitempty"`
+	ExposeHeaders  []string `xml:"ExposeHeader,omitempty"`
+	MaxAgeSeconds  *int64   `xml:"MaxAgeSeconds,omitempty"`
+}
+
+// corsConfiguration is the XML document of the CORS configuration of a bucket.
+type corsConfiguration struct {
+	XMLName xml.Name   `xml:"CORSConfiguration"`
+	Rules   []CORSRule `xml:"CORSRule"`
+}
+
+// GetBucketCors gets the CORS rules of a bucket. It returns nil if the bucket
+// has no CORS configuration.
+func (s *S3Client) GetBucketCors(ctx context.Context, bucket string) ([]CORSRule, error) {
+	resp, err := s.doRequest(ctx, http.MethodGet, bucket, "", url.Values{"cors": {""}}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newS3Error(resp)
+	}
+
+	var result corsConfiguration
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.Rules, nil
+}
+
+// PutBucketCors replaces the CORS rules of a bucket.
+func (s *S3Client) PutBucketCors(ctx context.Context, bucket string, rules []CORSRule) error {
+	body, err := xml.Marshal(corsConfiguration{Rules: rules})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	checksum := md5.Sum(body)
+	headers := http.Header{}
+	headers.Set("Content-Type", "application/xml")
+	headers.Set("Content-MD5", base64.StdEncoding.EncodeToString(checksum[:]))
+
+	resp, err := s.doRequest(ctx, http.MethodPut, bucket, "", url.Values{"cors": {""}}, headers, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newS3Error(resp)
+	}
+
+	return nil
+}
+
+// DeleteBucketCors removes the CORS configuration of a bucket.
+func (s *S3Client) DeleteBucketCors(ctx context.Context, bucket string) error {
+	resp, err := s.doRequest(ctx, http.MethodDelete, bucket, "", url.Values{"cors": {""}}, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return newS3Error(resp)
+	}
+
+	return nil
+}
+
+// doRequest makes a signed path-style request to the API.
+func (s *sigV4Client) doRequest(ctx context.Context, method, bucket, key string, query url.Values, headers http.Header, body []byte) (*http.Response, error) {
+	u, err := url.Parse(s.endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 endpoint: %w", err)
+	}
+
+	u.RawPath = strings.TrimSuffix(u.EscapedPath(), "/") + objectPath(bucket, key)
+	u.Path, err = url.PathUnescape(u.RawPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid object path: %w", err)
+	}
+	u.RawQuery = canonicalQueryString(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for name, values := range headers {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+
+	payloadHash := sha256.Sum256(body)
+	signV4(req, hex.EncodeToString(payloadHash[:]), s.accessKeyID, s.secretAccessKey, s.region, s.service, time.Now())
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+
+	return resp, nil
+}
+
+// signV4 signs req with AWS Signature Version 4. All headers present on the
+// request, plus host, x-amz-date and x-amz-content-sha256, are signed.
+func signV4(req *http.Request, payloadHash, accessKeyID, secretAccessKey, region, service string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	signingKey := hmacSHA256([]byte("AWS4"+secretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+// objectPath returns the escaped path-style path of an object. Every segment is
+// strictly percent-encoded so the path on the wire matches the canonical URI.
+func objectPath(bucket, key string) string {
+	path := "/" + uriEncode(bucket)
+	if key == "" {
+		return path
+	}
+
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = uriEncode(segment)
+	}
+
+	return path + "/" + strings.Join(segments, "/")
+}
+
+// canonicalQueryString encodes query parameters sorted by key, as SigV4 requires.
+func canonicalQueryString(query url.Values) string {
+	if len(query) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			parts = append(parts, uriEncode(key)+"="+uriEncode(value))
+		}
+	}
+
+	return strings.Join(parts, "&")
+}
+
+// uriEncode percent-encodes everything except the unreserved characters of RFC 3986.
+func uriEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"cmp"
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CallStats counts Admin API calls and the time spent in them per endpoint.
+// The zero value is ready to use, and a CallStats may be shared by several
+// clients.
+type CallStats struct {
+	mu        sync.Mutex
+	endpoints map[string]*EndpointStats
+}
+
+// EndpointStats is the number of calls made to an endpoint and their total
+// duration.
+type EndpointStats struct {
+	Endpoint string
+	Calls    int
+	Duration time.Duration
+}
+
+// WithCallStats records every Admin API call made by the client in stats.
+func WithCallStats(stats *CallStats) Option {
+	return func(c *Client) {
+		c.callStats = stats
+	}
+}
+
+// callStatsKey is the context key of the CallStats of an operation.
+type callStatsKey struct{}
+
+// ContextWithCallStats returns a copy of ctx in which the Admin API calls made
+// by any client are also recorded in stats, to summarize a single operation.
+func ContextWithCallStats(ctx context.Context, stats *CallStats) context.Context {
+	return context.WithValue(ctx, callStatsKey{}, stats)
+}
+
+// recordCall records a call of the given duration in the statistics of the
+// client and in those of the operation ctx belongs to, if any.
+func (c *Client) recordCall(ctx context.Context, path string, duration time.Duration) {
+	endpoint := endpointName(path)
+
+	if c.callStats != nil {
+		c.callStats.Record(endpoint, duration)
+	}
+	if stats, ok := ctx.Value(callStatsKey{}).(*CallStats); ok {
+		stats.Record(endpoint, duration)
+	}
+}
+
+// Record adds a call of the given duration to the endpoint.
+func (s *CallStats) Record(endpoint string, duration time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.endpoints == nil {
+		s.endpoints = map[string]*EndpointStats{}
+	}
+
+	stats, ok := s.endpoints[endpoint]
+	if !ok {
+		stats = &EndpointStats{Endpoint: endpoint}
+		s.endpoints[endpoint] = stats
+	}
+
+	stats.Calls++
+	stats.Duration += duration
+}
+
+// add adds the calls recorded in other to s.
+func (s *CallStats) add(other *CallStats) {
+	summary := other.Summary()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.endpoints == nil {
+		s.endpoints = map[string]*EndpointStats{}
+	}
+
+	for _, endpoint := range summary {
+		stats, ok := s.endpoints[endpoint.Endpoint]
+		if !ok {
+			stats = &EndpointStats{Endpoint: endpoint.Endpoint}
+			s.endpoints[endpoint.Endpoint] = stats
+		}
+
+		stats.Calls += endpoint.Calls
+		stats.Duration += endpoint.Duration
+	}
+}
+
+// Summary returns the statistics of every endpoint called, the endpoints
+// that took the longest first.
+func (s *CallStats) Summary() []EndpointStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	summary := make([]EndpointStats, 0, len(s.endpoints))
+	for _, stats := range s.endpoints {
+		summary = append(summary, *stats)
+	}
+
+	slices.SortFunc(summary, func(a, b EndpointStats) int {
+		return cmp.Or(cmp.Compare(b.Duration, a.Duration), strings.Compare(a.Endpoint, b.Endpoint))
+	})
+
+	return summary
+}
+
+// Total returns the number of calls made to every endpoint and their total
+// duration.
+func (s *CallStats) Total() (int, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var calls int
+	var duration time.Duration
+	for _, stats := range s.endpoints {
+		calls += stats.Calls
+		duration += stats.Duration
+	}
+
+	return calls, duration
+}
+
+// endpointName returns the Admin API endpoint a request path calls, such as
+// GetBucketInfo for /v2/GetBucketInfo?id=...
+func endpointName(path string) string {
+	if u, err := url.Parse(path); err == nil {
+		path = u.Path
+	}
+	return strings.TrimPrefix(path, "/v2/")
+}
@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCallStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "bucket-1", "globalAliases": []}`))
+	}))
+	defer server.Close()
+
+	stats := &CallStats{}
+	client := NewClient(server.URL, "test-token", WithCallStats(stats))
+
+	id := "bucket-1"
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetBucketInfo(context.Background(), GetBucketInfoRequest{ID: &id}); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	summary := stats.Summary()
+	if len(summary) != 1 || summary[0].Endpoint != "GetBucketInfo" || summary[0].Calls != 2 {
+		t.Fatalf("Expected 2 calls to GetBucketInfo, got %+v", summary)
+	}
+}
+
+func TestContextWithCallStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	stats := &CallStats{}
+	client := NewClient(server.URL, "test-token", WithCallStats(stats))
+
+	first, second := &CallStats{}, &CallStats{}
+	for _, operation := range []*CallStats{first, second, second} {
+		if _, err := client.ListAdminTokens(ContextWithCallStats(context.Background(), operation)); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	if calls, _ := first.Total(); calls != 1 {
+		t.Errorf("Expected 1 call in the first operation, got %d", calls)
+	}
+	if calls, _ := second.Total(); calls != 2 {
+		t.Errorf("Expected 2 calls in the second operation, got %d", calls)
+	}
+	if calls, _ := stats.Total(); calls != 3 {
+		t.Errorf("Expected 3 calls made by the client, got %d", calls)
+	}
+}
+
+func TestContextWithCallStats_sharedCall(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id": "bucket-1", "globalAliases": []}`))
+	}))
+	defer server.Close()
+
+	stats := &CallStats{}
+	client := NewClient(server.URL, "test-token", WithCallStats(stats))
+
+	// Both operations wait on the same request
+	id := "bucket-1"
+	operations := []*CallStats{{}, {}}
+	var wg sync.WaitGroup
+	for _, operation := range operations {
+		wg.Add(1)
+		go func(operation *CallStats) {
+			defer wg.Done()
+			if _, err := client.GetBucketInfo(ContextWithCallStats(context.Background(), operation), GetBucketInfoRequest{ID: &id}); err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		}(operation)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i, operation := range operations {
+		if calls, _ := operation.Total(); calls != 1 {
+			t.Errorf("Expected 1 call in operation %d, got %d", i, calls)
+		}
+	}
+	if calls, _ := stats.Total(); calls != 1 {
+		t.Errorf("Expected 1 call made by the client, got %d", calls)
+	}
+}
+
+func TestCallStatsSummary(t *testing.T) {
+	stats := &CallStats{}
+	stats.Record("ListBuckets", 100*time.Millisecond)
+	stats.Record("GetBucketInfo", 30*time.Millisecond)
+	stats.Record("GetBucketInfo", 90*time.Millisecond)
+	stats.Record("GetKeyInfo", 100*time.Millisecond)
+
+	want := []EndpointStats{
+		{Endpoint: "GetBucketInfo", Calls: 2, Duration: 120 * time.Millisecond},
+		{Endpoint: "GetKeyInfo", Calls: 1, Duration: 100 * time.Millisecond},
+		{Endpoint: "ListBuckets", Calls: 1, Duration: 100 * time.Millisecond},
+	}
+
+	summary := stats.Summary()
+	if len(summary) != len(want) {
+		t.Fatalf("Expected %d endpoints, got %+v", len(want), summary)
+	}
+	for i := range want {
+		if summary[i] != want[i] {
+			t.Errorf("Expected %+v at position %d, got %+v", want[i], i, summary[i])
+		}
+	}
+}
+
+func TestEndpointName(t *testing.T) {
+	tests := map[string]string{
+		"/v2/GetBucketInfo?id=abc": "GetBucketInfo",
+		"/v2/ListBuckets":          "ListBuckets",
+		"/check?domain=example":    "/check",
+	}
+
+	for path, want := range tests {
+		if got := endpointName(path); got != want {
+			t.Errorf("endpointName(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// AllNodes selects every node of the cluster in node-scoped requests.
+const AllNodes = "*"
+
+// LocalNode selects the node that serves the request in node-scoped requests.
+const LocalNode = "self"
+
+// GetWorkerVariableRequest represents the request to read worker variables.
+// When Variable is nil, all variables are returned.
+type GetWorkerVariableRequest struct {
+	Variable *string `json:"variable"`
+}
+
+// WorkerVariablesResponse represents the worker variables of each node that
+// answered, and the error returned by each node that did not.
+type WorkerVariablesResponse struct {
+	Success map[string]map[string]string `json:"success"`
+	Error   map[string]string            `json:"error"`
+}
+
+// GetWorkerVariable reads worker variables on the given node, which may be a
+// node ID, LocalNode or AllNodes.
+func (c *Client) GetWorkerVariable(ctx context.Context, node string, req GetWorkerVariableRequest) (*WorkerVariablesResponse, error) {
+	if err := validateNode(node); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/GetWorkerVariable", url.Values{"node": {node}}, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result WorkerVariablesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// SetWorkerVariableRequest represents the request to change a worker variable.
+type SetWorkerVariableRequest struct {
+	Variable string `json:"variable"`
+	Value    string `json:"value"`
+}
+
+// WorkerVariable represents the new value of a worker variable on a node.
+type WorkerVariable struct {
+	Variable string `json:"variable"`
+	Value    string `json:"value"`
+}
+
+// SetWorkerVariableResponse represents the variable set on each node that
+// answered, and the error returned by each node that did not.
+type SetWorkerVariableResponse struct {
+	Success map[string]WorkerVariable `json:"success"`
+	Error   map[string]string         `json:"error"`
+}
+
+// SetWorkerVariable changes a worker variable on the given node, which may be
+// a node ID, LocalNode or AllNodes.
+func (c *Client) SetWorkerVariable(ctx context.Context, node string, req SetWorkerVariableRequest) (*SetWorkerVariableResponse, error) {
+	if err := validateNode(node); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/SetWorkerVariable", url.Values{"node": {node}}, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result SetWorkerVariableResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetWorkerInfoRequest represents the request to read a background worker.
+type GetWorkerInfoRequest struct {
+	ID int64 `json:"id"`
+}
+
+// WorkerInfo represents the status of a background worker.
+type WorkerInfo struct {
+	ID                int64            `json:"id"`
+	Name              string           `json:"name"`
+	State             WorkerState      `json:"state"`
+	Errors            int64            `json:"errors"`
+	ConsecutiveErrors int64            `json:"consecutiveErrors"`
+	LastError         *WorkerLastError `json:"lastError,omitempty"`
+	Tranquility       *int64           `json:"tranquility,omitempty"`
+	Progress          *string          `json:"progress,omitempty"`
+	QueueLength       *int64           `json:"queueLength,omitempty"`
+	PersistentErrors  *int64           `json:"persistentErrors,omitempty"`
+	Freeform          []string         `json:"freeform"`
+}
+
+// WorkerLastError represents the last error of a background worker.
+type WorkerLastError struct {
+	Message string `json:"message"`
+	SecsAgo int64  `json:"secsAgo"`
+}
+
+// WorkerState is the state of a background worker: "busy", "throttled",
+// "idle" or "done". ThrottledSecs is set for throttled workers.
+type WorkerState struct {
+	Name          string
+	ThrottledSecs *float64
+}
+
+// UnmarshalJSON decodes the worker state returned by the Garage API, which is
+// either a plain string or {"throttled": {"durationSecs": ...}}.
+func (s *WorkerState) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*s = WorkerState{Name: name}
+		return nil
+	}
+
+	var throttled struct {
+		Throttled *struct {
+			DurationSecs float64 `json:"durationSecs"`
+		} `json:"throttled"`
+	}
+	if err := json.Unmarshal(data, &throttled); err != nil {
+		return err
+	}
+	if throttled.Throttled == nil {
+		return fmt.Errorf("unknown worker state %s", data)
+	}
+	*s = WorkerState{Name: "throttled", ThrottledSecs: &throttled.Throttled.DurationSecs}
+	return nil
+}
+
+// WorkerInfoResponse represents the worker status reported by each node that
+// answered, and the error returned by each node that did not.
+type WorkerInfoResponse struct {
+	Success map[string]WorkerInfo `json:"success"`
+	Error   map[string]string     `json:"error"`
+}
+
+// GetWorkerInfo reads the status of a background worker on the given node,
+// which may be a node ID or LocalNode.
+func (c *Client) GetWorkerInfo(ctx context.Context, node string, req GetWorkerInfoRequest) (*WorkerInfoResponse, error) {
+	if err := validateNode(node); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/GetWorkerInfo", url.Values{"node": {node}}, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result WorkerInfoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// ListWorkersRequest represents the request to list background workers.
+type ListWorkersRequest struct {
+	BusyOnly  bool `json:"busyOnly"`
+	ErrorOnly bool `json:"errorOnly"`
+}
+
+// ListWorkersResponse represents the workers reported by each node that
+// answered, and the error returned by each node that did not.
+type ListWorkersResponse struct {
+	Success map[string][]WorkerInfo `json:"success"`
+	Error   map[string]string       `json:"error"`
+}
+
+// ListWorkers lists the background workers on the given node, which may be a
+// node ID, LocalNode or AllNodes.
+func (c *Client) ListWorkers(ctx context.Context, node string, req ListWorkersRequest) (*ListWorkersResponse, error) {
+	if err := validateNode(node); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodPost, "/v2/ListWorkers", url.Values{"node": {node}}, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	var result ListWorkersResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,203 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetWorkerVariable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+		if r.URL.Path != "/v2/GetWorkerVariable" {
+			t.Errorf("Expected path /v2/GetWorkerVariable, got %s", r.URL.Path)
+		}
+		if node := r.URL.Query().Get("node"); node != "*" {
+			t.Errorf("Expected node *, got %s", node)
+		}
+
+		var req GetWorkerVariableRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+		if req.Variable == nil || *req.Variable != "resync-tranquility" {
+			t.Errorf("Expected variable resync-tranquility, got %v", req.Variable)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"success": {"node-1": {"resync-tranquility": "2"}},
+			"error": {"node-2": "node is down"}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	variable := "resync-tranquility"
+	result, err := client.GetWorkerVariable(context.Background(), AllNodes, GetWorkerVariableRequest{Variable: &variable})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if got := result.Success["node-1"]["resync-tranquility"]; got != "2" {
+		t.Errorf("Expected resync-tranquility 2 on node-1, got %q", got)
+	}
+
+	if got := result.Error["node-2"]; got != "node is down" {
+		t.Errorf("Expected error for node-2, got %q", got)
+	}
+}
+
+func TestSetWorkerVariable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/SetWorkerVariable" {
+			t.Errorf("Expected path /v2/SetWorkerVariable, got %s", r.URL.Path)
+		}
+		if node := r.URL.Query().Get("node"); node != "node-1" {
+			t.Errorf("Expected node node-1, got %s", node)
+		}
+
+		var req SetWorkerVariableRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+		if req.Variable != "scrub-tranquility" || req.Value != "8" {
+			t.Errorf("Expected scrub-tranquility=8, got %s=%s", req.Variable, req.Value)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"success": {"node-1": {"variable": "scrub-tranquility", "value": "8"}},
+			"error": {}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	result, err := client.SetWorkerVariable(context.Background(), "node-1", SetWorkerVariableRequest{Variable: "scrub-tranquility", Value: "8"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if got := result.Success["node-1"].Value; got != "8" {
+		t.Errorf("Expected value 8 on node-1, got %q", got)
+	}
+}
+
+func TestGetWorkerInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/GetWorkerInfo" {
+			t.Errorf("Expected path /v2/GetWorkerInfo, got %s", r.URL.Path)
+		}
+		if node := r.URL.Query().Get("node"); node != "self" {
+			t.Errorf("Expected node self, got %s", node)
+		}
+
+		var req GetWorkerInfoRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+		if req.ID != 7 {
+			t.Errorf("Expected worker 7, got %d", req.ID)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"success": {"node-1": {
+				"id": 7,
+				"name": "Block resync worker #1",
+				"state": {"throttled": {"durationSecs": 0.5}},
+				"errors": 3,
+				"consecutiveErrors": 1,
+				"lastError": {"message": "connection reset", "secsAgo": 42},
+				"tranquility": 2,
+				"queueLength": 120,
+				"persistentErrors": 0,
+				"freeform": []
+			}},
+			"error": {}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	result, err := client.GetWorkerInfo(context.Background(), LocalNode, GetWorkerInfoRequest{ID: 7})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	info := result.Success["node-1"]
+	if info.State.Name != "throttled" || info.State.ThrottledSecs == nil || *info.State.ThrottledSecs != 0.5 {
+		t.Errorf("Expected throttled state for 0.5s, got %+v", info.State)
+	}
+	if info.LastError == nil || info.LastError.Message != "connection reset" {
+		t.Errorf("Expected last error, got %+v", info.LastError)
+	}
+	if info.QueueLength == nil || *info.QueueLength != 120 {
+		t.Errorf("Expected queue length 120, got %v", info.QueueLength)
+	}
+}
+
+func TestListWorkers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/ListWorkers" {
+			t.Errorf("Expected path /v2/ListWorkers, got %s", r.URL.Path)
+		}
+		if node := r.URL.Query().Get("node"); node != "*" {
+			t.Errorf("Expected node *, got %s", node)
+		}
+
+		var req ListWorkersRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+		if req.BusyOnly || req.ErrorOnly {
+			t.Errorf("Expected all workers to be requested, got %+v", req)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"success": {"node-1": [
+				{"id": 1, "name": "Block resync worker #1", "state": "idle", "errors": 0, "consecutiveErrors": 0, "freeform": []},
+				{"id": 12, "name": "Version repair worker", "state": "busy", "errors": 0, "consecutiveErrors": 0, "progress": "40%", "freeform": []}
+			]},
+			"error": {"node-2": "unreachable"}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-token")
+	result, err := client.ListWorkers(context.Background(), AllNodes, ListWorkersRequest{})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	workers := result.Success["node-1"]
+	if len(workers) != 2 || workers[1].ID != 12 || workers[1].State.Name != "busy" {
+		t.Errorf("Expected 2 workers with a busy repair worker, got %+v", workers)
+	}
+	if result.Error["node-2"] != "unreachable" {
+		t.Errorf("Expected an error for node-2, got %v", result.Error)
+	}
+}
+
+func TestWorkerStateUnmarshal(t *testing.T) {
+	var state WorkerState
+	if err := json.Unmarshal([]byte(`"idle"`), &state); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if state.Name != "idle" || state.ThrottledSecs != nil {
+		t.Errorf("Expected idle state, got %+v", state)
+	}
+
+	if err := json.Unmarshal([]byte(`{"paused": {}}`), &state); err == nil {
+		t.Error("Expected error for unknown state")
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AdminAPIInfoDataSource{}
+
+func NewAdminAPIInfoDataSource() datasource.DataSource {
+	return &AdminAPIInfoDataSource{}
+}
+
+// AdminAPIInfoDataSource defines the data source implementation.
+type AdminAPIInfoDataSource struct {
+	client *client.Client
+}
+
+// AdminAPIInfoDataSourceModel describes the data source data model.
+type AdminAPIInfoDataSourceModel struct {
+	NodeID         types.String `tfsdk:"node_id"`
+	GarageVersion  types.String `tfsdk:"garage_version"`
+	VersionMajor   types.Int64  `tfsdk:"version_major"`
+	VersionMinor   types.Int64  `tfsdk:"version_minor"`
+	VersionPatch   types.Int64  `tfsdk:"version_patch"`
+	GarageFeatures types.List   `tfsdk:"garage_features"`
+	RustVersion    types.String `tfsdk:"rust_version"`
+	DBEngine       types.String `tfsdk:"db_engine"`
+}
+
+func (d *AdminAPIInfoDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_admin_api_info"
+}
+
+func (d *AdminAPIInfoDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the version and build features of the Garage daemon serving the Admin API, " +
+			"so that modules can enable attributes depending on what the cluster supports.",
+
+		Attributes: map[string]schema.Attribute{
+			"node_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the node serving the Admin API.",
+			},
+			"garage_version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The Garage version as reported by the daemon (e.g., `v2.1.0`).",
+			},
+			"version_major": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The major version number, or null if the version could not be parsed.",
+			},
+			"version_minor": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The minor version number, or null if the version could not be parsed.",
+			},
+			"version_patch": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The patch version number, or null if the version could not be parsed.",
+			},
+			"garage_features": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The features Garage was built with (e.g., `k2v`, `metrics`).",
+			},
+			"rust_version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The Rust compiler version Garage was built with.",
+			},
+			"db_engine": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The metadata database engine in use.",
+			},
+		},
+	}
+}
+
+func (d *AdminAPIInfoDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *AdminAPIInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AdminAPIInfoDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	result, err := d.client.GetNodeInfo(ctx, client.LocalNode)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read node info", err)
+		return
+	}
+
+	for nodeID, message := range result.Error {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read node info of %s, got error: %s", nodeID, message))
+		return
+	}
+
+	if len(result.Success) != 1 {
+		resp.Diagnostics.AddError(
+			"Unexpected Node Info",
+			fmt.Sprintf("Expected information about exactly one node, got %d.", len(result.Success)),
+		)
+		return
+	}
+
+	for _, info := range result.Success {
+		data.NodeID = types.StringValue(info.NodeID)
+		data.GarageVersion = types.StringValue(info.GarageVersion)
+		data.RustVersion = types.StringValue(info.RustVersion)
+		data.DBEngine = types.StringValue(info.DBEngine)
+
+		if version, ok := parseGarageVersion(info.GarageVersion); ok {
+			data.VersionMajor = types.Int64Value(version[0])
+			data.VersionMinor = types.Int64Value(version[1])
+			data.VersionPatch = types.Int64Value(version[2])
+		} else {
+			data.VersionMajor = types.Int64Null()
+			data.VersionMinor = types.Int64Null()
+			data.VersionPatch = types.Int64Null()
+		}
+
+		features := info.GarageFeatures
+		if features == nil {
+			features = []string{}
+		}
+		featureList, diags := types.ListValueFrom(ctx, types.StringType, features)
+		resp.Diagnostics.Append(diags...)
+		data.GarageFeatures = featureList
+	}
+
+	tflog.Trace(ctx, "Read admin API info data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// parseGarageVersion extracts the major, minor and patch numbers from a Garage
+// version string such as "v2.1.0" or "v2.0.0-rc1". Development builds report
+// versions that cannot be parsed, in which case ok is false.
+func parseGarageVersion(version string) (parts [3]int64, ok bool) {
+	version = strings.TrimPrefix(version, "v")
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+
+	fields := strings.Split(version, ".")
+	if len(fields) != 3 {
+		return parts, false
+	}
+
+	for i, field := range fields {
+		n, err := strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return parts, false
+		}
+		parts[i] = n
+	}
+
+	return parts, true
+}
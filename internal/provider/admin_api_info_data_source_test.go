@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAdminAPIInfoDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAdminAPIInfoDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.garage_admin_api_info.test", "node_id"),
+					resource.TestCheckResourceAttrSet("data.garage_admin_api_info.test", "garage_version"),
+					resource.TestCheckResourceAttrSet("data.garage_admin_api_info.test", "garage_features.#"),
+					resource.TestCheckResourceAttrSet("data.garage_admin_api_info.test", "db_engine"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseGarageVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		want    [3]int64
+		ok      bool
+	}{
+		{version: "v2.1.0", want: [3]int64{2, 1, 0}, ok: true},
+		{version: "v2.0.0-rc1", want: [3]int64{2, 0, 0}, ok: true},
+		{version: "1.0.1", want: [3]int64{1, 0, 1}, ok: true},
+		{version: "git:v2.1.0-12-gabcdef", ok: false},
+		{version: "v2.1", ok: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseGarageVersion(tt.version)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("parseGarageVersion(%q) = %v, %v; want %v, %v", tt.version, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+const testAccAdminAPIInfoDataSourceConfig = `
+data "garage_admin_api_info" "test" {}
+`
@@ -0,0 +1,204 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AdminTokenDataSource{}
+
+func NewAdminTokenDataSource() datasource.DataSource {
+	return &AdminTokenDataSource{}
+}
+
+// AdminTokenDataSource defines the data source implementation.
+type AdminTokenDataSource struct {
+	client *client.Client
+}
+
+// AdminTokenDataSourceModel describes the data source data model.
+type AdminTokenDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Scope        types.List   `tfsdk:"scope"`
+	Expiration   types.String `tfsdk:"expiration"`
+	NeverExpires types.Bool   `tfsdk:"never_expires"`
+	Expired      types.Bool   `tfsdk:"expired"`
+	Created      types.String `tfsdk:"created"`
+}
+
+func (d *AdminTokenDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_admin_token"
+}
+
+func (d *AdminTokenDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the scope and expiration of a single admin API token, for instance to audit the tokens given to other systems. " +
+			"The secret of the token is never returned.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The ID of the admin token. Either id or name must be specified.",
+			},
+			"name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The name of the admin token. It must match exactly one token. Either id or name must be specified.",
+			},
+			"scope": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The Admin API endpoints the token may call, or `[\"*\"]` for all endpoints.",
+			},
+			"expiration": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the token expires, or null if it never expires.",
+			},
+			"never_expires": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the token never expires.",
+			},
+			"expired": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the token has expired.",
+			},
+			"created": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the token was created.",
+			},
+		},
+	}
+}
+
+func (d *AdminTokenDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *AdminTokenDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AdminTokenDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Validate that either ID or Name is provided
+	if data.ID.IsNull() && data.Name.IsNull() {
+		resp.Diagnostics.AddError(
+			"Missing Required Attribute",
+			"Either 'id' or 'name' must be specified.",
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Reading admin token data source", map[string]interface{}{
+		"id":   data.ID.ValueString(),
+		"name": data.Name.ValueString(),
+	})
+
+	var token *client.AdminTokenInfo
+	if !data.ID.IsNull() {
+		var err error
+		token, err = d.client.GetAdminTokenInfo(ctx, data.ID.ValueString())
+		if err != nil {
+			addClientError(&resp.Diagnostics, "read admin token", err)
+			return
+		}
+	} else {
+		tokens, err := d.client.ListAdminTokens(ctx)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "list admin tokens", err)
+			return
+		}
+
+		matches := adminTokensNamed(tokens, data.Name.ValueString())
+		if len(matches) > 1 {
+			resp.Diagnostics.AddError(
+				"Ambiguous Admin Token Name",
+				fmt.Sprintf("%d admin tokens are named %q. Look the token up by id instead.", len(matches), data.Name.ValueString()),
+			)
+			return
+		}
+		if len(matches) == 1 {
+			token = &matches[0]
+		}
+	}
+
+	if token == nil {
+		resp.Diagnostics.AddError(
+			"Admin Token Not Found",
+			"The specified admin token could not be found.",
+		)
+		return
+	}
+
+	// When both are given, they must designate the same token
+	if !data.ID.IsNull() && !data.Name.IsNull() && data.Name.ValueString() != token.Name {
+		resp.Diagnostics.AddError(
+			"Admin Token Name Mismatch",
+			fmt.Sprintf("Admin token %s is named %q, not %q.", data.ID.ValueString(), token.Name, data.Name.ValueString()),
+		)
+		return
+	}
+
+	data.ID = types.StringPointerValue(token.ID)
+	data.Name = types.StringValue(token.Name)
+	data.Expiration = types.StringPointerValue(token.Expiration)
+	data.NeverExpires = types.BoolValue(token.Expiration == nil)
+	data.Expired = types.BoolValue(token.Expired)
+	data.Created = types.StringPointerValue(token.Created)
+
+	tokenScope := token.Scope
+	if tokenScope == nil {
+		tokenScope = []string{}
+	}
+	scope, diags := types.ListValueFrom(ctx, types.StringType, tokenScope)
+	resp.Diagnostics.Append(diags...)
+	data.Scope = scope
+
+	tflog.Trace(ctx, "Read admin token data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// adminTokensNamed returns the tokens with the given name. Tokens defined in
+// the Garage configuration file have no ID and cannot be managed, so they are
+// left out.
+func adminTokensNamed(tokens []client.AdminTokenInfo, name string) []client.AdminTokenInfo {
+	var matches []client.AdminTokenInfo
+	for _, token := range tokens {
+		if token.ID != nil && token.Name == name {
+			matches = append(matches, token)
+		}
+	}
+	return matches
+}
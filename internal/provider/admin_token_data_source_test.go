@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-garage/internal/client"
+)
+
+func TestAccAdminTokenDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAdminTokenDataSourceConfig("test-admin-token-lookup"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.garage_admin_token.by_name", "id", "garage_admin_token.test", "id"),
+					resource.TestCheckResourceAttr("data.garage_admin_token.by_name", "scope.#", "1"),
+					resource.TestCheckResourceAttr("data.garage_admin_token.by_name", "scope.0", "GetClusterHealth"),
+					resource.TestCheckResourceAttr("data.garage_admin_token.by_name", "expiration", "2099-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("data.garage_admin_token.by_name", "never_expires", "false"),
+					resource.TestCheckResourceAttr("data.garage_admin_token.by_name", "expired", "false"),
+					resource.TestCheckResourceAttrSet("data.garage_admin_token.by_name", "created"),
+					resource.TestCheckResourceAttr("data.garage_admin_token.by_id", "name", "test-admin-token-lookup"),
+					resource.TestCheckResourceAttrPair("data.garage_admin_token.by_id", "created", "garage_admin_token.test", "created"),
+				),
+			},
+		},
+	})
+}
+
+func TestAdminTokensNamed(t *testing.T) {
+	id1, id2 := "token-1", "token-2"
+	tokens := []client.AdminTokenInfo{
+		{ID: &id1, Name: "ci"},
+		{ID: &id2, Name: "backup"},
+		{Name: "ci"},
+	}
+
+	matches := adminTokensNamed(tokens, "ci")
+	if len(matches) != 1 || *matches[0].ID != id1 {
+		t.Errorf("Expected only token %s to match, got %+v", id1, matches)
+	}
+
+	if matches := adminTokensNamed(tokens, "missing"); len(matches) != 0 {
+		t.Errorf("Expected no match, got %+v", matches)
+	}
+}
+
+func testAccAdminTokenDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "garage_admin_token" "test" {
+  name       = %[1]q
+  scope      = ["GetClusterHealth"]
+  expiration = "2099-01-01T00:00:00Z"
+}
+
+data "garage_admin_token" "by_name" {
+  name = garage_admin_token.test.name
+}
+
+data "garage_admin_token" "by_id" {
+  id = garage_admin_token.test.id
+}
+`, name)
+}
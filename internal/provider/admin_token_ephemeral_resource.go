@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// defaultEphemeralAdminTokenTTL is how long an ephemeral admin token stays
+// valid when ttl is not set.
+const defaultEphemeralAdminTokenTTL = time.Hour
+
+// ephemeralAdminTokenPrivateKey is the private state key holding the ID of the token to revoke on close.
+const ephemeralAdminTokenPrivateKey = "admin_token_id"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &AdminTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &AdminTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &AdminTokenEphemeralResource{}
+
+func NewAdminTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &AdminTokenEphemeralResource{}
+}
+
+// AdminTokenEphemeralResource defines the ephemeral resource implementation.
+type AdminTokenEphemeralResource struct {
+	client *client.Client
+}
+
+// AdminTokenEphemeralResourceModel describes the ephemeral resource data model.
+type AdminTokenEphemeralResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Scope       types.List   `tfsdk:"scope"`
+	TTL         types.String `tfsdk:"ttl"`
+	SecretToken types.String `tfsdk:"secret_token"`
+	Expiration  types.String `tfsdk:"expiration"`
+}
+
+func (e *AdminTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_admin_token"
+}
+
+func (e *AdminTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a scoped admin API token for the duration of a Terraform run and revokes it afterwards, so that other providers or scripts can call the Admin API without a long-lived token in the state. " +
+			"The token also expires on its own after `ttl`, in case it cannot be revoked.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "A human-friendly name for the admin token. Defaults to `terraform-ephemeral`.",
+			},
+			"scope": schema.ListAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The Admin API endpoints the token may call (e.g., `GetClusterHealth`), or `[\"*\"]` for all endpoints.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"ttl": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "How long the admin token stays valid (e.g., `30m`). Defaults to `1h`.",
+				Validators: []validator.String{
+					positiveDuration(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the admin token.",
+			},
+			"secret_token": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The secret token to use as bearer token.",
+			},
+			"expiration": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the admin token expires, as an RFC3339 timestamp.",
+			},
+		},
+	}
+}
+
+func (e *AdminTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	e.client = client
+}
+
+func (e *AdminTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data AdminTokenEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ttl := defaultEphemeralAdminTokenTTL
+	if !data.TTL.IsNull() {
+		parsed, err := time.ParseDuration(data.TTL.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Duration", fmt.Sprintf("Unable to parse ttl: %s", err))
+			return
+		}
+		ttl = parsed
+	}
+
+	name := "terraform-ephemeral"
+	if !data.Name.IsNull() {
+		name = data.Name.ValueString()
+	}
+
+	var scope []string
+	resp.Diagnostics.Append(data.Scope.ElementsAs(ctx, &scope, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	expiration := time.Now().Add(ttl).UTC().Format(time.RFC3339)
+
+	tflog.Debug(ctx, "Creating ephemeral admin token", map[string]interface{}{
+		"name":       name,
+		"expiration": expiration,
+	})
+
+	token, err := e.client.CreateAdminToken(ctx, client.UpdateAdminTokenRequest{
+		Name:       &name,
+		Expiration: &expiration,
+		Scope:      &scope,
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "create ephemeral admin token", err)
+		return
+	}
+
+	if token.ID == nil {
+		resp.Diagnostics.AddError("Missing Admin Token ID", "Garage did not return the ID of the created admin token, so it cannot be revoked after the run.")
+		return
+	}
+
+	resp.Diagnostics.Append(setEphemeralPrivateID(ctx, resp.Private, ephemeralAdminTokenPrivateKey, *token.ID)...)
+
+	data.ID = types.StringValue(*token.ID)
+	data.Name = types.StringValue(name)
+	data.SecretToken = types.StringValue(token.SecretToken)
+	data.Expiration = types.StringValue(expiration)
+	if token.Expiration != nil {
+		data.Expiration = types.StringValue(*token.Expiration)
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (e *AdminTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	tokenID, diags := ephemeralPrivateID(ctx, req.Private, ephemeralAdminTokenPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || tokenID == "" {
+		return
+	}
+
+	tflog.Debug(ctx, "Revoking ephemeral admin token", map[string]interface{}{
+		"id": tokenID,
+	})
+
+	err := e.client.DeleteAdminToken(ctx, tokenID)
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		addClientError(&resp.Diagnostics, "revoke ephemeral admin token", err)
+	}
+}
@@ -0,0 +1,408 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AdminTokenResource{}
+var _ resource.ResourceWithImportState = &AdminTokenResource{}
+var _ resource.ResourceWithModifyPlan = &AdminTokenResource{}
+
+func NewAdminTokenResource() resource.Resource {
+	return &AdminTokenResource{}
+}
+
+// AdminTokenResource defines the resource implementation.
+type AdminTokenResource struct {
+	client *client.Client
+}
+
+// AdminTokenResourceModel describes the resource data model.
+type AdminTokenResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Scope        types.List   `tfsdk:"scope"`
+	Expiration   types.String `tfsdk:"expiration"`
+	NeverExpires types.Bool   `tfsdk:"never_expires"`
+	Expired      types.Bool   `tfsdk:"expired"`
+	Created      types.String `tfsdk:"created"`
+	SecretToken  types.String `tfsdk:"secret_token"`
+	RotateAfter  types.String `tfsdk:"rotate_after"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *AdminTokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_admin_token"
+}
+
+func (r *AdminTokenResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Garage admin API token, for instance to give monitoring or CI systems access to a subset of the Admin API.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the admin token.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "A human-friendly name for the admin token. Renaming the token keeps its secret.",
+			},
+			"scope": schema.ListAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The Admin API endpoints the token may call (e.g., `GetClusterHealth`), or `[\"*\"]` for all endpoints. Changing the scope keeps the secret.",
+			},
+			"expiration": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "When the token expires, as an RFC3339 timestamp. Conflicts with `never_expires = true`.",
+				Validators: []validator.String{
+					timestamp(),
+				},
+			},
+			"never_expires": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether the token never expires. Defaults to `true` when `expiration` is not set.",
+			},
+			"expired": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the token has expired.",
+			},
+			"created": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the token was created.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"secret_token": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The secret token to use as bearer token (only available on creation).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rotate_after": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Replace the token with a new one once it is older than this duration (e.g., `720h`). The age is computed from `created` at plan time.",
+				Validators: []validator.String{
+					positiveDuration(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *AdminTokenResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AdminTokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	validateTokenScope(ctx, r.client, resourceScopes{
+		Create: []string{"CreateAdminToken"},
+		Update: []string{"UpdateAdminToken"},
+		Delete: []string{"DeleteAdminToken"},
+	}, req, resp)
+
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		r.planRotation(ctx, req, resp)
+	}
+
+	planFutureExpiration(ctx, req, resp)
+
+	var expiration types.String
+	var neverExpires types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("expiration"), &expiration)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("never_expires"), &neverExpires)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case neverExpires.IsUnknown() || expiration.IsUnknown():
+		return
+	case neverExpires.IsNull():
+		// A token without expiration never expires
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("never_expires"), expiration.IsNull())...)
+	case neverExpires.ValueBool() && !expiration.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("never_expires"),
+			"Conflicting Expiration",
+			"never_expires cannot be true when expiration is set.",
+		)
+	case !neverExpires.ValueBool() && expiration.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expiration"),
+			"Missing Expiration",
+			"expiration must be set when never_expires is false.",
+		)
+	}
+}
+
+// planRotation replaces the token when it is older than rotate_after. The
+// planned creation time becomes unknown so that the replacement is triggered.
+func (r *AdminTokenResource) planRotation(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var rotateAfter, created types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("rotate_after"), &rotateAfter)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("created"), &created)...)
+	if resp.Diagnostics.HasError() || rotateAfter.IsNull() || rotateAfter.IsUnknown() || created.IsNull() {
+		return
+	}
+
+	interval, err := time.ParseDuration(rotateAfter.ValueString())
+	if err != nil {
+		return
+	}
+
+	createdAt, err := time.Parse(time.RFC3339, created.ValueString())
+	if err != nil {
+		tflog.Warn(ctx, "Unable to parse admin token creation time, skipping rotation", map[string]interface{}{
+			"created": created.ValueString(),
+		})
+		return
+	}
+
+	age := time.Since(createdAt)
+	if age < interval {
+		return
+	}
+
+	tflog.Info(ctx, "Admin token is due for rotation", map[string]interface{}{
+		"age":          age.Round(time.Second).String(),
+		"rotate_after": rotateAfter.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("created"), types.StringUnknown())...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("created"))
+}
+
+func (r *AdminTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AdminTokenResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	tflog.Debug(ctx, "Creating admin token", map[string]interface{}{
+		"name": data.Name.ValueString(),
+	})
+
+	createReq, diags := r.tokenRequest(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := r.client.CreateAdminToken(ctx, createReq)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "create admin token", err)
+		return
+	}
+
+	data.SecretToken = types.StringValue(token.SecretToken)
+	r.updateStateFromToken(ctx, &data, &token.AdminTokenInfo, &resp.Diagnostics)
+
+	tflog.Trace(ctx, "Created admin token resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AdminTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AdminTokenResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	token, err := r.client.GetAdminTokenInfo(ctx, data.ID.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read admin token", err)
+		return
+	}
+
+	if token == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	r.updateStateFromToken(ctx, &data, token, &resp.Diagnostics)
+	// Note: SecretToken is not returned by GetAdminTokenInfo (only on creation), so we keep the existing value
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AdminTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data AdminTokenResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	tflog.Debug(ctx, "Updating admin token", map[string]interface{}{
+		"id": data.ID.ValueString(),
+	})
+
+	updateReq, diags := r.tokenRequest(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := r.client.UpdateAdminToken(ctx, data.ID.ValueString(), updateReq)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "update admin token", err)
+		return
+	}
+
+	r.updateStateFromToken(ctx, &data, token, &resp.Diagnostics)
+
+	tflog.Trace(ctx, "Updated admin token resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AdminTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AdminTokenResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, defaultDeleteTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	tflog.Debug(ctx, "Deleting admin token", map[string]interface{}{
+		"id": data.ID.ValueString(),
+	})
+
+	// A token already deleted outside Terraform is gone as planned
+	if err := r.client.DeleteAdminToken(ctx, data.ID.ValueString()); err != nil && !errors.Is(err, client.ErrNotFound) {
+		addClientError(&resp.Diagnostics, "delete admin token", err)
+		return
+	}
+
+	tflog.Trace(ctx, "Deleted admin token resource")
+}
+
+func (r *AdminTokenResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// tokenRequest builds the create or update request for the planned token.
+// Updates send every attribute, so the name and scope change in place and
+// the secret in use stays valid. Without an expiration the token is
+// explicitly set to never expire, so that removing the expiration from the
+// configuration also removes it in Garage.
+func (r *AdminTokenResource) tokenRequest(ctx context.Context, data AdminTokenResourceModel) (client.UpdateAdminTokenRequest, diag.Diagnostics) {
+	// An empty scope must be sent for Garage to remove the previous one
+	scope := []string{}
+	diags := data.Scope.ElementsAs(ctx, &scope, false)
+
+	return client.UpdateAdminTokenRequest{
+		Name:         data.Name.ValueStringPointer(),
+		Expiration:   data.Expiration.ValueStringPointer(),
+		NeverExpires: data.Expiration.IsNull(),
+		Scope:        &scope,
+	}, diags
+}
+
+// updateStateFromToken updates the resource state from admin token info.
+func (r *AdminTokenResource) updateStateFromToken(ctx context.Context, data *AdminTokenResourceModel, token *client.AdminTokenInfo, diags *diag.Diagnostics) {
+	data.ID = types.StringPointerValue(token.ID)
+	data.Name = types.StringValue(token.Name)
+	data.Created = types.StringPointerValue(token.Created)
+	data.Expired = types.BoolValue(token.Expired)
+	data.NeverExpires = types.BoolValue(token.Expiration == nil)
+
+	// Keep the configured timestamp when Garage formats the same instant differently
+	if token.Expiration == nil || !sameInstant(data.Expiration.ValueString(), *token.Expiration) {
+		data.Expiration = types.StringPointerValue(token.Expiration)
+	}
+
+	tokenScope := token.Scope
+	if tokenScope == nil {
+		tokenScope = []string{}
+	}
+	scope, d := types.ListValueFrom(ctx, types.StringType, tokenScope)
+	diags.Append(d...)
+	data.Scope = scope
+}
+
+// sameInstant reports whether two RFC3339 timestamps denote the same instant.
+func sameInstant(a, b string) bool {
+	ta, err := time.Parse(time.RFC3339, a)
+	if err != nil {
+		return false
+	}
+	tb, err := time.Parse(time.RFC3339, b)
+	if err != nil {
+		return false
+	}
+	return ta.Equal(tb)
+}
@@ -0,0 +1,199 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+)
+
+func TestAccAdminTokenResource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create a token that never expires
+			{
+				Config: testAccAdminTokenResourceConfig_basic("test-admin-token"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_admin_token.test", "name", "test-admin-token"),
+					resource.TestCheckResourceAttr("garage_admin_token.test", "scope.#", "1"),
+					resource.TestCheckResourceAttr("garage_admin_token.test", "never_expires", "true"),
+					resource.TestCheckResourceAttr("garage_admin_token.test", "expired", "false"),
+					resource.TestCheckNoResourceAttr("garage_admin_token.test", "expiration"),
+					resource.TestCheckResourceAttrSet("garage_admin_token.test", "id"),
+					resource.TestCheckResourceAttrSet("garage_admin_token.test", "secret_token"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "garage_admin_token.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret_token"},
+			},
+			// Set an expiration in place
+			{
+				Config: testAccAdminTokenResourceConfig_expiration("test-admin-token", "2099-01-01T00:00:00Z"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_admin_token.test", "expiration", "2099-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("garage_admin_token.test", "never_expires", "false"),
+					resource.TestCheckResourceAttr("garage_admin_token.test", "expired", "false"),
+				),
+			},
+			// Rename the token and widen its scope in place
+			{
+				Config: testAccAdminTokenResourceConfig_renamed("test-admin-token-renamed"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("garage_admin_token.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_admin_token.test", "name", "test-admin-token-renamed"),
+					resource.TestCheckResourceAttr("garage_admin_token.test", "scope.#", "2"),
+					resource.TestCheckResourceAttrSet("garage_admin_token.test", "secret_token"),
+				),
+			},
+			// Remove the expiration again
+			{
+				Config: testAccAdminTokenResourceConfig_neverExpires("test-admin-token-renamed"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_admin_token.test", "never_expires", "true"),
+					resource.TestCheckNoResourceAttr("garage_admin_token.test", "expiration"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAdminTokenResource_rotateAfter(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A token younger than the interval is kept
+			{
+				Config: testAccAdminTokenResourceConfig_rotateAfter("test-admin-token-rotate", "8760h"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_admin_token.test", "rotate_after", "8760h"),
+					resource.TestCheckResourceAttrSet("garage_admin_token.test", "created"),
+				),
+			},
+			{
+				Config: testAccAdminTokenResourceConfig_rotateAfter("test-admin-token-rotate", "8760h"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Shortening the interval below the token age replaces the token
+			{
+				PreConfig: func() { time.Sleep(2 * time.Second) },
+				Config:    testAccAdminTokenResourceConfig_rotateAfter("test-admin-token-rotate", "1s"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("garage_admin_token.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("garage_admin_token.test", "secret_token"),
+				),
+			},
+		},
+	})
+}
+
+func TestSameInstant(t *testing.T) {
+	if !sameInstant("2030-01-01T02:00:00+02:00", "2030-01-01T00:00:00Z") {
+		t.Error("Expected timestamps in different offsets to denote the same instant")
+	}
+	if sameInstant("2030-01-01T00:00:00Z", "2030-01-02T00:00:00Z") {
+		t.Error("Expected different days to differ")
+	}
+	if sameInstant("", "2030-01-01T00:00:00Z") {
+		t.Error("Expected an empty timestamp to differ")
+	}
+}
+
+func TestAdminTokenRequest_emptyScope(t *testing.T) {
+	r := &AdminTokenResource{}
+	data := AdminTokenResourceModel{
+		Name:       types.StringValue("ci"),
+		Expiration: types.StringNull(),
+		Scope:      types.ListValueMust(types.StringType, []attr.Value{}),
+	}
+
+	req, diags := r.tokenRequest(context.Background(), data)
+	if diags.HasError() {
+		t.Fatalf("Expected no error, got %v", diags)
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !strings.Contains(string(body), `"scope":[]`) {
+		t.Errorf("Expected the empty scope to be sent, got %s", body)
+	}
+}
+
+func testAccAdminTokenResourceConfig_basic(name string) string {
+	return fmt.Sprintf(`
+resource "garage_admin_token" "test" {
+  name  = %[1]q
+  scope = ["GetClusterHealth"]
+}
+`, name)
+}
+
+func testAccAdminTokenResourceConfig_expiration(name, expiration string) string {
+	return fmt.Sprintf(`
+resource "garage_admin_token" "test" {
+  name       = %[1]q
+  scope      = ["GetClusterHealth"]
+  expiration = %[2]q
+}
+`, name, expiration)
+}
+
+func testAccAdminTokenResourceConfig_renamed(name string) string {
+	return fmt.Sprintf(`
+resource "garage_admin_token" "test" {
+  name       = %[1]q
+  scope      = ["GetClusterHealth", "GetClusterStatus"]
+  expiration = "2099-01-01T00:00:00Z"
+}
+`, name)
+}
+
+func testAccAdminTokenResourceConfig_neverExpires(name string) string {
+	return fmt.Sprintf(`
+resource "garage_admin_token" "test" {
+  name          = %[1]q
+  scope         = ["GetClusterHealth", "GetClusterStatus"]
+  never_expires = true
+}
+`, name)
+}
+
+func testAccAdminTokenResourceConfig_rotateAfter(name, rotateAfter string) string {
+	return fmt.Sprintf(`
+resource "garage_admin_token" "test" {
+  name         = %[1]q
+  scope        = ["GetClusterHealth"]
+  rotate_after = %[2]q
+}
+`, name, rotateAfter)
+}
@@ -0,0 +1,264 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BlockErrorsDataSource{}
+
+// Garage retries resynchronizing a block after a delay that doubles with each
+// failure, up to a maximum, which gives an estimate of when it first failed.
+const (
+	blockResyncRetryDelay           = 60 * time.Second
+	blockResyncRetryMaxBackoffPower = 6
+)
+
+func NewBlockErrorsDataSource() datasource.DataSource {
+	return &BlockErrorsDataSource{}
+}
+
+// BlockErrorsDataSource defines the data source implementation.
+type BlockErrorsDataSource struct {
+	client *client.Client
+}
+
+// BlockErrorsDataSourceModel describes the data source data model.
+type BlockErrorsDataSourceModel struct {
+	Node        types.String      `tfsdk:"node"`
+	MinErrorAge types.String      `tfsdk:"min_error_age"`
+	MinRefcount types.Int64       `tfsdk:"min_refcount"`
+	MaxRefcount types.Int64       `tfsdk:"max_refcount"`
+	Errors      []BlockErrorModel `tfsdk:"errors"`
+}
+
+// BlockErrorModel describes a single block error of the list.
+type BlockErrorModel struct {
+	NodeID         types.String `tfsdk:"node_id"`
+	BlockHash      types.String `tfsdk:"block_hash"`
+	Refcount       types.Int64  `tfsdk:"refcount"`
+	ErrorCount     types.Int64  `tfsdk:"error_count"`
+	LastTrySecsAgo types.Int64  `tfsdk:"last_try_secs_ago"`
+	NextTryInSecs  types.Int64  `tfsdk:"next_try_in_secs"`
+	ErrorAgeSecs   types.Int64  `tfsdk:"error_age_secs"`
+}
+
+// blockErrorFilter selects the block errors to list.
+type blockErrorFilter struct {
+	minErrorAge time.Duration
+	minRefcount types.Int64
+	maxRefcount types.Int64
+}
+
+func (d *BlockErrorsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_block_errors"
+}
+
+func (d *BlockErrorsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the data blocks that nodes failed to resynchronize, as shown by `garage block list-errors`, to alert on damaged blocks or decide when to launch a repair with `garage_repair`.",
+
+		Attributes: map[string]schema.Attribute{
+			"node": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The node to list the block errors of: a node ID, `self` for the node the provider talks to, or `*` for every node. Defaults to `*`.",
+			},
+			"min_error_age": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list blocks that have been failing for at least this long (e.g., `24h`), as estimated by `error_age_secs`, to leave out blocks that are likely to be resynchronized on their own.",
+				Validators: []validator.String{
+					positiveDuration(),
+				},
+			},
+			"min_refcount": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Only list blocks referenced by at least this many object versions. Set to `1` to list the blocks whose loss damages objects.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_refcount": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Only list blocks referenced by at most this many object versions. Set to `0` to list the blocks that can be purged safely.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"errors": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The block errors, ordered by node ID and block hash.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"node_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the node that failed to resynchronize the block.",
+						},
+						"block_hash": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The hash of the block.",
+						},
+						"refcount": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The number of object versions referencing the block. A block with no references can be purged safely.",
+						},
+						"error_count": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The number of failed resynchronization attempts.",
+						},
+						"last_try_secs_ago": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "How many seconds ago the last attempt failed.",
+						},
+						"next_try_in_secs": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "In how many seconds the next attempt is scheduled.",
+						},
+						"error_age_secs": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "An estimate of how many seconds ago the block first failed to resynchronize, from the number of attempts and the delay Garage waits between them.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *BlockErrorsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *BlockErrorsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data BlockErrorsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Node.IsNull() {
+		data.Node = types.StringValue(client.AllNodes)
+	}
+
+	filter := blockErrorFilter{minRefcount: data.MinRefcount, maxRefcount: data.MaxRefcount}
+	if !data.MinErrorAge.IsNull() {
+		minErrorAge, err := time.ParseDuration(data.MinErrorAge.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("min_error_age"), "Invalid Duration", err.Error())
+			return
+		}
+		filter.minErrorAge = minErrorAge
+	}
+
+	tflog.Debug(ctx, "Listing block errors", map[string]interface{}{
+		"node": data.Node.ValueString(),
+	})
+
+	result, err := d.client.ListBlockErrors(ctx, data.Node.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "list block errors", err)
+		return
+	}
+
+	for nodeID, message := range result.Error {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list block errors on node %s, got error: %s", nodeID, message))
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Errors = blockErrorModels(result.Success, filter)
+
+	tflog.Trace(ctx, "Read block errors data source", map[string]interface{}{
+		"errors": len(data.Errors),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// blockErrorModels flattens the block errors reported by each node that
+// match filter, ordered by node ID and block hash so that the list is stable
+// across reads.
+func blockErrorModels(reported map[string][]client.BlockError, filter blockErrorFilter) []BlockErrorModel {
+	models := []BlockErrorModel{}
+	for nodeID, blockErrors := range reported {
+		for _, blockError := range blockErrors {
+			if !filter.matches(blockError) {
+				continue
+			}
+
+			models = append(models, BlockErrorModel{
+				NodeID:         types.StringValue(nodeID),
+				BlockHash:      types.StringValue(blockError.BlockHash),
+				Refcount:       types.Int64Value(blockError.Refcount),
+				ErrorCount:     types.Int64Value(blockError.ErrorCount),
+				LastTrySecsAgo: types.Int64Value(blockError.LastTrySecsAgo),
+				NextTryInSecs:  types.Int64Value(blockError.NextTryInSecs),
+				ErrorAgeSecs:   types.Int64Value(int64(blockErrorAge(blockError).Seconds())),
+			})
+		}
+	}
+
+	sort.Slice(models, func(i, j int) bool {
+		if a, b := models[i].NodeID.ValueString(), models[j].NodeID.ValueString(); a != b {
+			return a < b
+		}
+		return models[i].BlockHash.ValueString() < models[j].BlockHash.ValueString()
+	})
+
+	return models
+}
+
+// matches reports whether a block error is selected by the filter.
+func (f blockErrorFilter) matches(blockError client.BlockError) bool {
+	if !f.minRefcount.IsNull() && blockError.Refcount < f.minRefcount.ValueInt64() {
+		return false
+	}
+	if !f.maxRefcount.IsNull() && blockError.Refcount > f.maxRefcount.ValueInt64() {
+		return false
+	}
+	return blockErrorAge(blockError) >= f.minErrorAge
+}
+
+// blockErrorAge estimates how long ago a block first failed to resynchronize,
+// adding up the delays Garage waited between the failed attempts.
+func blockErrorAge(blockError client.BlockError) time.Duration {
+	age := time.Duration(blockError.LastTrySecsAgo) * time.Second
+	for attempt := int64(1); attempt < blockError.ErrorCount; attempt++ {
+		age += blockResyncRetryDelay << min(attempt-1, blockResyncRetryMaxBackoffPower)
+	}
+	return age
+}
@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-garage/internal/client"
+)
+
+func TestAccBlockErrorsDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBlockErrorsDataSourceConfig_basic,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.garage_block_errors.all", "node", "*"),
+					resource.TestCheckResourceAttrSet("data.garage_block_errors.all", "errors.#"),
+					resource.TestCheckResourceAttr("data.garage_block_errors.self", "node", "self"),
+					resource.TestCheckResourceAttr("data.garage_block_errors.long_standing", "min_error_age", "24h"),
+				),
+			},
+		},
+	})
+}
+
+func TestBlockErrorModels(t *testing.T) {
+	models := blockErrorModels(map[string][]client.BlockError{
+		"node-2": {{BlockHash: "aa", ErrorCount: 1}},
+		"node-1": {{BlockHash: "cc", ErrorCount: 2}, {BlockHash: "bb", Refcount: 3}},
+		"node-3": {},
+	}, blockErrorFilter{})
+
+	want := []string{"node-1/bb", "node-1/cc", "node-2/aa"}
+	if len(models) != len(want) {
+		t.Fatalf("Expected %d block errors, got %d", len(want), len(models))
+	}
+
+	for i, model := range models {
+		if got := model.NodeID.ValueString() + "/" + model.BlockHash.ValueString(); got != want[i] {
+			t.Errorf("Expected block error %d to be %s, got %s", i, want[i], got)
+		}
+	}
+
+	if models[0].Refcount.ValueInt64() != 3 {
+		t.Errorf("Expected refcount 3, got %d", models[0].Refcount.ValueInt64())
+	}
+}
+
+func TestBlockErrorModels_filter(t *testing.T) {
+	reported := map[string][]client.BlockError{
+		"node-1": {
+			// Failing for 1 minute
+			{BlockHash: "recent", Refcount: 1, ErrorCount: 1, LastTrySecsAgo: 60},
+			// Failing for 1+2+4 minutes and 30 seconds
+			{BlockHash: "old", Refcount: 2, ErrorCount: 4, LastTrySecsAgo: 30},
+			{BlockHash: "unreferenced", Refcount: 0, ErrorCount: 20},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		filter blockErrorFilter
+		want   []string
+	}{
+		{name: "none", want: []string{"old", "recent", "unreferenced"}},
+		{name: "min error age", filter: blockErrorFilter{minErrorAge: 5 * time.Minute}, want: []string{"old", "unreferenced"}},
+		{name: "min refcount", filter: blockErrorFilter{minRefcount: types.Int64Value(1)}, want: []string{"old", "recent"}},
+		{name: "max refcount", filter: blockErrorFilter{maxRefcount: types.Int64Value(0)}, want: []string{"unreferenced"}},
+		{
+			name:   "combined",
+			filter: blockErrorFilter{minErrorAge: 5 * time.Minute, minRefcount: types.Int64Value(1), maxRefcount: types.Int64Value(2)},
+			want:   []string{"old"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			models := blockErrorModels(reported, tt.filter)
+
+			var got []string
+			for _, model := range models {
+				got = append(got, model.BlockHash.ValueString())
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Expected blocks %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBlockErrorAge(t *testing.T) {
+	tests := []struct {
+		blockError client.BlockError
+		want       time.Duration
+	}{
+		{blockError: client.BlockError{ErrorCount: 1, LastTrySecsAgo: 10}, want: 10 * time.Second},
+		{blockError: client.BlockError{ErrorCount: 3, LastTrySecsAgo: 10}, want: 3*time.Minute + 10*time.Second},
+		// The delay stops doubling after 64 minutes
+		{blockError: client.BlockError{ErrorCount: 9}, want: (1 + 2 + 4 + 8 + 16 + 32 + 64 + 64) * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := blockErrorAge(tt.blockError); got != tt.want {
+			t.Errorf("Expected age %s for %+v, got %s", tt.want, tt.blockError, got)
+		}
+	}
+}
+
+const testAccBlockErrorsDataSourceConfig_basic = `
+data "garage_block_errors" "all" {}
+
+data "garage_block_errors" "self" {
+  node = "self"
+}
+
+data "garage_block_errors" "long_standing" {
+  min_error_age = "24h"
+  min_refcount  = 1
+}
+`
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BucketAliasDataSource{}
+
+func NewBucketAliasDataSource() datasource.DataSource {
+	return &BucketAliasDataSource{}
+}
+
+// BucketAliasDataSource defines the data source implementation.
+type BucketAliasDataSource struct {
+	client *client.Client
+}
+
+// BucketAliasDataSourceModel describes the data source data model.
+type BucketAliasDataSourceModel struct {
+	GlobalAlias types.String `tfsdk:"global_alias"`
+	BucketID    types.String `tfsdk:"bucket_id"`
+	Exists      types.Bool   `tfsdk:"exists"`
+}
+
+func (d *BucketAliasDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bucket_alias"
+}
+
+func (d *BucketAliasDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Resolves a global alias to a bucket ID. Unlike the `garage_bucket` data source, a missing bucket is not an error, " +
+			"so modules can decide whether to create a bucket or adopt an existing one.",
+
+		Attributes: map[string]schema.Attribute{
+			"global_alias": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The global alias to resolve.",
+			},
+			"bucket_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the bucket the alias points to, or null if no bucket has this alias.",
+			},
+			"exists": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether a bucket with this global alias exists.",
+			},
+		},
+	}
+}
+
+func (d *BucketAliasDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *BucketAliasDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data BucketAliasDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	alias := data.GlobalAlias.ValueString()
+
+	tflog.Debug(ctx, "Resolving bucket alias", map[string]interface{}{
+		"global_alias": alias,
+	})
+
+	bucket, err := d.client.GetBucketInfo(ctx, client.GetBucketInfoRequest{GlobalAlias: &alias})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read bucket", err)
+		return
+	}
+
+	if bucket == nil {
+		data.BucketID = types.StringNull()
+		data.Exists = types.BoolValue(false)
+	} else {
+		data.BucketID = types.StringValue(bucket.ID)
+		data.Exists = types.BoolValue(true)
+	}
+
+	tflog.Trace(ctx, "Read bucket alias data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBucketAliasDataSource_exists(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBucketAliasDataSourceConfig_exists("test-bucket-alias-ds"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.garage_bucket_alias.test", "exists", "true"),
+					resource.TestCheckResourceAttrPair(
+						"data.garage_bucket_alias.test", "bucket_id",
+						"garage_bucket.source", "id",
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBucketAliasDataSource_missing(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBucketAliasDataSourceConfig_missing("test-bucket-alias-ds-missing"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.garage_bucket_alias.test", "exists", "false"),
+					resource.TestCheckNoResourceAttr("data.garage_bucket_alias.test", "bucket_id"),
+				),
+			},
+		},
+	})
+}
+
+// Test configuration functions
+
+func testAccBucketAliasDataSourceConfig_exists(alias string) string {
+	return fmt.Sprintf(`
+resource "garage_bucket" "source" {
+  global_alias = %[1]q
+}
+
+data "garage_bucket_alias" "test" {
+  global_alias = garage_bucket.source.global_alias
+}
+`, alias)
+}
+
+func testAccBucketAliasDataSourceConfig_missing(alias string) string {
+	return fmt.Sprintf(`
+data "garage_bucket_alias" "test" {
+  global_alias = %[1]q
+}
+`, alias)
+}
@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BucketAliasesDataSource{}
+
+func NewBucketAliasesDataSource() datasource.DataSource {
+	return &BucketAliasesDataSource{}
+}
+
+// BucketAliasesDataSource defines the data source implementation.
+type BucketAliasesDataSource struct {
+	client *client.Client
+}
+
+// BucketAliasesDataSourceModel describes the data source data model.
+type BucketAliasesDataSourceModel struct {
+	BucketIDs map[string]types.String `tfsdk:"bucket_ids"`
+}
+
+func (d *BucketAliasesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bucket_aliases"
+}
+
+func (d *BucketAliasesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Maps the global aliases of all buckets of the cluster to their bucket IDs, " +
+			"for use as a `for_each` source when managing permissions or quotas across existing buckets.",
+
+		Attributes: map[string]schema.Attribute{
+			"bucket_ids": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The bucket IDs keyed by global alias. A bucket with several global aliases appears once per alias; buckets without a global alias are left out.",
+			},
+		},
+	}
+}
+
+func (d *BucketAliasesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *BucketAliasesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data BucketAliasesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.BucketIDs = map[string]types.String{}
+	err := d.client.EachBucket(ctx, func(bucket client.Bucket) error {
+		for _, alias := range bucket.GlobalAliases {
+			data.BucketIDs[alias] = types.StringValue(bucket.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "list buckets", err)
+		return
+	}
+
+	tflog.Trace(ctx, "Read bucket aliases data source", map[string]interface{}{
+		"aliases": len(data.BucketIDs),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBucketAliasesDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBucketAliasesDataSourceConfig("test-bucket-aliases-ds"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.garage_bucket_aliases.test", "bucket_ids.test-bucket-aliases-ds",
+						"garage_bucket.test", "id",
+					),
+				),
+			},
+		},
+	})
+}
+
+func testAccBucketAliasesDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "garage_bucket" "test" {
+  global_alias = %[1]q
+}
+
+data "garage_bucket_aliases" "test" {
+  depends_on = [garage_bucket.test]
+}
+`, name)
+}
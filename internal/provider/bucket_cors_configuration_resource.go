@@ -0,0 +1,390 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// corsRuleAttributeTypes are the attributes of a CORS rule.
+var corsRuleAttributeTypes = map[string]attr.Type{
+	"id":              types.StringType,
+	"allowed_origins": types.SetType{ElemType: types.StringType},
+	"allowed_methods": types.SetType{ElemType: types.StringType},
+	"allowed_headers": types.SetType{ElemType: types.StringType},
+	"expose_headers":  types.SetType{ElemType: types.StringType},
+	"max_age_seconds": types.Int64Type,
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BucketCorsConfigurationResource{}
+
+func NewBucketCorsConfigurationResource() resource.Resource {
+	return &BucketCorsConfigurationResource{}
+}
+
+// BucketCorsConfigurationResource defines the resource implementation.
+type BucketCorsConfigurationResource struct {
+	client *client.Client
+}
+
+// BucketCorsConfigurationResourceModel describes the resource data model.
+type BucketCorsConfigurationResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Bucket          types.String `tfsdk:"bucket"`
+	AccessKeyID     types.String `tfsdk:"access_key_id"`
+	SecretAccessKey types.String `tfsdk:"secret_access_key"`
+	CORSRules       types.List   `tfsdk:"cors_rule"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// CORSRuleModel describes a CORS rule of a bucket.
+type CORSRuleModel struct {
+	ID             types.String `tfsdk:"id"`
+	AllowedOrigins types.Set    `tfsdk:"allowed_origins"`
+	AllowedMethods types.Set    `tfsdk:"allowed_methods"`
+	AllowedHeaders types.Set    `tfsdk:"allowed_headers"`
+	ExposeHeaders  types.Set    `tfsdk:"expose_headers"`
+	MaxAgeSeconds  types.Int64  `tfsdk:"max_age_seconds"`
+}
+
+func (r *BucketCorsConfigurationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bucket_cors_configuration"
+}
+
+func (r *BucketCorsConfigurationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the CORS rules of a bucket through the S3 API, so that web applications on other origins can use the bucket. " +
+			"Destroying the resource removes the CORS configuration of the bucket. Requires the provider `s3_endpoint` to be set.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the CORS configuration (same as `bucket`).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"bucket": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the bucket in the S3 API: a global alias of the bucket, or a local alias of the access key.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"access_key_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The ID of an access key with owner permission on the bucket. Defaults to the provider `s3_access_key_id`.",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("secret_access_key")),
+				},
+			},
+			"secret_access_key": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The secret of the access key.",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("access_key_id")),
+				},
+			},
+			"cors_rule": schema.ListNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "The CORS rules of the bucket. The first rule matching a request applies.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "An identifier for the rule.",
+						},
+						"allowed_origins": schema.SetAttribute{
+							Required:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The origins allowed to make cross-origin requests (e.g., 'https://app.example.com', or '*' for any origin).",
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+							},
+						},
+						"allowed_methods": schema.SetAttribute{
+							Required:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The HTTP methods allowed: `GET`, `PUT`, `POST`, `DELETE` or `HEAD`.",
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+								setvalidator.ValueStringsAre(stringvalidator.OneOf(
+									http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete, http.MethodHead,
+								)),
+							},
+						},
+						"allowed_headers": schema.SetAttribute{
+							Optional:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The request headers allowed in a preflight request (e.g., 'Authorization', or '*' for any header).",
+						},
+						"expose_headers": schema.SetAttribute{
+							Optional:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The response headers the browser lets the application read (e.g., 'ETag').",
+						},
+						"max_age_seconds": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "How long the browser may cache the result of a preflight request, in seconds.",
+							Validators: []validator.Int64{
+								int64validator.AtLeast(0),
+							},
+						},
+					},
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *BucketCorsConfigurationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BucketCorsConfigurationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BucketCorsConfigurationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	r.put(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Created bucket CORS configuration resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketCorsConfigurationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BucketCorsConfigurationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	s3, err := r.client.NewS3Client(data.AccessKeyID.ValueString(), data.SecretAccessKey.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read bucket CORS configuration", err)
+		return
+	}
+
+	rules, err := s3.GetBucketCors(ctx, data.Bucket.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read bucket CORS configuration", err)
+		return
+	}
+
+	if len(rules) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	value, diags := corsRulesValue(ctx, rules)
+	resp.Diagnostics.Append(diags...)
+	data.CORSRules = value
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketCorsConfigurationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data BucketCorsConfigurationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	r.put(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Updated bucket CORS configuration resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketCorsConfigurationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BucketCorsConfigurationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, defaultDeleteTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	tflog.Debug(ctx, "Deleting bucket CORS configuration", map[string]interface{}{
+		"bucket": data.Bucket.ValueString(),
+	})
+
+	s3, err := r.client.NewS3Client(data.AccessKeyID.ValueString(), data.SecretAccessKey.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "delete bucket CORS configuration", err)
+		return
+	}
+
+	if err := s3.DeleteBucketCors(ctx, data.Bucket.ValueString()); err != nil {
+		addClientError(&resp.Diagnostics, "delete bucket CORS configuration", err)
+		return
+	}
+
+	tflog.Trace(ctx, "Deleted bucket CORS configuration resource")
+}
+
+// put replaces the CORS rules of the bucket with those of the model.
+func (r *BucketCorsConfigurationResource) put(ctx context.Context, data *BucketCorsConfigurationResourceModel, diags *diag.Diagnostics) {
+	var ruleModels []CORSRuleModel
+	diags.Append(data.CORSRules.ElementsAs(ctx, &ruleModels, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	rules := make([]client.CORSRule, 0, len(ruleModels))
+	for _, model := range ruleModels {
+		rules = append(rules, corsRule(ctx, model, diags))
+	}
+	if diags.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Putting bucket CORS configuration", map[string]interface{}{
+		"bucket": data.Bucket.ValueString(),
+		"rules":  len(rules),
+	})
+
+	s3, err := r.client.NewS3Client(data.AccessKeyID.ValueString(), data.SecretAccessKey.ValueString())
+	if err != nil {
+		addClientError(diags, "put bucket CORS configuration", err)
+		return
+	}
+
+	if err := s3.PutBucketCors(ctx, data.Bucket.ValueString(), rules); err != nil {
+		addClientError(diags, "put bucket CORS configuration", err)
+		return
+	}
+
+	data.ID = data.Bucket
+}
+
+// corsRule converts a CORS rule of the model to the S3 API representation.
+func corsRule(ctx context.Context, model CORSRuleModel, diags *diag.Diagnostics) client.CORSRule {
+	rule := client.CORSRule{
+		ID: model.ID.ValueString(),
+	}
+
+	diags.Append(model.AllowedOrigins.ElementsAs(ctx, &rule.AllowedOrigins, false)...)
+	diags.Append(model.AllowedMethods.ElementsAs(ctx, &rule.AllowedMethods, false)...)
+	if !model.AllowedHeaders.IsNull() {
+		diags.Append(model.AllowedHeaders.ElementsAs(ctx, &rule.AllowedHeaders, false)...)
+	}
+	if !model.ExposeHeaders.IsNull() {
+		diags.Append(model.ExposeHeaders.ElementsAs(ctx, &rule.ExposeHeaders, false)...)
+	}
+	if !model.MaxAgeSeconds.IsNull() {
+		maxAge := model.MaxAgeSeconds.ValueInt64()
+		rule.MaxAgeSeconds = &maxAge
+	}
+
+	return rule
+}
+
+// corsRulesValue converts the CORS rules of a bucket to the value of the
+// cors_rule attribute. Empty optional fields are null, as when not configured.
+func corsRulesValue(ctx context.Context, rules []client.CORSRule) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	optionalSet := func(values []string) types.Set {
+		if len(values) == 0 {
+			return types.SetNull(types.StringType)
+		}
+		value, d := types.SetValueFrom(ctx, types.StringType, values)
+		diags.Append(d...)
+		return value
+	}
+
+	models := make([]CORSRuleModel, 0, len(rules))
+	for _, rule := range rules {
+		model := CORSRuleModel{
+			ID:             types.StringNull(),
+			AllowedOrigins: optionalSet(rule.AllowedOrigins),
+			AllowedMethods: optionalSet(rule.AllowedMethods),
+			AllowedHeaders: optionalSet(rule.AllowedHeaders),
+			ExposeHeaders:  optionalSet(rule.ExposeHeaders),
+			MaxAgeSeconds:  types.Int64PointerValue(rule.MaxAgeSeconds),
+		}
+		if rule.ID != "" {
+			model.ID = types.StringValue(rule.ID)
+		}
+		models = append(models, model)
+	}
+
+	value, d := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: corsRuleAttributeTypes}, models)
+	diags.Append(d...)
+	return value, diags
+}
@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-garage/internal/client"
+)
+
+func TestAccBucketCorsConfigurationResource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckS3(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBucketCorsConfigurationResourceConfig_basic("test-bucket-cors", `["GET"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_bucket_cors_configuration.test", "id", "test-bucket-cors"),
+					resource.TestCheckResourceAttr("garage_bucket_cors_configuration.test", "cors_rule.#", "1"),
+					resource.TestCheckResourceAttr("garage_bucket_cors_configuration.test", "cors_rule.0.allowed_methods.#", "1"),
+					resource.TestCheckResourceAttr("garage_bucket_cors_configuration.test", "cors_rule.0.max_age_seconds", "3600"),
+				),
+			},
+			{
+				Config: testAccBucketCorsConfigurationResourceConfig_basic("test-bucket-cors", `["GET", "PUT"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_bucket_cors_configuration.test", "cors_rule.0.allowed_methods.#", "2"),
+					resource.TestCheckTypeSetElemAttr("garage_bucket_cors_configuration.test", "cors_rule.0.allowed_methods.*", "PUT"),
+				),
+			},
+		},
+	})
+}
+
+func TestCORSRulesRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	maxAge := int64(600)
+
+	rules := []client.CORSRule{
+		{
+			ID:             "uploads",
+			AllowedOrigins: []string{"https://app.example.com"},
+			AllowedMethods: []string{"PUT", "POST"},
+			AllowedHeaders: []string{"*"},
+			ExposeHeaders:  []string{"ETag"},
+			MaxAgeSeconds:  &maxAge,
+		},
+		{
+			AllowedOrigins: []string{"*"},
+			AllowedMethods: []string{"GET"},
+		},
+	}
+
+	value, diags := corsRulesValue(ctx, rules)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	var models []CORSRuleModel
+	diags.Append(value.ElementsAs(ctx, &models, false)...)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if !models[1].ID.IsNull() || !models[1].AllowedHeaders.IsNull() || !models[1].ExposeHeaders.IsNull() || !models[1].MaxAgeSeconds.IsNull() {
+		t.Errorf("expected unset fields to be null, got %+v", models[1])
+	}
+
+	for i, model := range models {
+		var d diag.Diagnostics
+		got := corsRule(ctx, model, &d)
+		if d.HasError() {
+			t.Fatalf("unexpected error: %v", d)
+		}
+		if !reflect.DeepEqual(got, rules[i]) {
+			t.Errorf("rule %d: expected %+v, got %+v", i, rules[i], got)
+		}
+	}
+}
+
+func testAccBucketCorsConfigurationResourceConfig_basic(name, methods string) string {
+	return fmt.Sprintf(`
+resource "garage_bucket" "test" {
+  global_alias = %[1]q
+}
+
+resource "garage_key" "test" {
+  name = "%[1]s-key"
+}
+
+resource "garage_bucket_permission" "test" {
+  bucket_id     = garage_bucket.test.id
+  access_key_id = garage_key.test.id
+  owner         = true
+}
+
+resource "garage_bucket_cors_configuration" "test" {
+  bucket            = garage_bucket.test.global_alias
+  access_key_id     = garage_bucket_permission.test.access_key_id
+  secret_access_key = garage_key.test.secret_access_key
+
+  cors_rule = [{
+    allowed_origins = ["https://app.example.com"]
+    allowed_methods = %[2]s
+    max_age_seconds = 3600
+  }]
+}
+`, name, methods)
+}
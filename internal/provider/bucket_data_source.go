@@ -7,8 +7,11 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -40,6 +43,8 @@ type BucketDataSourceModel struct {
 	Objects           types.Int64  `tfsdk:"objects"`
 	Bytes             types.Int64  `tfsdk:"bytes"`
 	UnfinishedUploads types.Int64  `tfsdk:"unfinished_uploads"`
+	Keys              types.List   `tfsdk:"keys"`
+	FailIfMissing     types.Bool   `tfsdk:"fail_if_missing"`
 }
 
 func (d *BucketDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -54,12 +59,19 @@ func (d *BucketDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 			"id": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "The unique identifier of the bucket. Either id or global_alias must be specified.",
+				MarkdownDescription: "The unique identifier of the bucket. Exactly one of id or global_alias must be specified.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("global_alias")),
+				},
 			},
 			"global_alias": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "The primary global alias (name) of the bucket. Either id or global_alias must be specified.",
+				MarkdownDescription: "A global alias (name) of the bucket. Exactly one of id or global_alias must be specified. When looking up by id, set to the first global alias of the bucket.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 			"global_aliases": schema.ListAttribute{
 				Computed:            true,
@@ -98,6 +110,38 @@ func (d *BucketDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				Computed:            true,
 				MarkdownDescription: "Number of unfinished multipart uploads.",
 			},
+			"keys": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The access keys granted permissions on the bucket, for instance to assert that only an allowlist of keys owns it.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"access_key_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The access key ID.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the key.",
+						},
+						"read": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the key can read from the bucket.",
+						},
+						"write": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the key can write to the bucket.",
+						},
+						"owner": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the key owns the bucket.",
+						},
+					},
+				},
+			},
+			"fail_if_missing": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether to fail when the bucket does not exist. When `false`, a missing bucket leaves all computed attributes null instead. Defaults to `true`.",
+			},
 		},
 	}
 }
@@ -130,37 +174,39 @@ func (d *BucketDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	// Validate that either ID or GlobalAlias is provided
-	if data.ID.IsNull() && data.GlobalAlias.IsNull() {
-		resp.Diagnostics.AddError(
-			"Missing Required Attribute",
-			"Either 'id' or 'global_alias' must be specified.",
-		)
-		return
-	}
-
 	tflog.Debug(ctx, "Reading bucket data source", map[string]interface{}{
 		"id":           data.ID.ValueString(),
 		"global_alias": data.GlobalAlias.ValueString(),
 	})
 
-	// Build request
-	getBucketReq := client.GetBucketInfoRequest{}
-
-	if !data.ID.IsNull() {
-		id := data.ID.ValueString()
-		getBucketReq.ID = &id
-	}
-
-	if !data.GlobalAlias.IsNull() {
-		alias := data.GlobalAlias.ValueString()
-		getBucketReq.GlobalAlias = &alias
+	// Build request; the schema ensures exactly one of them is set
+	getBucketReq := client.GetBucketInfoRequest{
+		ID:          data.ID.ValueStringPointer(),
+		GlobalAlias: data.GlobalAlias.ValueStringPointer(),
 	}
 
 	// Fetch bucket info
 	bucket, err := d.client.GetBucketInfo(ctx, getBucketReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read bucket, got error: %s", err))
+		addClientError(&resp.Diagnostics, "read bucket", err)
+		return
+	}
+
+	if bucket == nil && !data.FailIfMissing.IsNull() && !data.FailIfMissing.ValueBool() {
+		tflog.Debug(ctx, "Bucket not found, returning null attributes")
+
+		data.GlobalAliases = types.ListNull(types.StringType)
+		data.WebsiteEnabled = types.BoolNull()
+		data.WebsiteIndex = types.StringNull()
+		data.WebsiteError = types.StringNull()
+		data.MaxSize = types.Int64Null()
+		data.MaxObjects = types.Int64Null()
+		data.Objects = types.Int64Null()
+		data.Bytes = types.Int64Null()
+		data.UnfinishedUploads = types.Int64Null()
+		data.Keys = types.ListNull(types.ObjectType{AttrTypes: bucketKeyAttributeTypes})
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 		return
 	}
 
@@ -176,7 +222,10 @@ func (d *BucketDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	data.ID = types.StringValue(bucket.ID)
 
 	if len(bucket.GlobalAliases) > 0 {
-		data.GlobalAlias = types.StringValue(bucket.GlobalAliases[0])
+		// Keep the alias the bucket was looked up by, which may not be its first
+		if data.GlobalAlias.IsNull() {
+			data.GlobalAlias = types.StringValue(bucket.GlobalAliases[0])
+		}
 
 		aliases := make([]types.String, 0, len(bucket.GlobalAliases))
 		for _, alias := range bucket.GlobalAliases {
@@ -223,6 +272,10 @@ func (d *BucketDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	data.Bytes = types.Int64Value(bucket.Bytes)
 	data.UnfinishedUploads = types.Int64Value(bucket.UnfinishedUploads)
 
+	keys, diags := bucketKeysValue(ctx, bucket.Keys)
+	resp.Diagnostics.Append(diags...)
+	data.Keys = keys
+
 	tflog.Trace(ctx, "Read bucket data source")
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
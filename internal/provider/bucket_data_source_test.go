@@ -5,6 +5,7 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -105,6 +106,27 @@ func TestAccBucketDataSource_full(t *testing.T) {
 					resource.TestCheckResourceAttrSet("data.garage_bucket.test", "objects"),
 					resource.TestCheckResourceAttrSet("data.garage_bucket.test", "bytes"),
 					resource.TestCheckResourceAttrSet("data.garage_bucket.test", "unfinished_uploads"),
+					resource.TestCheckResourceAttr("data.garage_bucket.test", "keys.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBucketDataSource_keys(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBucketDataSourceConfig_keys("test-bucket-datasource-keys"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.garage_bucket.test", "keys.#", "1"),
+					resource.TestCheckResourceAttrPair("data.garage_bucket.test", "keys.0.access_key_id", "garage_key.test", "id"),
+					resource.TestCheckResourceAttr("data.garage_bucket.test", "keys.0.name", "test-bucket-datasource-keys-key"),
+					resource.TestCheckResourceAttr("data.garage_bucket.test", "keys.0.read", "true"),
+					resource.TestCheckResourceAttr("data.garage_bucket.test", "keys.0.write", "false"),
+					resource.TestCheckResourceAttr("data.garage_bucket.test", "keys.0.owner", "false"),
 				),
 			},
 		},
@@ -129,6 +151,44 @@ func TestAccBucketDataSource_multipleAliases(t *testing.T) {
 	})
 }
 
+func TestAccBucketDataSource_idAndAlias(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccBucketDataSourceConfig_idAndAlias,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+			{
+				Config:      testAccBucketDataSourceConfig_neither,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+		},
+	})
+}
+
+func TestAccBucketDataSource_missing(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccBucketDataSourceConfig_missing("test-bucket-datasource-missing", true),
+				ExpectError: regexp.MustCompile("Bucket Not Found"),
+			},
+			{
+				Config: testAccBucketDataSourceConfig_missing("test-bucket-datasource-missing", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.garage_bucket.test", "global_alias", "test-bucket-datasource-missing"),
+					resource.TestCheckNoResourceAttr("data.garage_bucket.test", "id"),
+					resource.TestCheckNoResourceAttr("data.garage_bucket.test", "objects"),
+				),
+			},
+		},
+	})
+}
+
 // Test configuration functions
 
 func testAccBucketDataSourceConfig_byAlias(name string) string {
@@ -143,6 +203,26 @@ data "garage_bucket" "test" {
 `, name)
 }
 
+func testAccBucketDataSourceConfig_missing(name string, failIfMissing bool) string {
+	return fmt.Sprintf(`
+data "garage_bucket" "test" {
+  global_alias    = %[1]q
+  fail_if_missing = %[2]t
+}
+`, name, failIfMissing)
+}
+
+const testAccBucketDataSourceConfig_idAndAlias = `
+data "garage_bucket" "test" {
+  id           = "0123456789abcdef"
+  global_alias = "test-bucket-datasource-both"
+}
+`
+
+const testAccBucketDataSourceConfig_neither = `
+data "garage_bucket" "test" {}
+`
+
 func testAccBucketDataSourceConfig_byID(name string) string {
 	return fmt.Sprintf(`
 resource "garage_bucket" "source" {
@@ -184,6 +264,29 @@ data "garage_bucket" "test" {
 `, name)
 }
 
+func testAccBucketDataSourceConfig_keys(name string) string {
+	return fmt.Sprintf(`
+resource "garage_bucket" "source" {
+  global_alias = %[1]q
+}
+
+resource "garage_key" "test" {
+  name = "%[1]s-key"
+}
+
+resource "garage_bucket_permission" "test" {
+  bucket_id     = garage_bucket.source.id
+  access_key_id = garage_key.test.id
+  read          = true
+}
+
+data "garage_bucket" "test" {
+  id         = garage_bucket.source.id
+  depends_on = [garage_bucket_permission.test]
+}
+`, name)
+}
+
 func testAccBucketDataSourceConfig_full(name string) string {
 	return fmt.Sprintf(`
 resource "garage_bucket" "source" {
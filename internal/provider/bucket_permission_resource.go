@@ -5,12 +5,19 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -19,9 +26,18 @@ import (
 	"terraform-provider-garage/internal/client"
 )
 
+// keyConvergenceTimeout bounds how long Create retries AllowBucketKey while
+// Garage reports the access key as unknown, and keyConvergenceInterval is the
+// delay between attempts.
+var (
+	keyConvergenceTimeout  = 30 * time.Second
+	keyConvergenceInterval = time.Second
+)
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &BucketPermissionResource{}
 var _ resource.ResourceWithImportState = &BucketPermissionResource{}
+var _ resource.ResourceWithModifyPlan = &BucketPermissionResource{}
 
 func NewBucketPermissionResource() resource.Resource {
 	return &BucketPermissionResource{}
@@ -40,6 +56,13 @@ type BucketPermissionResourceModel struct {
 	Read        types.Bool   `tfsdk:"read"`
 	Write       types.Bool   `tfsdk:"write"`
 	Owner       types.Bool   `tfsdk:"owner"`
+
+	EffectiveRead  types.Bool `tfsdk:"effective_read"`
+	EffectiveWrite types.Bool `tfsdk:"effective_write"`
+	EffectiveOwner types.Bool `tfsdk:"effective_owner"`
+	LocalAliases   types.List `tfsdk:"local_aliases"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *BucketPermissionResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -48,7 +71,9 @@ func (r *BucketPermissionResource) Metadata(ctx context.Context, req resource.Me
 
 func (r *BucketPermissionResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Manages permissions for an access key on a Garage S3 bucket.",
+		MarkdownDescription: "Manages permissions for an access key on a Garage S3 bucket. " +
+			"Only the permissions of this key are managed: grants to other keys, including out-of-band ones, are left alone. " +
+			"To revoke every grant not declared in the configuration, use `garage_bucket_policy` instead.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -90,6 +115,39 @@ func (r *BucketPermissionResource) Schema(ctx context.Context, req resource.Sche
 				Default:             booldefault.StaticBool(false),
 				MarkdownDescription: "Grant owner permission to the access key.",
 			},
+			"effective_read": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the access key can read the bucket, as reported by Garage. Differs from `read` when the permissions were changed outside Terraform.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"effective_write": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the access key can write to the bucket, as reported by Garage. Differs from `write` when the permissions were changed outside Terraform.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"effective_owner": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the access key owns the bucket, as reported by Garage. Differs from `owner` when the permissions were changed outside Terraform.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"local_aliases": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The local aliases the access key has for the bucket.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
 		},
 	}
 }
@@ -113,6 +171,37 @@ func (r *BucketPermissionResource) Configure(ctx context.Context, req resource.C
 	r.client = client
 }
 
+func (r *BucketPermissionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !req.Plan.Raw.IsNull() {
+		var data, state BucketPermissionResourceModel
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+		if !req.State.Raw.IsNull() {
+			resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		}
+
+		// Both IDs force a replacement, so they only need checking when they change
+		if !data.BucketID.Equal(state.BucketID) || !data.AccessKeyID.Equal(state.AccessKeyID) {
+			r.validateReferences(ctx, data, &resp.Diagnostics)
+		}
+
+		// Applying sets the effective permissions to the configured ones, so
+		// they are unknown until then whenever both differ. This also plans an
+		// update for permissions changed outside Terraform.
+		if !data.EffectiveRead.Equal(data.Read) || !data.EffectiveWrite.Equal(data.Write) || !data.EffectiveOwner.Equal(data.Owner) {
+			data.EffectiveRead = types.BoolUnknown()
+			data.EffectiveWrite = types.BoolUnknown()
+			data.EffectiveOwner = types.BoolUnknown()
+			resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
+		}
+	}
+
+	validateTokenScope(ctx, r.client, resourceScopes{
+		Create: []string{"AllowBucketKey"},
+		Update: []string{"AllowBucketKey", "DenyBucketKey"},
+		Delete: []string{"DenyBucketKey"},
+	}, req, resp)
+}
+
 func (r *BucketPermissionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data BucketPermissionResourceModel
 
@@ -122,33 +211,43 @@ func (r *BucketPermissionResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	tflog.Debug(ctx, "Creating bucket permission", map[string]interface{}{
 		"bucket_id":     data.BucketID.ValueString(),
 		"access_key_id": data.AccessKeyID.ValueString(),
 	})
 
 	// Grant permissions using AllowBucketKey
-	allowReq := client.BucketKeyPermRequest{
-		BucketID:    data.BucketID.ValueString(),
-		AccessKeyID: data.AccessKeyID.ValueString(),
-		Permissions: client.Permissions{
+	bucketID := data.BucketID.ValueString()
+	accessKeyID := data.AccessKeyID.ValueString()
+
+	bucket, err := permissionChanges.apply(ctx, r.client, bucketID, accessKeyID, permissionChange{
+		Allow: client.Permissions{
 			Read:  data.Read.ValueBool(),
 			Write: data.Write.ValueBool(),
 			Owner: data.Owner.ValueBool(),
 		},
-	}
-
-	bucket, err := r.client.AllowBucketKey(ctx, allowReq)
+	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create bucket permission, got error: %s", err))
+		addClientError(&resp.Diagnostics, "create bucket permission", err)
 		return
 	}
 
+	// Nothing was granted, read the bucket to record the current permissions
+	if bucket == nil {
+		bucket = r.readBucket(ctx, bucketID, &resp.Diagnostics)
+		if bucket == nil {
+			return
+		}
+	}
+
 	// Set the ID
 	data.ID = types.StringValue(fmt.Sprintf("%s/%s", data.BucketID.ValueString(), data.AccessKeyID.ValueString()))
 
-	// Update state from bucket info to ensure consistency
-	r.updateStateFromBucket(&data, bucket)
+	// Record what Garage actually granted next to the configured flags
+	r.setEffectivePermissions(ctx, &data, bucket, &resp.Diagnostics)
 
 	tflog.Trace(ctx, "Created bucket permission resource")
 
@@ -164,13 +263,14 @@ func (r *BucketPermissionResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
-	bucketID := data.BucketID.ValueString()
-	bucket, err := r.client.GetBucketInfo(ctx, client.GetBucketInfoRequest{
-		ID: &bucketID,
-	})
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	// Permissions of the same bucket share one bucket lookup during a refresh
+	bucket, err := r.client.GetBucketInfoCached(ctx, data.BucketID.ValueString())
 
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read bucket, got error: %s", err))
+		addClientError(&resp.Diagnostics, "read bucket", err)
 		return
 	}
 
@@ -179,87 +279,50 @@ func (r *BucketPermissionResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
-	// Update state from bucket info
-	r.updateStateFromBucket(&data, bucket)
+	// Drift is recorded in the effective permissions only, next to the
+	// configured ones, and planned as an update
+	r.setEffectivePermissions(ctx, &data, bucket, &resp.Diagnostics)
+
+	// Imported permissions have no configured values yet
+	if data.Read.IsNull() && data.Write.IsNull() && data.Owner.IsNull() {
+		data.Read = data.EffectiveRead
+		data.Write = data.EffectiveWrite
+		data.Owner = data.EffectiveOwner
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *BucketPermissionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data BucketPermissionResourceModel
-	var state BucketPermissionResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
-	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	tflog.Debug(ctx, "Updating bucket permission", map[string]interface{}{
 		"bucket_id":     data.BucketID.ValueString(),
 		"access_key_id": data.AccessKeyID.ValueString(),
 	})
 
-	// Determine which permissions need to be granted or revoked
-	readChanged := data.Read.ValueBool() != state.Read.ValueBool()
-	writeChanged := data.Write.ValueBool() != state.Write.ValueBool()
-	ownerChanged := data.Owner.ValueBool() != state.Owner.ValueBool()
-
-	bucketID := data.BucketID.ValueString()
-	accessKeyID := data.AccessKeyID.ValueString()
-
-	var bucket *client.Bucket
-	var err error
-
-	// If any permission is being enabled, use AllowBucketKey
-	if (readChanged && data.Read.ValueBool()) ||
-		(writeChanged && data.Write.ValueBool()) ||
-		(ownerChanged && data.Owner.ValueBool()) {
-
-		allowReq := client.BucketKeyPermRequest{
-			BucketID:    bucketID,
-			AccessKeyID: accessKeyID,
-			Permissions: client.Permissions{
-				Read:  readChanged && data.Read.ValueBool(),
-				Write: writeChanged && data.Write.ValueBool(),
-				Owner: ownerChanged && data.Owner.ValueBool(),
-			},
-		}
-
-		bucket, err = r.client.AllowBucketKey(ctx, allowReq)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to grant bucket permissions, got error: %s", err))
-			return
-		}
-	}
-
-	// If any permission is being disabled, use DenyBucketKey
-	if (readChanged && !data.Read.ValueBool()) ||
-		(writeChanged && !data.Write.ValueBool()) ||
-		(ownerChanged && !data.Owner.ValueBool()) {
-
-		denyReq := client.BucketKeyPermRequest{
-			BucketID:    bucketID,
-			AccessKeyID: accessKeyID,
-			Permissions: client.Permissions{
-				Read:  readChanged && !data.Read.ValueBool(),
-				Write: writeChanged && !data.Write.ValueBool(),
-				Owner: ownerChanged && !data.Owner.ValueBool(),
-			},
-		}
-
-		bucket, err = r.client.DenyBucketKey(ctx, denyReq)
-		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke bucket permissions, got error: %s", err))
-			return
-		}
+	// Converge on the live permissions rather than on the state, so that
+	// grants and revocations made outside Terraform are corrected too
+	bucket := r.converge(ctx, data.BucketID.ValueString(), data.AccessKeyID.ValueString(), client.Permissions{
+		Read:  data.Read.ValueBool(),
+		Write: data.Write.ValueBool(),
+		Owner: data.Owner.ValueBool(),
+	}, &resp.Diagnostics)
+	if bucket == nil {
+		return
 	}
 
-	// Update state from bucket info to ensure consistency
-	if bucket != nil {
-		r.updateStateFromBucket(&data, bucket)
-	}
+	// Record what Garage actually granted next to the configured flags
+	r.setEffectivePermissions(ctx, &data, bucket, &resp.Diagnostics)
 
 	tflog.Trace(ctx, "Updated bucket permission resource")
 
@@ -275,25 +338,24 @@ func (r *BucketPermissionResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, defaultDeleteTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	tflog.Debug(ctx, "Deleting bucket permission", map[string]interface{}{
 		"bucket_id":     data.BucketID.ValueString(),
 		"access_key_id": data.AccessKeyID.ValueString(),
 	})
 
-	// Revoke all permissions
-	denyReq := client.BucketKeyPermRequest{
-		BucketID:    data.BucketID.ValueString(),
-		AccessKeyID: data.AccessKeyID.ValueString(),
-		Permissions: client.Permissions{
-			Read:  data.Read.ValueBool(),
-			Write: data.Write.ValueBool(),
-			Owner: data.Owner.ValueBool(),
+	// Revoke all permissions, including those granted outside Terraform
+	_, err := permissionChanges.apply(ctx, r.client, data.BucketID.ValueString(), data.AccessKeyID.ValueString(), permissionChange{
+		Deny: client.Permissions{
+			Read:  data.Read.ValueBool() || data.EffectiveRead.ValueBool(),
+			Write: data.Write.ValueBool() || data.EffectiveWrite.ValueBool(),
+			Owner: data.Owner.ValueBool() || data.EffectiveOwner.ValueBool(),
 		},
-	}
-
-	_, err := r.client.DenyBucketKey(ctx, denyReq)
+	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete bucket permission, got error: %s", err))
+		addClientError(&resp.Diagnostics, "delete bucket permission", err)
 		return
 	}
 
@@ -317,28 +379,163 @@ func (r *BucketPermissionResource) ImportState(ctx context.Context, req resource
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("access_key_id"), accessKeyID)...)
 }
 
-// updateStateFromBucket updates the resource state from bucket info.
-func (r *BucketPermissionResource) updateStateFromBucket(data *BucketPermissionResourceModel, bucket *client.Bucket) {
-	// Find the permissions for this access key in the bucket info
-	accessKeyID := data.AccessKeyID.ValueString()
-	found := false
+// allowBucketKeyWithRetry calls AllowBucketKey, retrying for a short while if
+// Garage does not know the access key yet. A key created on another node may
+// take a moment to replicate to the node serving this request.
+func allowBucketKeyWithRetry(ctx context.Context, c *client.Client, req client.BucketKeyPermRequest) (*client.Bucket, error) {
+	deadline := time.Now().Add(keyConvergenceTimeout)
+
+	for {
+		bucket, err := c.AllowBucketKey(ctx, req)
+		if err == nil || !isNoSuchAccessKeyError(err) || time.Now().After(deadline) {
+			return bucket, err
+		}
 
+		tflog.Debug(ctx, "Access key not visible yet, retrying", map[string]interface{}{
+			"bucket_id":     req.BucketID,
+			"access_key_id": req.AccessKeyID,
+		})
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(keyConvergenceInterval):
+		}
+	}
+}
+
+// isNoSuchAccessKeyError reports whether err is Garage's error for an unknown access key.
+func isNoSuchAccessKeyError(err error) bool {
+	var apiErr *client.APIError
+	return errors.As(err, &apiErr) && apiErr.Code == "NoSuchAccessKey"
+}
+
+// readBucket reads the bucket info, adding an error diagnostic and returning
+// nil if the bucket cannot be read.
+func (r *BucketPermissionResource) readBucket(ctx context.Context, bucketID string, diags *diag.Diagnostics) *client.Bucket {
+	bucket, err := r.client.GetBucketInfo(ctx, client.GetBucketInfoRequest{ID: &bucketID})
+	if err != nil {
+		addClientError(diags, "read bucket", err)
+		return nil
+	}
+	if bucket == nil {
+		diags.AddError("Bucket Not Found", fmt.Sprintf("The bucket %s could not be found.", bucketID))
+		return nil
+	}
+	return bucket
+}
+
+// validateReferences checks that the bucket and the access key exist when
+// their IDs are known at plan time, so that a wrong ID fails the plan rather
+// than the apply. Lookups failing for another reason, such as a token without
+// the GetBucketInfo or GetKeyInfo scope, are left for the apply to report.
+func (r *BucketPermissionResource) validateReferences(ctx context.Context, data BucketPermissionResourceModel, diags *diag.Diagnostics) {
+	// The client is not configured yet when provider settings are unknown
+	if r.client == nil {
+		return
+	}
+
+	if !data.BucketID.IsNull() && !data.BucketID.IsUnknown() {
+		bucketID := data.BucketID.ValueString()
+		bucket, err := r.client.GetBucketInfo(ctx, client.GetBucketInfoRequest{ID: &bucketID})
+		switch {
+		case isInvalidReferenceError(err):
+			diags.AddAttributeError(path.Root("bucket_id"), "Invalid Bucket ID", fmt.Sprintf("The bucket ID %s is invalid: %s", bucketID, err))
+		case err != nil:
+			tflog.Debug(ctx, "Unable to check that the bucket exists", map[string]interface{}{
+				"bucket_id": bucketID,
+				"error":     err.Error(),
+			})
+		case bucket == nil:
+			diags.AddAttributeError(path.Root("bucket_id"), "Bucket Not Found", fmt.Sprintf("The bucket %s does not exist.", bucketID))
+		}
+	}
+
+	if !data.AccessKeyID.IsNull() && !data.AccessKeyID.IsUnknown() {
+		accessKeyID := data.AccessKeyID.ValueString()
+		key, err := r.client.GetKeyInfo(ctx, client.GetKeyInfoRequest{ID: accessKeyID})
+		switch {
+		case isInvalidReferenceError(err):
+			diags.AddAttributeError(path.Root("access_key_id"), "Invalid Access Key ID", fmt.Sprintf("The access key ID %s is invalid: %s", accessKeyID, err))
+		case err != nil:
+			tflog.Debug(ctx, "Unable to check that the access key exists", map[string]interface{}{
+				"access_key_id": accessKeyID,
+				"error":         err.Error(),
+			})
+		case key == nil:
+			diags.AddAttributeError(path.Root("access_key_id"), "Access Key Not Found", fmt.Sprintf("The access key %s does not exist.", accessKeyID))
+		}
+	}
+}
+
+// isInvalidReferenceError reports whether Garage rejected a lookup because
+// the ID itself is malformed.
+func isInvalidReferenceError(err error) bool {
+	var apiErr *client.APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest
+}
+
+// converge grants and revokes whatever the permissions of the access key on
+// the live bucket differ from want by. It returns the bucket as left by the
+// change, or nil after adding an error diagnostic.
+func (r *BucketPermissionResource) converge(ctx context.Context, bucketID, accessKeyID string, want client.Permissions, diags *diag.Diagnostics) *client.Bucket {
+	bucket := r.readBucket(ctx, bucketID, diags)
+	if bucket == nil {
+		return nil
+	}
+
+	var have client.Permissions
 	for _, keyInfo := range bucket.Keys {
 		if keyInfo.AccessKeyID == accessKeyID {
-			data.Read = types.BoolValue(keyInfo.Permissions.Read)
-			data.Write = types.BoolValue(keyInfo.Permissions.Write)
-			data.Owner = types.BoolValue(keyInfo.Permissions.Owner)
-			found = true
+			have = keyInfo.Permissions
 			break
 		}
 	}
 
-	if !found {
-		// If the key is not in the bucket's key list, all permissions are false
-		data.Read = types.BoolValue(false)
-		data.Write = types.BoolValue(false)
-		data.Owner = types.BoolValue(false)
+	change := permissionsDiff(have, want)
+	if change == (permissionChange{}) {
+		return bucket
 	}
+
+	updated, err := permissionChanges.apply(ctx, r.client, bucketID, accessKeyID, change)
+	if err != nil {
+		addClientError(diags, "update bucket permissions", err)
+		return nil
+	}
+
+	// The change was cancelled out by another one to the same pair
+	if updated == nil {
+		return r.readBucket(ctx, bucketID, diags)
+	}
+
+	return updated
+}
+
+// setEffectivePermissions sets the computed permissions and local aliases of
+// the access key from bucket info.
+func (r *BucketPermissionResource) setEffectivePermissions(ctx context.Context, data *BucketPermissionResourceModel, bucket *client.Bucket, diags *diag.Diagnostics) {
+	// Find the permissions for this access key in the bucket info. If the key
+	// is not in the bucket's key list, all permissions are false.
+	var permissions client.Permissions
+	localAliases := []string{}
+
+	for _, keyInfo := range bucket.Keys {
+		if keyInfo.AccessKeyID == data.AccessKeyID.ValueString() {
+			permissions = keyInfo.Permissions
+			if keyInfo.BucketLocalAliases != nil {
+				localAliases = keyInfo.BucketLocalAliases
+			}
+			break
+		}
+	}
+
+	data.EffectiveRead = types.BoolValue(permissions.Read)
+	data.EffectiveWrite = types.BoolValue(permissions.Write)
+	data.EffectiveOwner = types.BoolValue(permissions.Owner)
+
+	aliases, d := types.ListValueFrom(ctx, types.StringType, localAliases)
+	diags.Append(d...)
+	data.LocalAliases = aliases
 }
 
 // parseImportID parses an import ID in the format "bucket_id/access_key_id".
@@ -4,10 +4,21 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"terraform-provider-garage/internal/client"
 )
 
 func TestAccBucketPermissionResource_basic(t *testing.T) {
@@ -25,6 +36,10 @@ func TestAccBucketPermissionResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("garage_bucket_permission.test", "read", "true"),
 					resource.TestCheckResourceAttr("garage_bucket_permission.test", "write", "false"),
 					resource.TestCheckResourceAttr("garage_bucket_permission.test", "owner", "false"),
+					resource.TestCheckResourceAttr("garage_bucket_permission.test", "effective_read", "true"),
+					resource.TestCheckResourceAttr("garage_bucket_permission.test", "effective_write", "false"),
+					resource.TestCheckResourceAttr("garage_bucket_permission.test", "effective_owner", "false"),
+					resource.TestCheckResourceAttr("garage_bucket_permission.test", "local_aliases.#", "0"),
 				),
 			},
 			// ImportState testing
@@ -40,6 +55,7 @@ func TestAccBucketPermissionResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("garage_bucket_permission.test", "read", "true"),
 					resource.TestCheckResourceAttr("garage_bucket_permission.test", "write", "true"),
 					resource.TestCheckResourceAttr("garage_bucket_permission.test", "owner", "false"),
+					resource.TestCheckResourceAttr("garage_bucket_permission.test", "effective_write", "true"),
 				),
 			},
 			// Delete testing automatically occurs in TestCase
@@ -47,6 +63,53 @@ func TestAccBucketPermissionResource_basic(t *testing.T) {
 	})
 }
 
+func TestAccBucketPermissionResource_drift(t *testing.T) {
+	var bucketID, accessKeyID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBucketPermissionResourceConfig_basic("test-perm-drift-bucket", "test-perm-drift-key", true, false, false),
+				Check: func(s *terraform.State) error {
+					permission := s.RootModule().Resources["garage_bucket_permission.test"].Primary
+					bucketID, accessKeyID = permission.Attributes["bucket_id"], permission.Attributes["access_key_id"]
+					return nil
+				},
+			},
+			// Grant write outside Terraform: only the effective permissions change
+			{
+				PreConfig: func() {
+					c := client.NewClient(os.Getenv("GARAGE_ENDPOINT"), os.Getenv("GARAGE_TOKEN"))
+					_, err := c.AllowBucketKey(context.Background(), client.BucketKeyPermRequest{
+						BucketID:    bucketID,
+						AccessKeyID: accessKeyID,
+						Permissions: client.Permissions{Write: true},
+					})
+					if err != nil {
+						t.Fatalf("Unable to grant write permission: %v", err)
+					}
+				},
+				RefreshState:       true,
+				ExpectNonEmptyPlan: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_bucket_permission.test", "write", "false"),
+					resource.TestCheckResourceAttr("garage_bucket_permission.test", "effective_write", "true"),
+				),
+			},
+			// Applying revokes it again
+			{
+				Config: testAccBucketPermissionResourceConfig_basic("test-perm-drift-bucket", "test-perm-drift-key", true, false, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_bucket_permission.test", "write", "false"),
+					resource.TestCheckResourceAttr("garage_bucket_permission.test", "effective_write", "false"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccBucketPermissionResource_allPermissions(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -177,3 +240,157 @@ resource "garage_bucket_permission" "test2" {
 }
 `, bucketName, key1Name, key2Name)
 }
+
+func TestBucketPermissionResource_converge(t *testing.T) {
+	tests := []struct {
+		name      string
+		live      []client.BucketKeyInfo
+		want      client.Permissions
+		wantAllow *client.Permissions
+		wantDeny  *client.Permissions
+	}{
+		{
+			name:      "owner granted out of band",
+			live:      []client.BucketKeyInfo{{AccessKeyID: "key-1", Permissions: client.Permissions{Read: true, Owner: true}}},
+			want:      client.Permissions{Read: true, Write: true},
+			wantAllow: &client.Permissions{Write: true},
+			wantDeny:  &client.Permissions{Owner: true},
+		},
+		{
+			name:      "revoked out of band",
+			live:      []client.BucketKeyInfo{{AccessKeyID: "key-2", Permissions: client.Permissions{Read: true}}},
+			want:      client.Permissions{Read: true, Write: true},
+			wantAllow: &client.Permissions{Read: true, Write: true},
+		},
+		{
+			name: "already converged",
+			live: []client.BucketKeyInfo{{AccessKeyID: "key-1", Permissions: client.Permissions{Read: true}}},
+			want: client.Permissions{Read: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var allowed, denied *client.Permissions
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				switch r.URL.Path {
+				case "/v2/GetBucketInfo":
+				case "/v2/AllowBucketKey", "/v2/DenyBucketKey":
+					var req client.BucketKeyPermRequest
+					_ = json.NewDecoder(r.Body).Decode(&req)
+					if r.URL.Path == "/v2/AllowBucketKey" {
+						allowed = &req.Permissions
+					} else {
+						denied = &req.Permissions
+					}
+				default:
+					t.Errorf("Unexpected request to %s", r.URL.Path)
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(client.Bucket{ID: "bucket-1", Keys: tt.live})
+			}))
+			defer server.Close()
+
+			r := &BucketPermissionResource{client: client.NewClient(server.URL, "test-token")}
+
+			var diags diag.Diagnostics
+			if bucket := r.converge(context.Background(), "bucket-1", "key-1", tt.want, &diags); bucket == nil {
+				t.Fatalf("Expected the bucket, got diagnostics %v", diags)
+			}
+
+			if (allowed == nil) != (tt.wantAllow == nil) || (allowed != nil && *allowed != *tt.wantAllow) {
+				t.Errorf("Expected allowed permissions %+v, got %+v", tt.wantAllow, allowed)
+			}
+
+			if (denied == nil) != (tt.wantDeny == nil) || (denied != nil && *denied != *tt.wantDeny) {
+				t.Errorf("Expected denied permissions %+v, got %+v", tt.wantDeny, denied)
+			}
+		})
+	}
+}
+
+func TestBucketPermissionResource_validateReferences(t *testing.T) {
+	tests := []struct {
+		name       string
+		bucket     int
+		key        int
+		bucketID   types.String
+		wantErrors []string
+	}{
+		{
+			name:     "both exist",
+			bucket:   http.StatusOK,
+			key:      http.StatusOK,
+			bucketID: types.StringValue("bucket-1"),
+		},
+		{
+			name:       "bucket and key missing",
+			bucket:     http.StatusNotFound,
+			key:        http.StatusNotFound,
+			bucketID:   types.StringValue("bucket-1"),
+			wantErrors: []string{"Bucket Not Found", "Access Key Not Found"},
+		},
+		{
+			name:       "malformed bucket ID",
+			bucket:     http.StatusBadRequest,
+			key:        http.StatusOK,
+			bucketID:   types.StringValue("bucket-1"),
+			wantErrors: []string{"Invalid Bucket ID"},
+		},
+		{
+			name:     "lookup not allowed",
+			bucket:   http.StatusForbidden,
+			key:      http.StatusForbidden,
+			bucketID: types.StringValue("bucket-1"),
+		},
+		{
+			name:       "bucket not known yet",
+			key:        http.StatusNotFound,
+			bucketID:   types.StringUnknown(),
+			wantErrors: []string{"Access Key Not Found"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				status := tt.key
+				if r.URL.Path == "/v2/GetBucketInfo" {
+					status = tt.bucket
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(status)
+				if status == http.StatusOK {
+					_, _ = w.Write([]byte(`{"id": "bucket-1", "accessKeyId": "key-1"}`))
+				} else {
+					_, _ = w.Write([]byte(`{"code": "Error", "message": "error"}`))
+				}
+			}))
+			defer server.Close()
+
+			r := &BucketPermissionResource{client: client.NewClient(server.URL, "test-token")}
+
+			var diags diag.Diagnostics
+			r.validateReferences(context.Background(), BucketPermissionResourceModel{
+				BucketID:    tt.bucketID,
+				AccessKeyID: types.StringValue("key-1"),
+			}, &diags)
+
+			var got []string
+			for _, d := range diags.Errors() {
+				got = append(got, d.Summary())
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.wantErrors) {
+				t.Errorf("Expected errors %v, got %v", tt.wantErrors, got)
+			}
+		})
+	}
+}
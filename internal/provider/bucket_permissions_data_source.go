@@ -0,0 +1,175 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BucketPermissionsDataSource{}
+
+func NewBucketPermissionsDataSource() datasource.DataSource {
+	return &BucketPermissionsDataSource{}
+}
+
+// BucketPermissionsDataSource defines the data source implementation.
+type BucketPermissionsDataSource struct {
+	client *client.Client
+}
+
+// BucketPermissionsDataSourceModel describes the data source data model.
+type BucketPermissionsDataSourceModel struct {
+	BucketID    types.String                   `tfsdk:"bucket_id"`
+	GlobalAlias types.String                   `tfsdk:"global_alias"`
+	Permissions map[string]KeyPermissionsModel `tfsdk:"permissions"`
+}
+
+// KeyPermissionsModel describes the permissions of an access key on the bucket.
+type KeyPermissionsModel struct {
+	Name  types.String `tfsdk:"name"`
+	Read  types.Bool   `tfsdk:"read"`
+	Write types.Bool   `tfsdk:"write"`
+	Owner types.Bool   `tfsdk:"owner"`
+}
+
+func (d *BucketPermissionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bucket_permissions"
+}
+
+func (d *BucketPermissionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Maps the access keys granted permissions on a bucket to their permissions, " +
+			"for use as a `for_each` source when cleaning up stray grants or reporting drift.",
+
+		Attributes: map[string]schema.Attribute{
+			"bucket_id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The ID of the bucket. Exactly one of bucket_id or global_alias must be specified.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("global_alias")),
+				},
+			},
+			"global_alias": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "A global alias (name) of the bucket. Exactly one of bucket_id or global_alias must be specified.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"permissions": schema.MapNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The permissions on the bucket, keyed by access key ID.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the key.",
+						},
+						"read": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the key can read from the bucket.",
+						},
+						"write": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the key can write to the bucket.",
+						},
+						"owner": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the key owns the bucket.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *BucketPermissionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *BucketPermissionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data BucketPermissionsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading bucket permissions", map[string]interface{}{
+		"bucket_id":    data.BucketID.ValueString(),
+		"global_alias": data.GlobalAlias.ValueString(),
+	})
+
+	// The schema ensures exactly one of them is set
+	bucket, err := d.client.GetBucketInfo(ctx, client.GetBucketInfoRequest{
+		ID:          data.BucketID.ValueStringPointer(),
+		GlobalAlias: data.GlobalAlias.ValueStringPointer(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read bucket", err)
+		return
+	}
+
+	if bucket == nil {
+		resp.Diagnostics.AddError(
+			"Bucket Not Found",
+			"The specified bucket could not be found.",
+		)
+		return
+	}
+
+	data.BucketID = types.StringValue(bucket.ID)
+	data.Permissions = keyPermissionsModels(bucket.Keys)
+
+	tflog.Trace(ctx, "Read bucket permissions data source", map[string]interface{}{
+		"keys": len(data.Permissions),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// keyPermissionsModels keys the permissions granted on a bucket by access key ID.
+func keyPermissionsModels(keys []client.BucketKeyInfo) map[string]KeyPermissionsModel {
+	models := make(map[string]KeyPermissionsModel, len(keys))
+	for _, key := range keys {
+		models[key.AccessKeyID] = KeyPermissionsModel{
+			Name:  types.StringValue(key.Name),
+			Read:  types.BoolValue(key.Permissions.Read),
+			Write: types.BoolValue(key.Permissions.Write),
+			Owner: types.BoolValue(key.Permissions.Owner),
+		}
+	}
+	return models
+}
@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBucketPermissionsDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBucketPermissionsDataSourceConfig("test-bucket-permissions-ds"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.garage_bucket_permissions.test", "bucket_id", "garage_bucket.test", "id"),
+					resource.TestCheckResourceAttr("data.garage_bucket_permissions.test", "permissions.%", "1"),
+					resource.TestCheckResourceAttr("data.garage_bucket_permissions.by_id", "permissions.%", "1"),
+					resource.TestCheckOutput("reader_read", "true"),
+					resource.TestCheckOutput("reader_owner", "false"),
+				),
+			},
+			{
+				Config:      testAccBucketPermissionsDataSourceConfig_missing,
+				ExpectError: regexp.MustCompile("Bucket Not Found"),
+			},
+		},
+	})
+}
+
+func testAccBucketPermissionsDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "garage_bucket" "test" {
+  global_alias = %[1]q
+}
+
+resource "garage_key" "test" {
+  name = "%[1]s-key"
+}
+
+resource "garage_bucket_permission" "test" {
+  bucket_id     = garage_bucket.test.id
+  access_key_id = garage_key.test.id
+  read          = true
+}
+
+data "garage_bucket_permissions" "test" {
+  global_alias = garage_bucket.test.global_alias
+  depends_on   = [garage_bucket_permission.test]
+}
+
+data "garage_bucket_permissions" "by_id" {
+  bucket_id  = garage_bucket.test.id
+  depends_on = [garage_bucket_permission.test]
+}
+
+output "reader_read" {
+  value = tostring(data.garage_bucket_permissions.test.permissions[garage_key.test.id].read)
+}
+
+output "reader_owner" {
+  value = tostring(data.garage_bucket_permissions.test.permissions[garage_key.test.id].owner)
+}
+`, name)
+}
+
+const testAccBucketPermissionsDataSourceConfig_missing = `
+data "garage_bucket_permissions" "missing" {
+  global_alias = "test-bucket-permissions-ds-missing"
+}
+`
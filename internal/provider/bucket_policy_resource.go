@@ -0,0 +1,409 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// policyAccessLevels are the permissions granted by each access level of a
+// bucket policy statement.
+var policyAccessLevels = map[string]client.Permissions{
+	"ro":    {Read: true},
+	"rw":    {Read: true, Write: true},
+	"owner": {Read: true, Write: true, Owner: true},
+}
+
+// policyCustomAccess is the access level read back for a key whose
+// permissions match none of policyAccessLevels.
+const policyCustomAccess = "custom"
+
+// bucketPolicyStatementAttributeTypes are the attributes of a statement.
+var bucketPolicyStatementAttributeTypes = map[string]attr.Type{
+	"key":    types.StringType,
+	"access": types.StringType,
+}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BucketPolicyResource{}
+var _ resource.ResourceWithImportState = &BucketPolicyResource{}
+var _ resource.ResourceWithModifyPlan = &BucketPolicyResource{}
+
+func NewBucketPolicyResource() resource.Resource {
+	return &BucketPolicyResource{}
+}
+
+// BucketPolicyResource defines the resource implementation.
+type BucketPolicyResource struct {
+	client *client.Client
+}
+
+// BucketPolicyResourceModel describes the resource data model.
+type BucketPolicyResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	BucketID   types.String `tfsdk:"bucket_id"`
+	Statements types.Set    `tfsdk:"statement"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// BucketPolicyStatementModel describes the access of one key to the bucket.
+type BucketPolicyStatementModel struct {
+	Key    types.String `tfsdk:"key"`
+	Access types.String `tfsdk:"access"`
+}
+
+func (r *BucketPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bucket_policy"
+}
+
+func (r *BucketPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages all the permissions on a bucket authoritatively: the keys listed in `statement` get exactly the access they are given, " +
+			"and every other grant on the bucket is revoked, including grants made outside Terraform. `garage_bucket_permission` only manages its own key; " +
+			"do not use both on the same bucket, or they will revoke each other's grants. Destroying the resource revokes the permissions of the listed keys.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the policy (same as `bucket_id`).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"bucket_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the bucket.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"statement": schema.SetNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "The keys allowed on the bucket, at most one statement per key. An empty set revokes every grant on the bucket.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The ID of the access key.",
+						},
+						"access": schema.StringAttribute{
+							Required: true,
+							MarkdownDescription: "The access of the key: `ro` (read), `rw` (read and write) or `owner` (read, write and owner). " +
+								"Keys whose permissions on the bucket match none of them are read back as `custom`.",
+							Validators: []validator.String{
+								stringvalidator.OneOf("ro", "rw", "owner"),
+							},
+						},
+					},
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *BucketPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BucketPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !req.Plan.Raw.IsNull() {
+		var data BucketPolicyResourceModel
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+		if !data.Statements.IsUnknown() {
+			var statements []BucketPolicyStatementModel
+			resp.Diagnostics.Append(data.Statements.ElementsAs(ctx, &statements, false)...)
+
+			seen := map[string]bool{}
+			for _, statement := range statements {
+				if statement.Key.IsUnknown() {
+					continue
+				}
+				key := statement.Key.ValueString()
+				if seen[key] {
+					resp.Diagnostics.AddAttributeError(path.Root("statement"), "Duplicate Policy Statement", fmt.Sprintf("The key %s appears in several statements; give each key a single access level.", key))
+				}
+				seen[key] = true
+			}
+		}
+	}
+
+	validateTokenScope(ctx, r.client, resourceScopes{
+		Create: []string{"AllowBucketKey", "DenyBucketKey"},
+		Update: []string{"AllowBucketKey", "DenyBucketKey"},
+		Delete: []string{"DenyBucketKey"},
+	}, req, resp)
+}
+
+func (r *BucketPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BucketPolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	r.apply(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Created bucket policy resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BucketPolicyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	bucket, err := r.client.GetBucketInfoCached(ctx, data.BucketID.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read bucket", err)
+		return
+	}
+
+	if bucket == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Record every grant on the bucket, so that stray grants show up in the plan
+	statements, diags := bucketPolicyStatementsValue(ctx, bucket.Keys)
+	resp.Diagnostics.Append(diags...)
+	data.ID = types.StringValue(bucket.ID)
+	data.Statements = statements
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data BucketPolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	r.apply(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Updated bucket policy resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BucketPolicyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, defaultDeleteTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	var statements []BucketPolicyStatementModel
+	resp.Diagnostics.Append(data.Statements.ElementsAs(ctx, &statements, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Revoking bucket policy", map[string]interface{}{
+		"bucket_id": data.BucketID.ValueString(),
+		"keys":      len(statements),
+	})
+
+	for _, statement := range statements {
+		_, err := r.client.DenyBucketKey(ctx, client.BucketKeyPermRequest{
+			BucketID:    data.BucketID.ValueString(),
+			AccessKeyID: statement.Key.ValueString(),
+			Permissions: client.Permissions{Read: true, Write: true, Owner: true},
+		})
+		if err != nil {
+			// The bucket may have been deleted along with its permissions
+			if isNoSuchBucketError(err) {
+				return
+			}
+			addClientError(&resp.Diagnostics, "revoke bucket policy", err)
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "Deleted bucket policy resource")
+}
+
+func (r *BucketPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket_id"), req.ID)...)
+}
+
+// apply reconciles the permissions on the bucket with the statements of the
+// model. Every missing permission is granted before any other is revoked, so
+// that a key moving between access levels never loses access in between.
+func (r *BucketPolicyResource) apply(ctx context.Context, data *BucketPolicyResourceModel, diags *diag.Diagnostics) {
+	bucketID := data.BucketID.ValueString()
+
+	var statements []BucketPolicyStatementModel
+	diags.Append(data.Statements.ElementsAs(ctx, &statements, false)...)
+	if diags.HasError() {
+		return
+	}
+
+	desired := make(map[string]client.Permissions, len(statements))
+	for _, statement := range statements {
+		desired[statement.Key.ValueString()] = policyAccessLevels[statement.Access.ValueString()]
+	}
+
+	bucket, err := r.client.GetBucketInfo(ctx, client.GetBucketInfoRequest{ID: &bucketID})
+	if err != nil {
+		addClientError(diags, "read bucket", err)
+		return
+	}
+	if bucket == nil {
+		diags.AddError("Bucket Not Found", fmt.Sprintf("The bucket %s could not be found.", bucketID))
+		return
+	}
+
+	changes := policyChanges(bucket.Keys, desired)
+
+	accessKeyIDs := make([]string, 0, len(changes))
+	for accessKeyID := range changes {
+		accessKeyIDs = append(accessKeyIDs, accessKeyID)
+	}
+	sort.Strings(accessKeyIDs)
+
+	tflog.Debug(ctx, "Applying bucket policy", map[string]interface{}{
+		"bucket_id": bucketID,
+		"keys":      accessKeyIDs,
+	})
+
+	for _, accessKeyID := range accessKeyIDs {
+		if _, err := sendPermissionChange(ctx, r.client, bucketID, accessKeyID, permissionChange{Allow: changes[accessKeyID].Allow}); err != nil {
+			addClientError(diags, fmt.Sprintf("grant bucket permissions to key %s", accessKeyID), err)
+			return
+		}
+	}
+
+	for _, accessKeyID := range accessKeyIDs {
+		if _, err := sendPermissionChange(ctx, r.client, bucketID, accessKeyID, permissionChange{Deny: changes[accessKeyID].Deny}); err != nil {
+			addClientError(diags, fmt.Sprintf("revoke bucket permissions of key %s", accessKeyID), err)
+			return
+		}
+	}
+
+	data.ID = types.StringValue(bucketID)
+}
+
+// policyChanges computes, for each key whose permissions on the bucket differ
+// from the desired ones, the permissions to grant and to revoke. Keys missing
+// from desired lose all their permissions.
+func policyChanges(current []client.BucketKeyInfo, desired map[string]client.Permissions) map[string]permissionChange {
+	actual := make(map[string]client.Permissions, len(current))
+	for _, key := range current {
+		actual[key.AccessKeyID] = key.Permissions
+	}
+
+	changes := map[string]permissionChange{}
+	diff := func(accessKeyID string) {
+		if change := permissionsDiff(actual[accessKeyID], desired[accessKeyID]); change != (permissionChange{}) {
+			changes[accessKeyID] = change
+		}
+	}
+
+	for accessKeyID := range actual {
+		diff(accessKeyID)
+	}
+	for accessKeyID := range desired {
+		diff(accessKeyID)
+	}
+
+	return changes
+}
+
+// policyAccessLevel returns the access level granting exactly permissions, or
+// policyCustomAccess if there is none.
+func policyAccessLevel(permissions client.Permissions) string {
+	for access, granted := range policyAccessLevels {
+		if granted == permissions {
+			return access
+		}
+	}
+	return policyCustomAccess
+}
+
+// bucketPolicyStatementsValue converts the keys granted permissions on a
+// bucket to the value of the statement attribute.
+func bucketPolicyStatementsValue(ctx context.Context, keys []client.BucketKeyInfo) (types.Set, diag.Diagnostics) {
+	statements := make([]BucketPolicyStatementModel, 0, len(keys))
+	for _, key := range keys {
+		// Keys whose permissions were all revoked stay listed by Garage
+		if key.Permissions == (client.Permissions{}) {
+			continue
+		}
+		statements = append(statements, BucketPolicyStatementModel{
+			Key:    types.StringValue(key.AccessKeyID),
+			Access: types.StringValue(policyAccessLevel(key.Permissions)),
+		})
+	}
+
+	return types.SetValueFrom(ctx, types.ObjectType{AttrTypes: bucketPolicyStatementAttributeTypes}, statements)
+}
@@ -0,0 +1,188 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-garage/internal/client"
+)
+
+func TestAccBucketPolicyResource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A grant made outside the policy
+			{
+				Config: testAccBucketPolicyResourceConfig_strayGrant("test-bucket-policy"),
+			},
+			// Only the reader gets access, the stray grant is revoked
+			{
+				Config: testAccBucketPolicyResourceConfig("test-bucket-policy", "ro"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("garage_bucket_policy.test", "id", "garage_bucket.test", "id"),
+					resource.TestCheckResourceAttr("garage_bucket_policy.test", "statement.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("garage_bucket_policy.test", "statement.*", map[string]string{
+						"access": "ro",
+					}),
+					resource.TestCheckResourceAttr("data.garage_bucket_permissions.test", "permissions.%", "1"),
+				),
+			},
+			// Moving the reader to read-write grants write in place
+			{
+				Config: testAccBucketPolicyResourceConfig("test-bucket-policy", "rw"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs("garage_bucket_policy.test", "statement.*", map[string]string{
+						"access": "rw",
+					}),
+				),
+			},
+			{
+				ResourceName:      "garage_bucket_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:      testAccBucketPolicyResourceConfig_duplicate,
+				ExpectError: regexp.MustCompile("Duplicate Policy Statement"),
+			},
+		},
+	})
+}
+
+func testAccBucketPolicyResourceConfig(name, access string) string {
+	return fmt.Sprintf(`
+resource "garage_bucket" "test" {
+  global_alias = %[1]q
+}
+
+resource "garage_key" "reader" {
+  name = "%[1]s-reader"
+}
+
+resource "garage_key" "stray" {
+  name = "%[1]s-stray"
+}
+
+# Forget the stray grant without revoking it, so that the policy has to
+removed {
+  from = garage_bucket_permission.stray
+
+  lifecycle {
+    destroy = false
+  }
+}
+
+resource "garage_bucket_policy" "test" {
+  bucket_id = garage_bucket.test.id
+
+  statement = [
+    {
+      key    = garage_key.reader.id
+      access = %[2]q
+    },
+  ]
+}
+
+data "garage_bucket_permissions" "test" {
+  bucket_id  = garage_bucket.test.id
+  depends_on = [garage_bucket_policy.test]
+}
+`, name, access)
+}
+
+func testAccBucketPolicyResourceConfig_strayGrant(name string) string {
+	return fmt.Sprintf(`
+resource "garage_bucket" "test" {
+  global_alias = %[1]q
+}
+
+resource "garage_key" "reader" {
+  name = "%[1]s-reader"
+}
+
+resource "garage_key" "stray" {
+  name = "%[1]s-stray"
+}
+
+resource "garage_bucket_permission" "stray" {
+  bucket_id     = garage_bucket.test.id
+  access_key_id = garage_key.stray.id
+  read          = true
+}
+`, name)
+}
+
+const testAccBucketPolicyResourceConfig_duplicate = `
+resource "garage_bucket_policy" "test" {
+  bucket_id = "0123456789abcdef"
+
+  statement = [
+    {
+      key    = "GK31c2f218a2e44f485b94239e"
+      access = "ro"
+    },
+    {
+      key    = "GK31c2f218a2e44f485b94239e"
+      access = "rw"
+    },
+  ]
+}
+`
+
+func TestPolicyChanges(t *testing.T) {
+	current := []client.BucketKeyInfo{
+		{AccessKeyID: "GKreader", Permissions: client.Permissions{Read: true}},
+		{AccessKeyID: "GKstray", Permissions: client.Permissions{Read: true, Owner: true}},
+		{AccessKeyID: "GKowner", Permissions: client.Permissions{Read: true, Write: true, Owner: true}},
+		{AccessKeyID: "GKrevoked"},
+	}
+	desired := map[string]client.Permissions{
+		"GKreader": policyAccessLevels["rw"],
+		"GKowner":  policyAccessLevels["owner"],
+		"GKnew":    policyAccessLevels["ro"],
+	}
+
+	changes := policyChanges(current, desired)
+
+	want := map[string]permissionChange{
+		"GKreader": {Allow: client.Permissions{Write: true}},
+		"GKstray":  {Deny: client.Permissions{Read: true, Owner: true}},
+		"GKnew":    {Allow: client.Permissions{Read: true}},
+	}
+
+	if len(changes) != len(want) {
+		t.Fatalf("Expected changes for %d keys, got %+v", len(want), changes)
+	}
+
+	for accessKeyID, change := range want {
+		if changes[accessKeyID] != change {
+			t.Errorf("Expected %+v for %s, got %+v", change, accessKeyID, changes[accessKeyID])
+		}
+	}
+}
+
+func TestPolicyAccessLevel(t *testing.T) {
+	tests := []struct {
+		permissions client.Permissions
+		want        string
+	}{
+		{client.Permissions{Read: true}, "ro"},
+		{client.Permissions{Read: true, Write: true}, "rw"},
+		{client.Permissions{Read: true, Write: true, Owner: true}, "owner"},
+		{client.Permissions{Write: true}, policyCustomAccess},
+		{client.Permissions{Read: true, Owner: true}, policyCustomAccess},
+	}
+
+	for _, tt := range tests {
+		if got := policyAccessLevel(tt.permissions); got != tt.want {
+			t.Errorf("policyAccessLevel(%+v) = %s, want %s", tt.permissions, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BucketPrefixPurgeResource{}
+var _ resource.ResourceWithModifyPlan = &BucketPrefixPurgeResource{}
+
+func NewBucketPrefixPurgeResource() resource.Resource {
+	return &BucketPrefixPurgeResource{}
+}
+
+// BucketPrefixPurgeResource defines the resource implementation.
+type BucketPrefixPurgeResource struct {
+	client *client.Client
+}
+
+// BucketPrefixPurgeResourceModel describes the resource data model.
+type BucketPrefixPurgeResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	BucketID    types.String `tfsdk:"bucket_id"`
+	Prefix      types.String `tfsdk:"prefix"`
+	DryRun      types.Bool   `tfsdk:"dry_run"`
+	ObjectCount types.Int64  `tfsdk:"object_count"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *BucketPrefixPurgeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bucket_prefix_purge"
+}
+
+func (r *BucketPrefixPurgeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Deletes all objects under a prefix of a bucket when the resource is destroyed, for instance to clean up the objects of an environment " +
+			"without destroying the shared bucket. Objects are only deleted when `dry_run` is `false`. Requires the provider `s3_endpoint` to be set.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the purge (format: bucket_id/prefix).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"bucket_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the bucket.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"prefix": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The key prefix of the objects to delete (e.g., `staging/`). It cannot be empty, so the whole bucket is never purged by accident.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"dry_run": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "When `true`, destroying the resource only reports how many objects would be deleted. Set to `false` to actually delete them. Defaults to `true`.",
+			},
+			"object_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of objects under the prefix when the resource was last applied.",
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *BucketPrefixPurgeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BucketPrefixPurgeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Objects are listed and deleted through a temporary access key
+	scopes := []string{"GetBucketInfo", "CreateKey", "AllowBucketKey", "DeleteKey"}
+
+	validateTokenScope(ctx, r.client, resourceScopes{
+		Create: scopes,
+		Update: scopes,
+		Delete: scopes,
+	}, req, resp)
+}
+
+func (r *BucketPrefixPurgeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BucketPrefixPurgeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	keys, err := r.listObjects(ctx, data)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "list objects", err)
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf("%s/%s", data.BucketID.ValueString(), data.Prefix.ValueString()))
+	data.ObjectCount = types.Int64Value(int64(len(keys)))
+
+	tflog.Trace(ctx, "Created bucket prefix purge resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketPrefixPurgeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BucketPrefixPurgeResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	bucketID := data.BucketID.ValueString()
+	bucket, err := r.client.GetBucketInfo(ctx, client.GetBucketInfoRequest{ID: &bucketID})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read bucket", err)
+		return
+	}
+
+	// Nothing is left to purge once the bucket is gone
+	if bucket == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketPrefixPurgeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data BucketPrefixPurgeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	keys, err := r.listObjects(ctx, data)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "list objects", err)
+		return
+	}
+
+	data.ObjectCount = types.Int64Value(int64(len(keys)))
+
+	tflog.Trace(ctx, "Updated bucket prefix purge resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketPrefixPurgeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BucketPrefixPurgeResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, defaultDeleteTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	bucketID := data.BucketID.ValueString()
+	prefix := data.Prefix.ValueString()
+
+	if data.DryRun.ValueBool() {
+		keys, err := r.listObjects(ctx, data)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "list objects", err)
+			return
+		}
+
+		resp.Diagnostics.AddWarning(
+			"Objects Not Purged",
+			fmt.Sprintf("dry_run is set, so the %d objects under %q in bucket %s were left in place. Set dry_run = false to delete them.", len(keys), prefix, bucketID),
+		)
+		return
+	}
+
+	bucketName, err := bucketS3Name(ctx, r.client, bucketID)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read bucket", err)
+		return
+	}
+
+	err = withTemporaryBucketKey(ctx, r.client, bucketID, client.Permissions{Read: true, Write: true}, func(s3 *client.S3Client) error {
+		keys, err := listObjectKeys(ctx, s3, bucketName, prefix)
+		if err != nil {
+			return err
+		}
+
+		for start := 0; start < len(keys); start += client.MaxDeleteObjects {
+			end := min(start+client.MaxDeleteObjects, len(keys))
+			if err := s3.DeleteObjects(ctx, bucketName, keys[start:end]); err != nil {
+				return err
+			}
+
+			tflog.Info(ctx, "Purging objects", map[string]interface{}{
+				"bucket_id": bucketID,
+				"prefix":    prefix,
+				"deleted":   end,
+				"total":     len(keys),
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "purge objects", err)
+		return
+	}
+
+	tflog.Trace(ctx, "Deleted bucket prefix purge resource")
+}
+
+// listObjects returns the keys of the objects under the prefix.
+func (r *BucketPrefixPurgeResource) listObjects(ctx context.Context, data BucketPrefixPurgeResourceModel) ([]string, error) {
+	bucketID := data.BucketID.ValueString()
+
+	bucketName, err := bucketS3Name(ctx, r.client, bucketID)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	err = withTemporaryBucketKey(ctx, r.client, bucketID, client.Permissions{Read: true}, func(s3 *client.S3Client) error {
+		keys, err = listObjectKeys(ctx, s3, bucketName, data.Prefix.ValueString())
+		return err
+	})
+
+	return keys, err
+}
+
+// listObjectKeys returns the keys of all objects whose key starts with prefix.
+func listObjectKeys(ctx context.Context, s3 *client.S3Client, bucket, prefix string) ([]string, error) {
+	keys := []string{}
+	err := s3.EachObject(ctx, bucket, prefix, func(object client.ListedObject) error {
+		keys = append(keys, object.Key)
+		return nil
+	})
+	return keys, err
+}
@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBucketPrefixPurgeResource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckS3(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Count the seeded index document without deleting it
+			{
+				Config: testAccBucketPrefixPurgeResourceConfig("test-bucket-prefix-purge", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_bucket_prefix_purge.test", "prefix", "index"),
+					resource.TestCheckResourceAttr("garage_bucket_prefix_purge.test", "dry_run", "true"),
+					resource.TestCheckResourceAttr("garage_bucket_prefix_purge.test", "object_count", "1"),
+				),
+			},
+			// Arm the purge so that destroying empties the bucket before it is deleted
+			{
+				Config: testAccBucketPrefixPurgeResourceConfig("test-bucket-prefix-purge", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_bucket_prefix_purge.test", "dry_run", "false"),
+					resource.TestCheckResourceAttr("garage_bucket_prefix_purge.test", "object_count", "1"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccBucketPrefixPurgeResourceConfig(alias string, dryRun bool) string {
+	return fmt.Sprintf(`
+resource "garage_bucket" "test" {
+  global_alias           = %[1]q
+  website_enabled        = true
+  website_seed_documents = true
+}
+
+resource "garage_bucket_prefix_purge" "test" {
+  bucket_id = garage_bucket.test.id
+  prefix    = "index"
+  dry_run   = %[2]t
+}
+`, alias, dryRun)
+}
@@ -5,23 +5,70 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
-
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"terraform-provider-garage/internal/client"
 )
 
+// bucketConvergenceTimeout bounds how long Create waits for a new bucket to
+// become visible through GetBucketInfo, and bucketConvergenceInterval is the
+// delay between polls.
+var (
+	bucketConvergenceTimeout  = 30 * time.Second
+	bucketConvergenceInterval = 500 * time.Millisecond
+)
+
+// Placeholder documents uploaded by website_seed_documents.
+const (
+	placeholderIndexDocument = "<!DOCTYPE html>\n<html>\n<head><title>Coming soon</title></head>\n<body><p>This site has not been published yet.</p></body>\n</html>\n"
+	placeholderErrorDocument = "<!DOCTYPE html>\n<html>\n<head><title>Not found</title></head>\n<body><p>The requested page could not be found.</p></body>\n</html>\n"
+)
+
+// bucketKeyAttributeTypes are the attributes of the keys granted on a bucket.
+var bucketKeyAttributeTypes = map[string]attr.Type{
+	"access_key_id": types.StringType,
+	"name":          types.StringType,
+	"read":          types.BoolType,
+	"write":         types.BoolType,
+	"owner":         types.BoolType,
+}
+
+// bucketLocalAliasAttributeTypes are the attributes of the local alias a
+// bucket is created with.
+var bucketLocalAliasAttributeTypes = map[string]attr.Type{
+	"access_key_id":   types.StringType,
+	"alias":           types.StringType,
+	"all_permissions": types.BoolType,
+}
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &BucketResource{}
 var _ resource.ResourceWithImportState = &BucketResource{}
+var _ resource.ResourceWithModifyPlan = &BucketResource{}
 
 func NewBucketResource() resource.Resource {
 	return &BucketResource{}
@@ -34,13 +81,41 @@ type BucketResource struct {
 
 // BucketResourceModel describes the resource data model.
 type BucketResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	GlobalAlias    types.String `tfsdk:"global_alias"`
-	WebsiteEnabled types.Bool   `tfsdk:"website_enabled"`
-	WebsiteIndex   types.String `tfsdk:"website_index_document"`
-	WebsiteError   types.String `tfsdk:"website_error_document"`
-	MaxSize        types.Int64  `tfsdk:"max_size"`
-	MaxObjects     types.Int64  `tfsdk:"max_objects"`
+	ID              types.String `tfsdk:"id"`
+	GlobalAlias     types.String `tfsdk:"global_alias"`
+	GlobalAliases   types.Set    `tfsdk:"global_aliases"`
+	FullGlobalAlias types.String `tfsdk:"full_global_alias"`
+	LocalAlias      types.Object `tfsdk:"local_alias"`
+	WebsiteEnabled  types.Bool   `tfsdk:"website_enabled"`
+	WebsiteIndex    types.String `tfsdk:"website_index_document"`
+	WebsiteError    types.String `tfsdk:"website_error_document"`
+	WebsiteSeed     types.Bool   `tfsdk:"website_seed_documents"`
+	MaxSize         types.Int64  `tfsdk:"max_size"`
+	MaxObjects      types.Int64  `tfsdk:"max_objects"`
+	SkipDestroy     types.Bool   `tfsdk:"skip_destroy"`
+	ForceDestroy    types.Bool   `tfsdk:"force_destroy"`
+	Keys            types.List   `tfsdk:"keys"`
+
+	CleanupUploadsOlderThan types.String `tfsdk:"cleanup_incomplete_uploads_older_than"`
+	UnfinishedUploads       types.Int64  `tfsdk:"unfinished_uploads"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// BucketLocalAliasModel describes the alias of a bucket local to an access key.
+type BucketLocalAliasModel struct {
+	AccessKeyID    types.String `tfsdk:"access_key_id"`
+	Alias          types.String `tfsdk:"alias"`
+	AllPermissions types.Bool   `tfsdk:"all_permissions"`
+}
+
+// BucketKeyModel describes a key granted permissions on a bucket.
+type BucketKeyModel struct {
+	AccessKeyID types.String `tfsdk:"access_key_id"`
+	Name        types.String `tfsdk:"name"`
+	Read        types.Bool   `tfsdk:"read"`
+	Write       types.Bool   `tfsdk:"write"`
+	Owner       types.Bool   `tfsdk:"owner"`
 }
 
 func (r *BucketResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -60,12 +135,58 @@ func (r *BucketResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 			"global_alias": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "The global alias (name) for the bucket.",
+				Optional:            true,
+				MarkdownDescription: "The global alias (name) for the bucket. Exactly one of `global_alias` and `local_alias` must be set.",
+				Validators: []validator.String{
+					bucketAlias(),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("local_alias")),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
+			"global_aliases": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "All the global aliases of the bucket, including `global_alias`. When set, aliases added outside Terraform are removed and missing ones are added back. Leave unset to leave the aliases of the bucket unmanaged.",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(bucketAlias()),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"full_global_alias": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The global alias of the bucket in Garage, that is `global_alias` after the provider's `bucket_alias_prefix`, as used to address the bucket through the S3 API. Null for buckets created with a local alias.",
+			},
+			"local_alias": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Create the bucket with an alias that is only visible to one access key, instead of a global alias. Exactly one of `global_alias` and `local_alias` must be set.",
+				Attributes: map[string]schema.Attribute{
+					"access_key_id": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "The access key ID the alias is local to.",
+					},
+					"alias": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "The name of the bucket for the access key.",
+						Validators: []validator.String{
+							bucketAlias(),
+						},
+					},
+					"all_permissions": schema.BoolAttribute{
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+						MarkdownDescription: "Grant the access key read, write and owner permissions on the new bucket. Defaults to `false`.",
+					},
+				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+			},
 			"website_enabled": schema.BoolAttribute{
 				Optional:            true,
 				Computed:            true,
@@ -80,15 +201,85 @@ func (r *BucketResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Optional:            true,
 				MarkdownDescription: "The error document for website hosting (e.g., 'error.html').",
 			},
+			"website_seed_documents": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Upload placeholder index and error documents when website hosting is enabled, so the site does not return 404 until content is deployed. Existing objects are never overwritten. Requires the provider `s3_endpoint` to be configured and the bucket to have a `global_alias`.",
+				Validators: []validator.Bool{
+					boolvalidator.AlsoRequires(path.MatchRoot("global_alias")),
+				},
+			},
 			"max_size": schema.Int64Attribute{
 				Optional:            true,
-				MarkdownDescription: "Maximum size of the bucket in bytes. Leave unset for unlimited.",
+				MarkdownDescription: "Maximum size of the bucket in bytes, between 1 byte and 1 EiB. Leave unset for unlimited.",
+				Validators: []validator.Int64{
+					quota(maxQuotaSize, "bytes"),
+				},
 			},
 			"max_objects": schema.Int64Attribute{
 				Optional:            true,
-				MarkdownDescription: "Maximum number of objects in the bucket. Leave unset for unlimited.",
+				MarkdownDescription: "Maximum number of objects in the bucket, between 1 and 2^40. Leave unset for unlimited.",
+				Validators: []validator.Int64{
+					quota(maxQuotaObjects, "objects"),
+				},
+			},
+			"skip_destroy": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "When `true`, destroying the resource only removes it from the Terraform state and leaves the bucket and its objects in Garage, for instance to hand the bucket over to another workspace. Defaults to `false`.",
+			},
+			"force_destroy": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "When `true`, destroying the resource deletes all objects and aborts all multipart uploads of the bucket first, since Garage only deletes empty buckets. Deleting objects requires the provider `s3_endpoint` to be configured and the bucket to have a global alias. Defaults to `false`.",
+			},
+			"cleanup_incomplete_uploads_older_than": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Abort the unfinished multipart uploads of the bucket started longer ago than this duration (e.g., `24h`) on every apply, so that abandoned uploads do not count against the quotas. While the bucket has unfinished uploads, every plan shows an update that runs the cleanup.",
+				Validators: []validator.String{
+					positiveDuration(),
+				},
+			},
+			"unfinished_uploads": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of unfinished multipart uploads in the bucket.",
+			},
+			"keys": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The access keys granted permissions on the bucket, whether through this configuration or otherwise.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"access_key_id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The access key ID.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the key.",
+						},
+						"read": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the key can read from the bucket.",
+						},
+						"write": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the key can write to the bucket.",
+						},
+						"owner": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the key owns the bucket.",
+						},
+					},
+				},
 			},
 		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
 	}
 }
 
@@ -111,6 +302,108 @@ func (r *BucketResource) Configure(ctx context.Context, req resource.ConfigureRe
 	r.client = client
 }
 
+func (r *BucketResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	scopes := resourceScopes{
+		Create: []string{"CreateBucket", "GetBucketInfo", "UpdateBucket"},
+		Update: []string{"GetBucketInfo", "UpdateBucket"},
+		Delete: []string{"DeleteBucket"},
+	}
+
+	if !req.Plan.Raw.IsNull() {
+		var data BucketResourceModel
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+		// The plan holds the aliases of the state when global_aliases is not
+		// configured, so only the configured ones are checked
+		aliases := configuredGlobalAliases(ctx, req.Config, &resp.Diagnostics)
+		if aliases != nil && !data.GlobalAlias.IsNull() && !data.GlobalAlias.IsUnknown() && !slices.Contains(aliases, data.GlobalAlias.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("global_aliases"),
+				"Invalid Global Aliases",
+				fmt.Sprintf("global_aliases must contain the global alias %s of the bucket.", data.GlobalAlias.ValueString()),
+			)
+		}
+
+		// The global alias is stored after the provider's bucket_alias_prefix,
+		// so that changing the prefix moves the alias in place
+		fullGlobalAlias := types.StringUnknown()
+		if r.client != nil && !data.GlobalAlias.IsUnknown() {
+			fullGlobalAlias = types.StringNull()
+			if !data.GlobalAlias.IsNull() {
+				fullGlobalAlias = types.StringValue(r.client.BucketAliasPrefix() + data.GlobalAlias.ValueString())
+			}
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("full_global_alias"), fullGlobalAlias)...)
+		}
+
+		// The aliases are valid on their own, but may not be once prefixed
+		if r.client != nil && r.client.BucketAliasPrefix() != "" {
+			if !fullGlobalAlias.IsNull() && !fullGlobalAlias.IsUnknown() {
+				checkPrefixedAlias(path.Root("global_alias"), fullGlobalAlias.ValueString(), &resp.Diagnostics)
+			}
+			for _, alias := range aliases {
+				if alias == data.GlobalAlias.ValueString() {
+					continue
+				}
+				checkPrefixedAlias(path.Root("global_aliases"), r.client.BucketAliasPrefix()+alias, &resp.Diagnostics)
+			}
+		}
+
+		// Unfinished uploads are aborted by an update of the bucket
+		if !data.CleanupUploadsOlderThan.IsNull() {
+			scopes.Update = append(scopes.Update, "CleanupIncompleteUploads")
+
+			if !req.State.Raw.IsNull() {
+				var unfinishedUploads types.Int64
+				resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("unfinished_uploads"), &unfinishedUploads)...)
+				if unfinishedUploads.ValueInt64() > 0 {
+					resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("unfinished_uploads"), types.Int64Unknown())...)
+				}
+			}
+		}
+
+		// Managed global aliases are added and removed one by one
+		var configured types.Set
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("global_aliases"), &configured)...)
+		if !configured.IsNull() {
+			scopes.Create = append(scopes.Create, "AddBucketAlias")
+			scopes.Update = append(scopes.Update, "AddBucketAlias", "RemoveBucketAlias")
+		} else if !req.State.Raw.IsNull() {
+			// A replacement bucket does not inherit the aliases of the old one
+			var state BucketResourceModel
+			resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+			if !data.GlobalAlias.Equal(state.GlobalAlias) || !data.LocalAlias.Equal(state.LocalAlias) || !fullGlobalAlias.Equal(state.FullGlobalAlias) {
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("global_aliases"), types.SetUnknown(types.StringType))...)
+			}
+		}
+	}
+
+	// Seeding website documents goes through a temporary access key
+	if !req.Plan.Raw.IsNull() {
+		var seed types.Bool
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("website_seed_documents"), &seed)...)
+		if seed.ValueBool() {
+			seedScopes := []string{"CreateKey", "AllowBucketKey", "DeleteKey"}
+			scopes.Create = append(scopes.Create, seedScopes...)
+			scopes.Update = append(scopes.Update, seedScopes...)
+		}
+	}
+
+	// Abandoned buckets are not deleted, and forcibly destroyed buckets are
+	// emptied through a temporary access key first
+	if req.Plan.Raw.IsNull() && !req.State.Raw.IsNull() {
+		var skipDestroy, forceDestroy types.Bool
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("skip_destroy"), &skipDestroy)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("force_destroy"), &forceDestroy)...)
+		if skipDestroy.ValueBool() {
+			scopes.Delete = nil
+		} else if forceDestroy.ValueBool() {
+			scopes.Delete = append(scopes.Delete, "GetBucketInfo", "CleanupIncompleteUploads", "CreateKey", "AllowBucketKey", "DeleteKey")
+		}
+	}
+
+	validateTokenScope(ctx, r.client, scopes, req, resp)
+}
+
 func (r *BucketResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data BucketResourceModel
 
@@ -120,24 +413,73 @@ func (r *BucketResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	tflog.Debug(ctx, "Creating bucket", map[string]interface{}{
 		"global_alias": data.GlobalAlias.ValueString(),
 	})
 
-	// Create bucket with global alias
-	globalAlias := data.GlobalAlias.ValueString()
-	createReq := client.CreateBucketRequest{
-		GlobalAlias: &globalAlias,
+	// Create bucket with either a global or a local alias
+	globalAlias := ""
+	createReq := client.CreateBucketRequest{}
+	data.FullGlobalAlias = types.StringNull()
+
+	if !data.LocalAlias.IsNull() {
+		var localAlias BucketLocalAliasModel
+		resp.Diagnostics.Append(data.LocalAlias.As(ctx, &localAlias, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		createReq.LocalAlias = bucketLocalAliasRequest(localAlias)
+	} else {
+		globalAlias = r.client.BucketAliasPrefix() + data.GlobalAlias.ValueString()
+		createReq.GlobalAlias = &globalAlias
+		data.FullGlobalAlias = types.StringValue(globalAlias)
 	}
 
 	bucket, err := r.client.CreateBucket(ctx, createReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create bucket, got error: %s", err))
+		addClientError(&resp.Diagnostics, "create bucket", err)
 		return
 	}
 
 	data.ID = types.StringValue(bucket.ID)
 
+	// A new bucket has no uploads to clean up
+	data.UnfinishedUploads = types.Int64Value(0)
+
+	// A new bucket has no keys yet
+	keys, diags := bucketKeysValue(ctx, nil)
+	resp.Diagnostics.Append(diags...)
+	data.Keys = keys
+
+	// Save the bucket right away so it is tracked even if a later step fails.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	// On multi-node clusters the new bucket may not have replicated to the
+	// node serving the next request yet, so wait until it is visible.
+	if err := waitForBucket(ctx, r.client, bucket.ID, globalAlias); err != nil {
+		addClientError(&resp.Diagnostics, fmt.Sprintf("wait for created bucket %s to become visible", bucket.ID), err)
+		return
+	}
+
+	// Add the other configured global aliases
+	var initialAliases []string
+	if createReq.GlobalAlias != nil {
+		initialAliases = []string{globalAlias}
+	}
+
+	if aliases := configuredGlobalAliases(ctx, req.Config, &resp.Diagnostics); aliases != nil {
+		if err := updateGlobalAliases(ctx, r.client, bucket.ID, initialAliases, addAliasPrefix(r.client.BucketAliasPrefix(), aliases)); err != nil {
+			addClientError(&resp.Diagnostics, "add bucket alias", err)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+	} else {
+		data.GlobalAliases = globalAliasesValue(ctx, trimAliasPrefix(r.client.BucketAliasPrefix(), initialAliases), &resp.Diagnostics)
+	}
+
 	// Update bucket with additional configuration if needed
 	updateReq := client.UpdateBucketRequest{}
 	needsUpdate := false
@@ -145,11 +487,7 @@ func (r *BucketResource) Create(ctx context.Context, req resource.CreateRequest,
 	// Configure website settings
 	if !data.WebsiteEnabled.IsNull() || !data.WebsiteIndex.IsNull() || !data.WebsiteError.IsNull() {
 		websiteEnabled := data.WebsiteEnabled.ValueBool()
-		updateReq.WebsiteAccess = &struct {
-			Enabled       bool    `json:"enabled"`
-			IndexDocument *string `json:"indexDocument,omitempty"`
-			ErrorDocument *string `json:"errorDocument,omitempty"`
-		}{
+		updateReq.WebsiteAccess = &client.UpdateBucketWebsiteAccess{
 			Enabled: websiteEnabled,
 		}
 
@@ -186,7 +524,15 @@ func (r *BucketResource) Create(ctx context.Context, req resource.CreateRequest,
 	if needsUpdate {
 		_, err = r.client.UpdateBucket(ctx, bucket.ID, updateReq)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update bucket, got error: %s", err))
+			addClientError(&resp.Diagnostics, "update bucket", err)
+			return
+		}
+	}
+
+	if data.WebsiteEnabled.ValueBool() && data.WebsiteSeed.ValueBool() {
+		if err := r.seedWebsiteDocuments(ctx, data); err != nil {
+			addClientError(&resp.Diagnostics, "seed website documents", err)
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 			return
 		}
 	}
@@ -205,13 +551,16 @@ func (r *BucketResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	bucketID := data.ID.ValueString()
 	bucket, err := r.client.GetBucketInfo(ctx, client.GetBucketInfoRequest{
 		ID: &bucketID,
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read bucket, got error: %s", err))
+		addClientError(&resp.Diagnostics, "read bucket", err)
 		return
 	}
 
@@ -223,11 +572,45 @@ func (r *BucketResource) Read(ctx context.Context, req resource.ReadRequest, res
 	// Update state with bucket information
 	data.ID = types.StringValue(bucket.ID)
 
-	if len(bucket.GlobalAliases) > 0 {
-		data.GlobalAlias = types.StringValue(bucket.GlobalAliases[0])
+	// A bucket created with a local alias keeps it, even if it has been given
+	// a global alias since. Otherwise the global alias only changes once it
+	// has been removed from the bucket.
+	prefix := r.client.BucketAliasPrefix()
+	fullGlobalAlias := data.FullGlobalAlias.ValueString()
+	if data.FullGlobalAlias.IsNull() {
+		// States written before the prefix existed hold the alias as is
+		fullGlobalAlias = data.GlobalAlias.ValueString()
+	}
+
+	if data.LocalAlias.IsNull() && len(bucket.GlobalAliases) > 0 && !slices.Contains(bucket.GlobalAliases, fullGlobalAlias) {
+		fullGlobalAlias = bucket.GlobalAliases[0]
+		data.GlobalAlias = types.StringValue(fullGlobalAlias)
+	}
+
+	// An alias without the current prefix keeps its global_alias, so that the
+	// plan moves it to the new prefix instead of replacing the bucket
+	data.FullGlobalAlias = types.StringNull()
+	if !data.GlobalAlias.IsNull() {
+		if strings.HasPrefix(fullGlobalAlias, prefix) {
+			data.GlobalAlias = types.StringValue(strings.TrimPrefix(fullGlobalAlias, prefix))
+		}
+		data.FullGlobalAlias = types.StringValue(fullGlobalAlias)
+	}
+
+	data.GlobalAliases = globalAliasesValue(ctx, trimAliasPrefix(prefix, bucket.GlobalAliases), &resp.Diagnostics)
+
+	// An imported bucket that is only known by a local alias takes it from
+	// the key it is local to.
+	if data.LocalAlias.IsNull() && data.GlobalAlias.IsNull() {
+		if localAlias := findBucketLocalAlias(bucket.Keys); localAlias != nil {
+			value, diags := types.ObjectValueFrom(ctx, bucketLocalAliasAttributeTypes, localAlias)
+			resp.Diagnostics.Append(diags...)
+			data.LocalAlias = value
+		}
 	}
 
 	data.WebsiteEnabled = types.BoolValue(bucket.WebsiteAccess)
+	data.UnfinishedUploads = types.Int64Value(bucket.UnfinishedUploads)
 
 	if bucket.WebsiteConfig != nil {
 		data.WebsiteIndex = types.StringValue(bucket.WebsiteConfig.IndexDocument)
@@ -254,29 +637,67 @@ func (r *BucketResource) Read(ctx context.Context, req resource.ReadRequest, res
 		data.MaxObjects = types.Int64Null()
 	}
 
+	keys, diags := bucketKeysValue(ctx, bucket.Keys)
+	resp.Diagnostics.Append(diags...)
+	data.Keys = keys
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *BucketResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data BucketResourceModel
+	var data, state BucketResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	bucketID := data.ID.ValueString()
+	prefix := r.client.BucketAliasPrefix()
+
+	if !data.GlobalAlias.IsNull() {
+		data.FullGlobalAlias = types.StringValue(prefix + data.GlobalAlias.ValueString())
+	}
+
+	// Reconcile the global aliases, adding new ones before removing the
+	// others so that the bucket is never left without an alias. The live
+	// aliases are compared, as those in the state have the prefix removed.
+	// Aliases are left alone when global_aliases is not configured.
+	if aliases := configuredGlobalAliases(ctx, req.Config, &resp.Diagnostics); aliases != nil {
+		current, err := r.client.GetBucketInfo(ctx, client.GetBucketInfoRequest{ID: &bucketID})
+		if err != nil {
+			addClientError(&resp.Diagnostics, "read bucket", err)
+			return
+		}
+
+		var previous []string
+		if current != nil {
+			previous = current.GlobalAliases
+		}
+
+		if err := updateGlobalAliases(ctx, r.client, bucketID, previous, addAliasPrefix(prefix, aliases)); err != nil {
+			addClientError(&resp.Diagnostics, "update bucket aliases", err)
+			return
+		}
+	} else if !data.FullGlobalAlias.IsNull() && !state.FullGlobalAlias.IsNull() && !data.FullGlobalAlias.Equal(state.FullGlobalAlias) {
+		// The bucket alias prefix changed
+		previous := []string{state.FullGlobalAlias.ValueString()}
+		if err := updateGlobalAliases(ctx, r.client, bucketID, previous, []string{data.FullGlobalAlias.ValueString()}); err != nil {
+			addClientError(&resp.Diagnostics, "update bucket aliases", err)
+			return
+		}
+	}
 
 	updateReq := client.UpdateBucketRequest{}
 
 	// Configure website settings
 	websiteEnabled := data.WebsiteEnabled.ValueBool()
-	updateReq.WebsiteAccess = &struct {
-		Enabled       bool    `json:"enabled"`
-		IndexDocument *string `json:"indexDocument,omitempty"`
-		ErrorDocument *string `json:"errorDocument,omitempty"`
-	}{
+	updateReq.WebsiteAccess = &client.UpdateBucketWebsiteAccess{
 		Enabled: websiteEnabled,
 	}
 
@@ -303,12 +724,38 @@ func (r *BucketResource) Update(ctx context.Context, req resource.UpdateRequest,
 		updateReq.Quotas.MaxObjects = &maxObjects
 	}
 
-	_, err := r.client.UpdateBucket(ctx, bucketID, updateReq)
+	bucket, err := r.client.UpdateBucket(ctx, bucketID, updateReq)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update bucket, got error: %s", err))
+		addClientError(&resp.Diagnostics, "update bucket", err)
 		return
 	}
 
+	keys, diags := bucketKeysValue(ctx, bucket.Keys)
+	resp.Diagnostics.Append(diags...)
+	data.Keys = keys
+
+	if data.GlobalAliases.IsUnknown() {
+		data.GlobalAliases = globalAliasesValue(ctx, trimAliasPrefix(prefix, bucket.GlobalAliases), &resp.Diagnostics)
+	}
+
+	unfinishedUploads := bucket.UnfinishedUploads
+	if !data.CleanupUploadsOlderThan.IsNull() && unfinishedUploads > 0 {
+		deleted, err := cleanupIncompleteUploads(ctx, r.client, bucketID, data.CleanupUploadsOlderThan.ValueString())
+		if err != nil {
+			addClientError(&resp.Diagnostics, "clean up incomplete uploads", err)
+			return
+		}
+		unfinishedUploads = max(unfinishedUploads-deleted, 0)
+	}
+	data.UnfinishedUploads = types.Int64Value(unfinishedUploads)
+
+	if data.WebsiteEnabled.ValueBool() && data.WebsiteSeed.ValueBool() {
+		if err := r.seedWebsiteDocuments(ctx, data); err != nil {
+			addClientError(&resp.Diagnostics, "seed website documents", err)
+			return
+		}
+	}
+
 	tflog.Trace(ctx, "Updated bucket resource")
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -323,14 +770,37 @@ func (r *BucketResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, defaultDeleteTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	bucketID := data.ID.ValueString()
 
+	if data.SkipDestroy.ValueBool() {
+		tflog.Info(ctx, "Removing bucket from state without deleting it", map[string]interface{}{
+			"id": bucketID,
+		})
+		return
+	}
+
+	if data.ForceDestroy.ValueBool() {
+		if err := emptyBucket(ctx, r.client, bucketID); err != nil {
+			addClientError(&resp.Diagnostics, "empty bucket", err)
+			return
+		}
+	} else {
+		resp.Diagnostics.Append(checkBucketEmpty(ctx, r.client, bucketID, "Delete the objects and abort the uploads, or set force_destroy = true, then destroy the bucket again.")...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	err := r.client.DeleteBucket(ctx, client.DeleteBucketRequest{
 		ID: bucketID,
 	})
 
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete bucket, got error: %s", err))
+	// A bucket already deleted outside Terraform is gone as planned
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		addClientError(&resp.Diagnostics, "delete bucket", err)
 		return
 	}
 
@@ -339,4 +809,354 @@ func (r *BucketResource) Delete(ctx context.Context, req resource.DeleteRequest,
 
 func (r *BucketResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("skip_destroy"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_destroy"), false)...)
+}
+
+// configuredGlobalAliases returns the global aliases set in the
+// configuration, or nil if they are not managed or not known yet.
+func configuredGlobalAliases(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics) []string {
+	var configured types.Set
+	diags.Append(config.GetAttribute(ctx, path.Root("global_aliases"), &configured)...)
+	if configured.IsNull() || configured.IsUnknown() {
+		return nil
+	}
+
+	aliases := []string{}
+	diags.Append(configured.ElementsAs(ctx, &aliases, false)...)
+	return aliases
+}
+
+// checkPrefixedAlias reports an error at p when an alias, once prefixed with
+// the provider's bucket_alias_prefix, is not a bucket name Garage accepts.
+func checkPrefixedAlias(p path.Path, alias string, diags *diag.Diagnostics) {
+	if problem := bucketAliasProblem(alias); problem != "" {
+		diags.AddAttributeError(
+			p,
+			"Invalid Bucket Alias",
+			fmt.Sprintf("The alias %q, prefixed with the provider bucket_alias_prefix, is not a valid bucket name: %s.", alias, problem),
+		)
+	}
+}
+
+// globalAliasesValue converts the global aliases of a bucket to the value of
+// the global_aliases attribute.
+func globalAliasesValue(ctx context.Context, aliases []string, diags *diag.Diagnostics) types.Set {
+	if aliases == nil {
+		aliases = []string{}
+	}
+
+	value, d := types.SetValueFrom(ctx, types.StringType, aliases)
+	diags.Append(d...)
+	return value
+}
+
+// addAliasPrefix returns the aliases with prefix prepended, or nil if they
+// are not managed.
+func addAliasPrefix(prefix string, aliases []string) []string {
+	if aliases == nil {
+		return nil
+	}
+
+	prefixed := make([]string, 0, len(aliases))
+	for _, alias := range aliases {
+		prefixed = append(prefixed, prefix+alias)
+	}
+	return prefixed
+}
+
+// trimAliasPrefix returns the aliases with prefix removed from those that
+// start with it.
+func trimAliasPrefix(prefix string, aliases []string) []string {
+	trimmed := make([]string, 0, len(aliases))
+	for _, alias := range aliases {
+		trimmed = append(trimmed, strings.TrimPrefix(alias, prefix))
+	}
+	return trimmed
+}
+
+// updateGlobalAliases adds the new global aliases to the bucket and removes
+// those no longer wanted.
+func updateGlobalAliases(ctx context.Context, c *client.Client, bucketID string, previous, current []string) error {
+	for _, alias := range current {
+		if slices.Contains(previous, alias) {
+			continue
+		}
+
+		tflog.Debug(ctx, "Adding bucket alias", map[string]interface{}{
+			"bucket_id": bucketID,
+			"alias":     alias,
+		})
+
+		if err := c.AddBucketAlias(ctx, bucketID, alias); err != nil {
+			return err
+		}
+	}
+
+	for _, alias := range previous {
+		if slices.Contains(current, alias) {
+			continue
+		}
+
+		tflog.Debug(ctx, "Removing bucket alias", map[string]interface{}{
+			"bucket_id": bucketID,
+			"alias":     alias,
+		})
+
+		if err := c.RemoveBucketAlias(ctx, bucketID, alias); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// bucketLocalAliasRequest converts the local_alias attribute to the local
+// alias of a create bucket request.
+func bucketLocalAliasRequest(data BucketLocalAliasModel) *client.CreateBucketLocalAlias {
+	allPermissions := data.AllPermissions.ValueBool()
+
+	return &client.CreateBucketLocalAlias{
+		AccessKeyID: data.AccessKeyID.ValueString(),
+		Alias:       data.Alias.ValueString(),
+		Allow: client.Permissions{
+			Read:  allPermissions,
+			Write: allPermissions,
+			Owner: allPermissions,
+		},
+	}
+}
+
+// findBucketLocalAlias returns the local alias of a bucket, or nil unless
+// exactly one key has one.
+func findBucketLocalAlias(bucketKeys []client.BucketKeyInfo) *BucketLocalAliasModel {
+	var found *BucketLocalAliasModel
+	for _, key := range bucketKeys {
+		for _, alias := range key.BucketLocalAliases {
+			if found != nil {
+				return nil
+			}
+			found = &BucketLocalAliasModel{
+				AccessKeyID:    types.StringValue(key.AccessKeyID),
+				Alias:          types.StringValue(alias),
+				AllPermissions: types.BoolValue(key.Permissions.Read && key.Permissions.Write && key.Permissions.Owner),
+			}
+		}
+	}
+	return found
+}
+
+// bucketKeysValue converts the keys granted on a bucket to the value of the
+// keys attribute.
+func bucketKeysValue(ctx context.Context, bucketKeys []client.BucketKeyInfo) (types.List, diag.Diagnostics) {
+	keys := make([]BucketKeyModel, 0, len(bucketKeys))
+	for _, key := range bucketKeys {
+		keys = append(keys, BucketKeyModel{
+			AccessKeyID: types.StringValue(key.AccessKeyID),
+			Name:        types.StringValue(key.Name),
+			Read:        types.BoolValue(key.Permissions.Read),
+			Write:       types.BoolValue(key.Permissions.Write),
+			Owner:       types.BoolValue(key.Permissions.Owner),
+		})
+	}
+
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: bucketKeyAttributeTypes}, keys)
+}
+
+// seedWebsiteDocuments uploads placeholder index and error documents to the
+// bucket for each document that does not exist yet.
+func (r *BucketResource) seedWebsiteDocuments(ctx context.Context, data BucketResourceModel) error {
+	documents := map[string]string{}
+
+	indexDoc := "index.html"
+	if !data.WebsiteIndex.IsNull() {
+		indexDoc = data.WebsiteIndex.ValueString()
+	}
+	documents[indexDoc] = placeholderIndexDocument
+
+	if !data.WebsiteError.IsNull() {
+		documents[data.WebsiteError.ValueString()] = placeholderErrorDocument
+	}
+
+	bucketID := data.ID.ValueString()
+	// The S3 API addresses buckets by alias rather than by ID.
+	bucketName := data.FullGlobalAlias.ValueString()
+
+	return withTemporaryBucketKey(ctx, r.client, bucketID, client.Permissions{Read: true, Write: true}, func(s3 *client.S3Client) error {
+		for key, content := range documents {
+			existing, err := s3.HeadObject(ctx, bucketName, key)
+			if err != nil {
+				return fmt.Errorf("unable to check for %s: %w", key, err)
+			}
+
+			if existing != nil {
+				continue
+			}
+
+			tflog.Debug(ctx, "Seeding website document", map[string]interface{}{
+				"id":  bucketID,
+				"key": key,
+			})
+
+			_, err = s3.PutObject(ctx, client.PutObjectRequest{
+				Bucket:      bucketName,
+				Key:         key,
+				Body:        []byte(content),
+				ContentType: "text/html",
+			})
+			if err != nil {
+				return fmt.Errorf("unable to upload %s: %w", key, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// waitForBucket polls GetBucketInfo until the bucket can be read back and the
+// given global alias resolves to it. A bucket without a global alias is
+// looked up by ID.
+func waitForBucket(ctx context.Context, c *client.Client, bucketID, globalAlias string) error {
+	ctx, cancel := context.WithTimeout(ctx, bucketConvergenceTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(bucketConvergenceInterval)
+	defer ticker.Stop()
+
+	for {
+		lookup := client.GetBucketInfoRequest{GlobalAlias: &globalAlias}
+		if globalAlias == "" {
+			lookup = client.GetBucketInfoRequest{ID: &bucketID}
+		}
+
+		bucket, err := c.GetBucketInfo(ctx, lookup)
+		if err == nil && bucket != nil && bucket.ID == bucketID && (globalAlias == "" || slices.Contains(bucket.GlobalAliases, globalAlias)) {
+			return nil
+		}
+
+		tflog.Debug(ctx, "Waiting for bucket to become visible", map[string]interface{}{
+			"id":           bucketID,
+			"global_alias": globalAlias,
+		})
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return fmt.Errorf("timed out after %s: %w", bucketConvergenceTimeout, err)
+			}
+			return fmt.Errorf("timed out after %s", bucketConvergenceTimeout)
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkBucketEmpty returns an error if the bucket still holds objects or
+// unfinished multipart uploads, since Garage refuses to delete such buckets
+// with a less helpful error. fix tells the user how to proceed.
+func checkBucketEmpty(ctx context.Context, c *client.Client, bucketID, fix string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	bucket, err := c.GetBucketInfo(ctx, client.GetBucketInfoRequest{ID: &bucketID})
+	if err != nil {
+		addClientError(&diags, "read bucket", err)
+		return diags
+	}
+
+	// A bucket that is already gone is handled by the delete itself
+	if bucket == nil || (bucket.Objects == 0 && bucket.UnfinishedUploads == 0) {
+		return diags
+	}
+
+	diags.AddError(
+		"Bucket Not Empty",
+		fmt.Sprintf("Bucket %s still contains %d objects (%d bytes) and %d unfinished multipart uploads. "+
+			"Garage only deletes empty buckets. %s",
+			bucketID, bucket.Objects, bucket.Bytes, bucket.UnfinishedUploads, fix),
+	)
+
+	return diags
+}
+
+// cleanupIncompleteUploads aborts the unfinished multipart uploads of the
+// bucket started longer ago than olderThan, and returns how many were aborted.
+func cleanupIncompleteUploads(ctx context.Context, c *client.Client, bucketID, olderThan string) (int64, error) {
+	duration, err := time.ParseDuration(olderThan)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse cleanup_incomplete_uploads_older_than: %w", err)
+	}
+
+	tflog.Debug(ctx, "Cleaning up incomplete uploads", map[string]interface{}{
+		"bucket_id":  bucketID,
+		"older_than": olderThan,
+	})
+
+	result, err := c.CleanupIncompleteUploads(ctx, client.CleanupIncompleteUploadsRequest{
+		BucketID:      bucketID,
+		OlderThanSecs: int64(duration.Seconds()),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.UploadsDeleted, nil
+}
+
+// emptyBucket aborts all unfinished multipart uploads of the bucket and
+// deletes all its objects, so that Garage lets the bucket be deleted.
+func emptyBucket(ctx context.Context, c *client.Client, bucketID string) error {
+	bucket, err := c.GetBucketInfo(ctx, client.GetBucketInfoRequest{ID: &bucketID})
+	if err != nil {
+		return err
+	}
+
+	// A bucket that is already gone is handled by the delete itself
+	if bucket == nil {
+		return nil
+	}
+
+	if bucket.UnfinishedUploads > 0 {
+		result, err := c.CleanupIncompleteUploads(ctx, client.CleanupIncompleteUploadsRequest{
+			BucketID:      bucketID,
+			OlderThanSecs: 0,
+		})
+		if err != nil {
+			return fmt.Errorf("unable to abort multipart uploads: %w", err)
+		}
+
+		tflog.Info(ctx, "Aborted multipart uploads", map[string]interface{}{
+			"bucket_id": bucketID,
+			"aborted":   result.UploadsDeleted,
+		})
+	}
+
+	if bucket.Objects == 0 {
+		return nil
+	}
+
+	bucketName, err := bucketS3Name(ctx, c, bucketID)
+	if err != nil {
+		return err
+	}
+
+	return withTemporaryBucketKey(ctx, c, bucketID, client.Permissions{Read: true, Write: true}, func(s3 *client.S3Client) error {
+		keys, err := listObjectKeys(ctx, s3, bucketName, "")
+		if err != nil {
+			return err
+		}
+
+		for start := 0; start < len(keys); start += client.MaxDeleteObjects {
+			end := min(start+client.MaxDeleteObjects, len(keys))
+			if err := s3.DeleteObjects(ctx, bucketName, keys[start:end]); err != nil {
+				return err
+			}
+
+			tflog.Info(ctx, "Deleting objects", map[string]interface{}{
+				"bucket_id": bucketID,
+				"deleted":   end,
+				"total":     len(keys),
+			})
+		}
+
+		return nil
+	})
 }
@@ -5,9 +5,14 @@ package provider
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+
+	"terraform-provider-garage/internal/client"
 )
 
 func TestAccBucketResource_basic(t *testing.T) {
@@ -170,6 +175,317 @@ func TestAccBucketResource_nameChange(t *testing.T) {
 	})
 }
 
+func TestAccBucketResource_websiteSeedDocuments(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckS3(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create website bucket with placeholder documents
+			{
+				Config: testAccBucketResourceConfig_websiteSeed("test-bucket-seed"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_bucket.test", "website_enabled", "true"),
+					resource.TestCheckResourceAttr("garage_bucket.test", "website_seed_documents", "true"),
+					resource.TestCheckResourceAttr("data.garage_bucket.test", "objects", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBucketResource_forceDestroy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckS3(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Seeded documents leave the bucket non-empty
+			{
+				Config: testAccBucketResourceConfig_websiteSeed("test-bucket-force-destroy"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_bucket.test", "force_destroy", "true"),
+					resource.TestCheckResourceAttr("data.garage_bucket.test", "objects", "2"),
+				),
+			},
+			// The objects are deleted along with the bucket
+			{
+				Config:  testAccBucketResourceConfig_websiteSeed("test-bucket-force-destroy"),
+				Destroy: true,
+			},
+		},
+	})
+}
+
+func TestAccBucketResource_cleanupIncompleteUploads(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBucketResourceConfig_cleanupUploads("test-bucket-cleanup", "24h"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_bucket.test", "cleanup_incomplete_uploads_older_than", "24h"),
+					resource.TestCheckResourceAttr("garage_bucket.test", "unfinished_uploads", "0"),
+				),
+			},
+			{
+				Config: testAccBucketResourceConfig_cleanupUploads("test-bucket-cleanup", "1h"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_bucket.test", "cleanup_incomplete_uploads_older_than", "1h"),
+					resource.TestCheckResourceAttr("garage_bucket.test", "unfinished_uploads", "0"),
+				),
+			},
+			{
+				ResourceName:            "garage_bucket.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"cleanup_incomplete_uploads_older_than"},
+			},
+		},
+	})
+}
+
+func TestAccBucketResource_skipDestroy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create a bucket that is abandoned on destroy
+			{
+				Config: testAccBucketResourceConfig_skipDestroy("test-bucket-skip-destroy"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_bucket.test", "skip_destroy", "true"),
+				),
+			},
+			// Removing the resource leaves the bucket in Garage
+			{
+				Config: testAccBucketResourceConfig_abandoned("test-bucket-skip-destroy"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.garage_bucket.abandoned", "id"),
+				),
+			},
+			// Adopt the bucket again so that it is deleted at the end of the test
+			{
+				Config: testAccBucketResourceConfig_adopted("test-bucket-skip-destroy"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_bucket.test", "skip_destroy", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBucketResource_keys(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// A new bucket has no keys
+			{
+				Config: testAccBucketResourceConfig_basic("test-bucket-keys"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_bucket.test", "keys.#", "0"),
+				),
+			},
+			// Grant a key, then refresh the bucket to pick it up
+			{
+				Config: testAccBucketResourceConfig_keys("test-bucket-keys", "test-bucket-keys-key"),
+			},
+			{
+				RefreshState: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_bucket.test", "keys.#", "1"),
+					resource.TestCheckResourceAttrPair("garage_bucket.test", "keys.0.access_key_id", "garage_key.test", "id"),
+					resource.TestCheckResourceAttr("garage_bucket.test", "keys.0.name", "test-bucket-keys-key"),
+					resource.TestCheckResourceAttr("garage_bucket.test", "keys.0.read", "true"),
+					resource.TestCheckResourceAttr("garage_bucket.test", "keys.0.write", "false"),
+					resource.TestCheckResourceAttr("garage_bucket.test", "keys.0.owner", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBucketResource_localAlias(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBucketResourceConfig_localAlias("test-bucket-local", "test-bucket-local-key"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("garage_bucket.test", "id"),
+					resource.TestCheckNoResourceAttr("garage_bucket.test", "global_alias"),
+					resource.TestCheckResourceAttrPair("garage_bucket.test", "local_alias.access_key_id", "garage_key.test", "id"),
+					resource.TestCheckResourceAttr("garage_bucket.test", "local_alias.alias", "test-bucket-local"),
+					resource.TestCheckResourceAttr("garage_bucket.test", "local_alias.all_permissions", "true"),
+				),
+			},
+			// The key is granted permissions when the bucket is created
+			{
+				RefreshState: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_bucket.test", "keys.#", "1"),
+					resource.TestCheckResourceAttrPair("garage_bucket.test", "keys.0.access_key_id", "garage_key.test", "id"),
+					resource.TestCheckResourceAttr("garage_bucket.test", "keys.0.read", "true"),
+					resource.TestCheckResourceAttr("garage_bucket.test", "keys.0.write", "true"),
+					resource.TestCheckResourceAttr("garage_bucket.test", "keys.0.owner", "true"),
+				),
+			},
+			{
+				ResourceName:      "garage_bucket.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccBucketResource_globalAliases(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Unmanaged aliases are only reported
+			{
+				Config: testAccBucketResourceConfig_basic("test-bucket-aliases"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_bucket.test", "global_aliases.#", "1"),
+					resource.TestCheckTypeSetElemAttr("garage_bucket.test", "global_aliases.*", "test-bucket-aliases"),
+				),
+			},
+			// Add aliases
+			{
+				Config: testAccBucketResourceConfig_globalAliases("test-bucket-aliases", "test-bucket-aliases-a", "test-bucket-aliases-b"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_bucket.test", "global_alias", "test-bucket-aliases"),
+					resource.TestCheckResourceAttr("garage_bucket.test", "global_aliases.#", "3"),
+					resource.TestCheckTypeSetElemAttr("garage_bucket.test", "global_aliases.*", "test-bucket-aliases-a"),
+					resource.TestCheckTypeSetElemAttr("garage_bucket.test", "global_aliases.*", "test-bucket-aliases-b"),
+				),
+			},
+			// Replace one alias with another
+			{
+				Config: testAccBucketResourceConfig_globalAliases("test-bucket-aliases", "test-bucket-aliases-a", "test-bucket-aliases-c"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_bucket.test", "global_aliases.#", "3"),
+					resource.TestCheckTypeSetElemAttr("garage_bucket.test", "global_aliases.*", "test-bucket-aliases-c"),
+				),
+			},
+			{
+				ResourceName:      "garage_bucket.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Remove the extra aliases
+			{
+				Config: testAccBucketResourceConfig_globalAliases("test-bucket-aliases"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_bucket.test", "global_aliases.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBucketResource_aliasPrefix(t *testing.T) {
+	t.Setenv("GARAGE_BUCKET_ALIAS_PREFIX", "tfacc-dev-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBucketResourceConfig_basic("test-bucket-prefix"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_bucket.test", "global_alias", "test-bucket-prefix"),
+					resource.TestCheckResourceAttr("garage_bucket.test", "full_global_alias", "tfacc-dev-test-bucket-prefix"),
+					resource.TestCheckTypeSetElemAttr("garage_bucket.test", "global_aliases.*", "test-bucket-prefix"),
+				),
+			},
+			// Changing the prefix moves the alias without replacing the bucket
+			{
+				PreConfig: func() { t.Setenv("GARAGE_BUCKET_ALIAS_PREFIX", "tfacc-stg-") },
+				Config:    testAccBucketResourceConfig_basic("test-bucket-prefix"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("garage_bucket.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_bucket.test", "global_alias", "test-bucket-prefix"),
+					resource.TestCheckResourceAttr("garage_bucket.test", "full_global_alias", "tfacc-stg-test-bucket-prefix"),
+					resource.TestCheckResourceAttr("garage_bucket.test", "global_aliases.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAliasPrefix(t *testing.T) {
+	if got := addAliasPrefix("dev-", nil); got != nil {
+		t.Errorf("Expected unmanaged aliases to stay nil, got %v", got)
+	}
+
+	if got := addAliasPrefix("dev-", []string{"assets", "www"}); !slices.Equal(got, []string{"dev-assets", "dev-www"}) {
+		t.Errorf("Expected prefixed aliases, got %v", got)
+	}
+
+	if got := trimAliasPrefix("dev-", []string{"dev-assets", "legacy"}); !slices.Equal(got, []string{"assets", "legacy"}) {
+		t.Errorf("Expected the prefix to be removed where present, got %v", got)
+	}
+}
+
+func TestFindBucketLocalAlias(t *testing.T) {
+	ownerKey := client.BucketKeyInfo{
+		AccessKeyID:        "GK1",
+		Permissions:        client.Permissions{Read: true, Write: true, Owner: true},
+		BucketLocalAliases: []string{"photos"},
+	}
+	readerKey := client.BucketKeyInfo{
+		AccessKeyID:        "GK2",
+		Permissions:        client.Permissions{Read: true},
+		BucketLocalAliases: []string{"shared"},
+	}
+	plainKey := client.BucketKeyInfo{
+		AccessKeyID: "GK3",
+		Permissions: client.Permissions{Read: true},
+	}
+
+	tests := []struct {
+		name           string
+		keys           []client.BucketKeyInfo
+		want           string
+		allPermissions bool
+	}{
+		{name: "no keys"},
+		{name: "no local alias", keys: []client.BucketKeyInfo{plainKey}},
+		{name: "owner", keys: []client.BucketKeyInfo{plainKey, ownerKey}, want: "GK1/photos", allPermissions: true},
+		{name: "reader", keys: []client.BucketKeyInfo{readerKey}, want: "GK2/shared"},
+		{name: "ambiguous", keys: []client.BucketKeyInfo{ownerKey, readerKey}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findBucketLocalAlias(tt.keys)
+			if tt.want == "" {
+				if got != nil {
+					t.Fatalf("expected no local alias, got %+v", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatalf("expected local alias %s, got none", tt.want)
+			}
+			if alias := got.AccessKeyID.ValueString() + "/" + got.Alias.ValueString(); alias != tt.want {
+				t.Errorf("expected local alias %s, got %s", tt.want, alias)
+			}
+			if got.AllPermissions.ValueBool() != tt.allPermissions {
+				t.Errorf("expected all_permissions %t, got %t", tt.allPermissions, got.AllPermissions.ValueBool())
+			}
+		})
+	}
+}
+
 // Test configuration functions
 
 func testAccBucketResourceConfig_basic(name string) string {
@@ -223,3 +539,103 @@ resource "garage_bucket" "test" {
 }
 `, name, websiteEnabled, indexDoc, errorDoc, maxSize, maxObjects)
 }
+
+func testAccBucketResourceConfig_websiteSeed(name string) string {
+	return fmt.Sprintf(`
+resource "garage_bucket" "test" {
+  global_alias           = %[1]q
+  website_enabled        = true
+  website_index_document = "index.html"
+  website_error_document = "error.html"
+  website_seed_documents = true
+  force_destroy          = true
+}
+
+data "garage_bucket" "test" {
+  id = garage_bucket.test.id
+}
+`, name)
+}
+
+func testAccBucketResourceConfig_skipDestroy(name string) string {
+	return fmt.Sprintf(`
+resource "garage_bucket" "test" {
+  global_alias = %[1]q
+  skip_destroy = true
+}
+`, name)
+}
+
+func testAccBucketResourceConfig_abandoned(name string) string {
+	return fmt.Sprintf(`
+data "garage_bucket" "abandoned" {
+  global_alias = %[1]q
+}
+`, name)
+}
+
+func testAccBucketResourceConfig_adopted(name string) string {
+	return testAccBucketResourceConfig_abandoned(name) + fmt.Sprintf(`
+import {
+  to = garage_bucket.test
+  id = data.garage_bucket.abandoned.id
+}
+
+resource "garage_bucket" "test" {
+  global_alias = %[1]q
+}
+`, name)
+}
+
+func testAccBucketResourceConfig_keys(bucketName, keyName string) string {
+	return testAccBucketResourceConfig_basic(bucketName) + fmt.Sprintf(`
+resource "garage_key" "test" {
+  name = %[1]q
+}
+
+resource "garage_bucket_permission" "test" {
+  bucket_id     = garage_bucket.test.id
+  access_key_id = garage_key.test.id
+  read          = true
+}
+`, keyName)
+}
+
+func testAccBucketResourceConfig_localAlias(alias, keyName string) string {
+	return fmt.Sprintf(`
+resource "garage_key" "test" {
+  name = %[2]q
+}
+
+resource "garage_bucket" "test" {
+  local_alias = {
+    access_key_id   = garage_key.test.id
+    alias           = %[1]q
+    all_permissions = true
+  }
+}
+`, alias, keyName)
+}
+
+func testAccBucketResourceConfig_globalAliases(name string, extra ...string) string {
+	aliases := append([]string{name}, extra...)
+	for i, alias := range aliases {
+		aliases[i] = fmt.Sprintf("%q", alias)
+	}
+
+	return fmt.Sprintf(`
+resource "garage_bucket" "test" {
+  global_alias   = %[1]q
+  global_aliases = [%[2]s]
+}
+`, name, strings.Join(aliases, ", "))
+}
+
+func testAccBucketResourceConfig_cleanupUploads(name, olderThan string) string {
+	return fmt.Sprintf(`
+resource "garage_bucket" "test" {
+  global_alias                          = %[1]q
+  cleanup_incomplete_uploads_older_than = %[2]q
+}
+`, name, olderThan)
+}
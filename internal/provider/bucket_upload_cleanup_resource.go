@@ -0,0 +1,233 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BucketUploadCleanupResource{}
+var _ resource.ResourceWithModifyPlan = &BucketUploadCleanupResource{}
+
+func NewBucketUploadCleanupResource() resource.Resource {
+	return &BucketUploadCleanupResource{}
+}
+
+// BucketUploadCleanupResource defines the resource implementation.
+type BucketUploadCleanupResource struct {
+	client *client.Client
+}
+
+// BucketUploadCleanupResourceModel describes the resource data model.
+type BucketUploadCleanupResourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	BucketID       types.String `tfsdk:"bucket_id"`
+	OlderThan      types.String `tfsdk:"older_than"`
+	Triggers       types.Map    `tfsdk:"triggers"`
+	UploadsDeleted types.Int64  `tfsdk:"uploads_deleted"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *BucketUploadCleanupResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bucket_upload_cleanup"
+}
+
+func (r *BucketUploadCleanupResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Aborts the unfinished multipart uploads of a bucket that are older than a given age, so that abandoned uploads do not keep consuming space and quota. " +
+			"The cleanup runs when the resource is created and every time it is updated, for instance when `triggers` change.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the cleanup (same as `bucket_id`).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"bucket_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the bucket.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"older_than": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("24h"),
+				MarkdownDescription: "Only abort uploads started longer ago than this duration (e.g., `72h`). Defaults to `24h`.",
+				Validators: []validator.String{
+					positiveDuration(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Arbitrary values that run the cleanup again when they change, such as a timestamp from a `time_rotating` resource.",
+			},
+			"uploads_deleted": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of uploads aborted by the last cleanup.",
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			// Delete makes no API call, so it takes no timeout
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *BucketUploadCleanupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BucketUploadCleanupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	validateTokenScope(ctx, r.client, resourceScopes{
+		Create: []string{"CleanupIncompleteUploads"},
+		Update: []string{"CleanupIncompleteUploads"},
+	}, req, resp)
+}
+
+func (r *BucketUploadCleanupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BucketUploadCleanupResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	r.cleanup(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.BucketID
+
+	tflog.Trace(ctx, "Created bucket upload cleanup resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketUploadCleanupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BucketUploadCleanupResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	bucketID := data.BucketID.ValueString()
+	bucket, err := r.client.GetBucketInfo(ctx, client.GetBucketInfoRequest{ID: &bucketID})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read bucket", err)
+		return
+	}
+
+	// There is nothing left to clean up once the bucket is gone
+	if bucket == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketUploadCleanupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data BucketUploadCleanupResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	r.cleanup(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Updated bucket upload cleanup resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketUploadCleanupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Nothing to undo: aborted uploads cannot be restored
+	tflog.Trace(ctx, "Deleted bucket upload cleanup resource")
+}
+
+// cleanup aborts the uploads older than the configured age and records how
+// many were aborted.
+func (r *BucketUploadCleanupResource) cleanup(ctx context.Context, data *BucketUploadCleanupResourceModel, diags *diag.Diagnostics) {
+	olderThan, err := time.ParseDuration(data.OlderThan.ValueString())
+	if err != nil {
+		diags.AddError("Invalid Duration", fmt.Sprintf("Unable to parse older_than: %s", err))
+		return
+	}
+
+	tflog.Debug(ctx, "Cleaning up incomplete uploads", map[string]interface{}{
+		"bucket_id":  data.BucketID.ValueString(),
+		"older_than": data.OlderThan.ValueString(),
+	})
+
+	result, err := r.client.CleanupIncompleteUploads(ctx, client.CleanupIncompleteUploadsRequest{
+		BucketID:      data.BucketID.ValueString(),
+		OlderThanSecs: int64(olderThan.Seconds()),
+	})
+	if err != nil {
+		addClientError(diags, "clean up incomplete uploads", err)
+		return
+	}
+
+	data.UploadsDeleted = types.Int64Value(result.UploadsDeleted)
+}
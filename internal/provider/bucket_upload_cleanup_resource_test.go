@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBucketUploadCleanupResource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Clean up a bucket without uploads
+			{
+				Config: testAccBucketUploadCleanupResourceConfig("test-bucket-upload-cleanup", "1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("garage_bucket_upload_cleanup.test", "id", "garage_bucket.test", "id"),
+					resource.TestCheckResourceAttr("garage_bucket_upload_cleanup.test", "older_than", "24h"),
+					resource.TestCheckResourceAttr("garage_bucket_upload_cleanup.test", "uploads_deleted", "0"),
+				),
+			},
+			// Changing the triggers runs the cleanup again
+			{
+				Config: testAccBucketUploadCleanupResourceConfig("test-bucket-upload-cleanup", "2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_bucket_upload_cleanup.test", "triggers.run", "2"),
+					resource.TestCheckResourceAttr("garage_bucket_upload_cleanup.test", "uploads_deleted", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccBucketUploadCleanupResourceConfig(alias, run string) string {
+	return fmt.Sprintf(`
+resource "garage_bucket" "test" {
+  global_alias = %[1]q
+}
+
+resource "garage_bucket_upload_cleanup" "test" {
+  bucket_id = garage_bucket.test.id
+
+  triggers = {
+    run = %[2]q
+  }
+}
+`, alias, run)
+}
@@ -0,0 +1,294 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BucketWebsiteResource{}
+var _ resource.ResourceWithImportState = &BucketWebsiteResource{}
+var _ resource.ResourceWithModifyPlan = &BucketWebsiteResource{}
+
+func NewBucketWebsiteResource() resource.Resource {
+	return &BucketWebsiteResource{}
+}
+
+// BucketWebsiteResource defines the resource implementation.
+type BucketWebsiteResource struct {
+	client *client.Client
+}
+
+// BucketWebsiteResourceModel describes the resource data model.
+type BucketWebsiteResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	BucketID      types.String `tfsdk:"bucket_id"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
+	IndexDocument types.String `tfsdk:"index_document"`
+	ErrorDocument types.String `tfsdk:"error_document"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *BucketWebsiteResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_bucket_website"
+}
+
+func (r *BucketWebsiteResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages website hosting on an existing Garage bucket. Destroying the resource disables website hosting on the bucket.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the website configuration (same as `bucket_id`).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"bucket_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the bucket.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether the bucket is served as a website. Defaults to `true`.",
+			},
+			"index_document": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("index.html"),
+				MarkdownDescription: "The index document for the website. Defaults to 'index.html'.",
+			},
+			"error_document": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The error document for the website (e.g., 'error.html').",
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *BucketWebsiteResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BucketWebsiteResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	validateTokenScope(ctx, r.client, resourceScopes{
+		Create: []string{"UpdateBucket"},
+		Update: []string{"UpdateBucket"},
+		Delete: []string{"UpdateBucket"},
+	}, req, resp)
+}
+
+func (r *BucketWebsiteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data BucketWebsiteResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	r.apply(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Created bucket website resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketWebsiteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data BucketWebsiteResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	bucketID := data.BucketID.ValueString()
+	bucket, err := r.client.GetBucketInfo(ctx, client.GetBucketInfoRequest{ID: &bucketID})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read bucket", err)
+		return
+	}
+
+	if bucket == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.ID = types.StringValue(bucket.ID)
+	data.Enabled = types.BoolValue(bucket.WebsiteAccess)
+
+	// Garage forgets the documents of a disabled website, keep the configured ones
+	if bucket.WebsiteConfig != nil {
+		data.IndexDocument = types.StringValue(bucket.WebsiteConfig.IndexDocument)
+		if bucket.WebsiteConfig.ErrorDocument != "" {
+			data.ErrorDocument = types.StringValue(bucket.WebsiteConfig.ErrorDocument)
+		} else {
+			data.ErrorDocument = types.StringNull()
+		}
+	} else if data.IndexDocument.IsNull() {
+		// Imported from a bucket without website hosting
+		data.IndexDocument = types.StringValue("index.html")
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketWebsiteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data BucketWebsiteResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	r.apply(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Updated bucket website resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketWebsiteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data BucketWebsiteResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, defaultDeleteTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	tflog.Debug(ctx, "Disabling bucket website", map[string]interface{}{
+		"bucket_id": data.BucketID.ValueString(),
+	})
+
+	_, err := r.client.UpdateBucket(ctx, data.BucketID.ValueString(), client.UpdateBucketRequest{
+		WebsiteAccess: &client.UpdateBucketWebsiteAccess{Enabled: false},
+	})
+	if err != nil {
+		// The bucket may have been deleted along with its website
+		if isNoSuchBucketError(err) {
+			return
+		}
+		addClientError(&resp.Diagnostics, "disable bucket website", err)
+		return
+	}
+
+	tflog.Trace(ctx, "Deleted bucket website resource")
+}
+
+func (r *BucketWebsiteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket_id"), req.ID)...)
+}
+
+// apply sets the website settings of the bucket from the model.
+func (r *BucketWebsiteResource) apply(ctx context.Context, data *BucketWebsiteResourceModel, diags *diag.Diagnostics) {
+	bucketID := data.BucketID.ValueString()
+
+	tflog.Debug(ctx, "Configuring bucket website", map[string]interface{}{
+		"bucket_id": bucketID,
+		"enabled":   data.Enabled.ValueBool(),
+	})
+
+	_, err := r.client.UpdateBucket(ctx, bucketID, client.UpdateBucketRequest{
+		WebsiteAccess: bucketWebsiteAccess(*data),
+	})
+	if err != nil {
+		addClientError(diags, "configure bucket website", err)
+		return
+	}
+
+	data.ID = types.StringValue(bucketID)
+}
+
+// bucketWebsiteAccess builds the website settings of the bucket from the
+// model. Garage only accepts documents while website hosting is enabled.
+func bucketWebsiteAccess(data BucketWebsiteResourceModel) *client.UpdateBucketWebsiteAccess {
+	websiteAccess := &client.UpdateBucketWebsiteAccess{
+		Enabled: data.Enabled.ValueBool(),
+	}
+
+	if !websiteAccess.Enabled {
+		return websiteAccess
+	}
+
+	indexDoc := data.IndexDocument.ValueString()
+	websiteAccess.IndexDocument = &indexDoc
+
+	if !data.ErrorDocument.IsNull() {
+		errorDoc := data.ErrorDocument.ValueString()
+		websiteAccess.ErrorDocument = &errorDoc
+	}
+
+	return websiteAccess
+}
+
+// isNoSuchBucketError reports whether err is Garage's error for an unknown bucket.
+func isNoSuchBucketError(err error) bool {
+	var apiErr *client.APIError
+	return errors.As(err, &apiErr) && apiErr.Code == "NoSuchBucket"
+}
@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBucketWebsiteResource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBucketWebsiteResourceConfig_basic("test-bucket-website", true, "error.html"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("garage_bucket_website.test", "id", "garage_bucket.test", "id"),
+					resource.TestCheckResourceAttr("garage_bucket_website.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("garage_bucket_website.test", "index_document", "index.html"),
+					resource.TestCheckResourceAttr("garage_bucket_website.test", "error_document", "error.html"),
+				),
+			},
+			{
+				ResourceName:      "garage_bucket_website.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Turn website hosting off without destroying the resource
+			{
+				Config: testAccBucketWebsiteResourceConfig_basic("test-bucket-website", false, "error.html"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_bucket_website.test", "enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestBucketWebsiteAccess(t *testing.T) {
+	enabled := bucketWebsiteAccess(BucketWebsiteResourceModel{
+		Enabled:       types.BoolValue(true),
+		IndexDocument: types.StringValue("index.html"),
+		ErrorDocument: types.StringValue("404.html"),
+	})
+	if !enabled.Enabled || enabled.IndexDocument == nil || *enabled.IndexDocument != "index.html" ||
+		enabled.ErrorDocument == nil || *enabled.ErrorDocument != "404.html" {
+		t.Errorf("unexpected website access for an enabled website: %+v", enabled)
+	}
+
+	withoutError := bucketWebsiteAccess(BucketWebsiteResourceModel{
+		Enabled:       types.BoolValue(true),
+		IndexDocument: types.StringValue("index.html"),
+		ErrorDocument: types.StringNull(),
+	})
+	if withoutError.ErrorDocument != nil {
+		t.Errorf("expected no error document, got %s", *withoutError.ErrorDocument)
+	}
+
+	disabled := bucketWebsiteAccess(BucketWebsiteResourceModel{
+		Enabled:       types.BoolValue(false),
+		IndexDocument: types.StringValue("index.html"),
+		ErrorDocument: types.StringValue("404.html"),
+	})
+	if disabled.Enabled || disabled.IndexDocument != nil || disabled.ErrorDocument != nil {
+		t.Errorf("expected no documents for a disabled website: %+v", disabled)
+	}
+}
+
+func testAccBucketWebsiteResourceConfig_basic(name string, enabled bool, errorDoc string) string {
+	return fmt.Sprintf(`
+resource "garage_bucket" "test" {
+  global_alias = %[1]q
+
+  lifecycle {
+    ignore_changes = [website_enabled, website_index_document, website_error_document]
+  }
+}
+
+resource "garage_bucket_website" "test" {
+  bucket_id      = garage_bucket.test.id
+  enabled        = %[2]t
+  error_document = %[3]q
+}
+`, name, enabled, errorDoc)
+}
@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// NewProtocol6Server returns a factory of protocol 6 servers for the
+// provider, which summarize the Admin API calls made by each resource and
+// data source operation once it completes.
+func NewProtocol6Server(version string) func() tfprotov6.ProviderServer {
+	return func() tfprotov6.ProviderServer {
+		p := New(version)().(*GarageProvider)
+
+		return &callSummaryServer{
+			ProviderServer: providerserver.NewProtocol6(p)(),
+			provider:       p,
+		}
+	}
+}
+
+// callSummaryServer wraps the provider server, recording the Admin API calls
+// of each operation so they can be logged, and reported as a warning when
+// call_summary is enabled. The framework has no hook at the end of a plan or
+// apply, so operations are summarized one by one.
+type callSummaryServer struct {
+	tfprotov6.ProviderServer
+	provider *GarageProvider
+}
+
+func (s *callSummaryServer) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRequest) (*tfprotov6.ReadResourceResponse, error) {
+	stats := &client.CallStats{}
+	resp, err := s.ProviderServer.ReadResource(client.ContextWithCallStats(ctx, stats), req)
+	if resp != nil {
+		resp.Diagnostics = s.summarize(ctx, req.TypeName, "read", stats, resp.Diagnostics)
+	}
+	return resp, err
+}
+
+func (s *callSummaryServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	stats := &client.CallStats{}
+	resp, err := s.ProviderServer.PlanResourceChange(client.ContextWithCallStats(ctx, stats), req)
+	if resp != nil {
+		resp.Diagnostics = s.summarize(ctx, req.TypeName, "plan", stats, resp.Diagnostics)
+	}
+	return resp, err
+}
+
+func (s *callSummaryServer) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyResourceChangeRequest) (*tfprotov6.ApplyResourceChangeResponse, error) {
+	stats := &client.CallStats{}
+	resp, err := s.ProviderServer.ApplyResourceChange(client.ContextWithCallStats(ctx, stats), req)
+	if resp != nil {
+		resp.Diagnostics = s.summarize(ctx, req.TypeName, "apply", stats, resp.Diagnostics)
+	}
+	return resp, err
+}
+
+func (s *callSummaryServer) ImportResourceState(ctx context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
+	stats := &client.CallStats{}
+	resp, err := s.ProviderServer.ImportResourceState(client.ContextWithCallStats(ctx, stats), req)
+	if resp != nil {
+		resp.Diagnostics = s.summarize(ctx, req.TypeName, "import", stats, resp.Diagnostics)
+	}
+	return resp, err
+}
+
+func (s *callSummaryServer) ReadDataSource(ctx context.Context, req *tfprotov6.ReadDataSourceRequest) (*tfprotov6.ReadDataSourceResponse, error) {
+	stats := &client.CallStats{}
+	resp, err := s.ProviderServer.ReadDataSource(client.ContextWithCallStats(ctx, stats), req)
+	if resp != nil {
+		resp.Diagnostics = s.summarize(ctx, req.TypeName, "read", stats, resp.Diagnostics)
+	}
+	return resp, err
+}
+
+func (s *callSummaryServer) OpenEphemeralResource(ctx context.Context, req *tfprotov6.OpenEphemeralResourceRequest) (*tfprotov6.OpenEphemeralResourceResponse, error) {
+	stats := &client.CallStats{}
+	resp, err := s.ProviderServer.OpenEphemeralResource(client.ContextWithCallStats(ctx, stats), req)
+	if resp != nil {
+		resp.Diagnostics = s.summarize(ctx, req.TypeName, "open", stats, resp.Diagnostics)
+	}
+	return resp, err
+}
+
+// summarize logs the Admin API calls an operation made, along with the calls
+// made since the provider started, and adds them to diags as a warning when
+// call_summary is enabled. Operations that made no call are not reported.
+func (s *callSummaryServer) summarize(ctx context.Context, typeName, operation string, stats *client.CallStats, diags []*tfprotov6.Diagnostic) []*tfprotov6.Diagnostic {
+	calls, duration := stats.Total()
+	if calls == 0 {
+		return diags
+	}
+
+	endpoints := map[string]interface{}{}
+	for _, endpoint := range stats.Summary() {
+		endpoints[endpoint.Endpoint] = fmt.Sprintf("%d in %s", endpoint.Calls, endpoint.Duration.Round(time.Millisecond))
+	}
+
+	totalCalls, totalDuration := s.provider.callStats.Total()
+
+	tflog.Debug(ctx, "Garage Admin API call summary", map[string]interface{}{
+		"type_name":      typeName,
+		"operation":      operation,
+		"calls":          calls,
+		"duration":       duration.Round(time.Millisecond).String(),
+		"endpoints":      endpoints,
+		"total_calls":    totalCalls,
+		"total_duration": totalDuration.Round(time.Millisecond).String(),
+	})
+
+	if !s.provider.callSummary.Load() {
+		return diags
+	}
+
+	return append(diags, &tfprotov6.Diagnostic{
+		Severity: tfprotov6.DiagnosticSeverityWarning,
+		Summary:  "Garage Admin API Call Summary",
+		Detail:   callSummaryDetail(typeName, operation, stats, totalCalls, totalDuration),
+	})
+}
+
+// callSummaryDetail describes the calls of an operation, the endpoints that
+// took the longest first.
+func callSummaryDetail(typeName, operation string, stats *client.CallStats, totalCalls int, totalDuration time.Duration) string {
+	calls, duration := stats.Total()
+
+	var detail strings.Builder
+	fmt.Fprintf(&detail, "The %s %s made %s in %s:\n", typeName, operation, pluralCalls(calls), duration.Round(time.Millisecond))
+	for _, endpoint := range stats.Summary() {
+		fmt.Fprintf(&detail, "  %s: %s in %s\n", endpoint.Endpoint, pluralCalls(endpoint.Calls), endpoint.Duration.Round(time.Millisecond))
+	}
+	fmt.Fprintf(&detail, "\nThe provider made %s in %s since it started.", pluralCalls(totalCalls), totalDuration.Round(time.Millisecond))
+
+	return detail.String()
+}
+
+// pluralCalls formats a number of calls.
+func pluralCalls(calls int) string {
+	if calls == 1 {
+		return "1 call"
+	}
+	return fmt.Sprintf("%d calls", calls)
+}
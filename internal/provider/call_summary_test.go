@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"terraform-provider-garage/internal/client"
+)
+
+func TestCallSummaryServerSummarize(t *testing.T) {
+	p := New("test")().(*GarageProvider)
+	p.callStats.Record("ListBuckets", 2*time.Second)
+	server := &callSummaryServer{provider: p}
+
+	stats := &client.CallStats{}
+	stats.Record("GetBucketInfo", 30*time.Millisecond)
+	stats.Record("GetBucketInfo", 90*time.Millisecond)
+	stats.Record("UpdateBucket", 200*time.Millisecond)
+
+	if diags := server.summarize(context.Background(), "garage_bucket", "apply", stats, nil); len(diags) != 0 {
+		t.Fatalf("Expected no warning unless call_summary is enabled, got %+v", diags)
+	}
+
+	p.callSummary.Store(true)
+
+	if diags := server.summarize(context.Background(), "garage_bucket", "read", &client.CallStats{}, nil); len(diags) != 0 {
+		t.Fatalf("Expected no warning for an operation without calls, got %+v", diags)
+	}
+
+	diags := server.summarize(context.Background(), "garage_bucket", "apply", stats, nil)
+	if len(diags) != 1 || diags[0].Severity != tfprotov6.DiagnosticSeverityWarning {
+		t.Fatalf("Expected a warning, got %+v", diags)
+	}
+
+	want := "The garage_bucket apply made 3 calls in 320ms:\n" +
+		"  UpdateBucket: 1 call in 200ms\n" +
+		"  GetBucketInfo: 2 calls in 120ms\n" +
+		"\nThe provider made 1 call in 2s since it started."
+	if diags[0].Detail != want {
+		t.Errorf("Expected detail %q, got %q", want, diags[0].Detail)
+	}
+}
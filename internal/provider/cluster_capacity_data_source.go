@@ -0,0 +1,208 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// layoutPartitions is the number of partitions Garage splits the data into.
+const layoutPartitions = 256
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ClusterCapacityDataSource{}
+
+func NewClusterCapacityDataSource() datasource.DataSource {
+	return &ClusterCapacityDataSource{}
+}
+
+// ClusterCapacityDataSource defines the data source implementation.
+type ClusterCapacityDataSource struct {
+	client *client.Client
+}
+
+// ClusterCapacityDataSourceModel describes the data source data model.
+type ClusterCapacityDataSourceModel struct {
+	ReplicationFactor     types.Int64 `tfsdk:"replication_factor"`
+	RawCapacity           types.Int64 `tfsdk:"raw_capacity"`
+	UsableCapacity        types.Int64 `tfsdk:"usable_capacity"`
+	RawFreeSpace          types.Int64 `tfsdk:"raw_free_space"`
+	FreeSpace             types.Int64 `tfsdk:"free_space"`
+	NodesWithoutFreeSpace types.List  `tfsdk:"nodes_without_free_space"`
+}
+
+func (d *ClusterCapacityDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_capacity"
+}
+
+func (d *ClusterCapacityDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Estimates how much data the cluster can store, from the current layout and the free disk space reported by the storage nodes. " +
+			"All sizes are in bytes of data as seen by S3 clients, after replication, unless stated otherwise.",
+
+		Attributes: map[string]schema.Attribute{
+			"replication_factor": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of copies of each partition in the current layout.",
+			},
+			"raw_capacity": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The sum of the capacities assigned to the storage nodes, before replication.",
+			},
+			"usable_capacity": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The amount of data the layout can hold, given the capacities, zones and replication factor.",
+			},
+			"raw_free_space": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The sum of the free space on the data disks of the storage nodes, before replication.",
+			},
+			"free_space": schema.Int64Attribute{
+				Computed: true,
+				MarkdownDescription: "The amount of data that can still be written before the first storage node runs out of disk space. " +
+					"Only nodes in `nodes_without_free_space` are left out of the estimate.",
+			},
+			"nodes_without_free_space": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The storage nodes that did not report their free disk space, for instance because they are down.",
+			},
+		},
+	}
+}
+
+func (d *ClusterCapacityDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ClusterCapacityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ClusterCapacityDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	layout, err := d.client.GetClusterLayout(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read cluster layout", err)
+		return
+	}
+
+	status, err := d.client.GetClusterStatus(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read cluster status", err)
+		return
+	}
+
+	headroom := capacityHeadroom(layout, status)
+
+	if len(headroom.NodesWithoutFreeSpace) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Incomplete Capacity Estimate",
+			fmt.Sprintf("%d storage nodes did not report their free disk space, so free_space may be overestimated.", len(headroom.NodesWithoutFreeSpace)),
+		)
+	}
+
+	data.ReplicationFactor = types.Int64Value(headroom.ReplicationFactor)
+	data.RawCapacity = types.Int64Value(headroom.RawCapacity)
+	data.UsableCapacity = types.Int64Value(headroom.UsableCapacity)
+	data.RawFreeSpace = types.Int64Value(headroom.RawFreeSpace)
+	data.FreeSpace = types.Int64PointerValue(headroom.FreeSpace)
+
+	nodes, diags := types.ListValueFrom(ctx, types.StringType, headroom.NodesWithoutFreeSpace)
+	resp.Diagnostics.Append(diags...)
+	data.NodesWithoutFreeSpace = nodes
+
+	tflog.Trace(ctx, "Read cluster capacity data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// clusterHeadroom is the capacity estimate of a cluster. FreeSpace is nil
+// when no storage node reported its free disk space.
+type clusterHeadroom struct {
+	ReplicationFactor     int64
+	RawCapacity           int64
+	UsableCapacity        int64
+	RawFreeSpace          int64
+	FreeSpace             *int64
+	NodesWithoutFreeSpace []string
+}
+
+// capacityHeadroom estimates the capacity of the cluster. Data is spread
+// evenly over the partitions, so a node storing p partitions receives p/256
+// of every byte written, and the node that fills first bounds the free space.
+func capacityHeadroom(layout *client.ClusterLayout, status *client.ClusterStatus) clusterHeadroom {
+	available := make(map[string]int64, len(status.Nodes))
+	for _, node := range status.Nodes {
+		if node.IsUp && node.DataPartition != nil {
+			available[node.ID] = node.DataPartition.Available
+		}
+	}
+
+	headroom := clusterHeadroom{
+		UsableCapacity:        layout.PartitionSize * layoutPartitions,
+		NodesWithoutFreeSpace: []string{},
+	}
+
+	var storedPartitions int64
+	for _, role := range layout.Roles {
+		if role.Capacity == nil {
+			continue
+		}
+		headroom.RawCapacity += *role.Capacity
+
+		var partitions int64
+		if role.StoredPartitions != nil {
+			partitions = *role.StoredPartitions
+		}
+		storedPartitions += partitions
+
+		free, ok := available[role.ID]
+		if !ok {
+			headroom.NodesWithoutFreeSpace = append(headroom.NodesWithoutFreeSpace, role.ID)
+			continue
+		}
+		headroom.RawFreeSpace += free
+
+		if partitions == 0 {
+			continue
+		}
+		nodeHeadroom := free * layoutPartitions / partitions
+		if headroom.FreeSpace == nil || nodeHeadroom < *headroom.FreeSpace {
+			headroom.FreeSpace = &nodeHeadroom
+		}
+	}
+
+	headroom.ReplicationFactor = storedPartitions / layoutPartitions
+	sort.Strings(headroom.NodesWithoutFreeSpace)
+
+	return headroom
+}
@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-garage/internal/client"
+)
+
+func TestAccClusterCapacityDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterCapacityDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.garage_cluster_capacity.test", "replication_factor"),
+					resource.TestCheckResourceAttrSet("data.garage_cluster_capacity.test", "usable_capacity"),
+					resource.TestCheckResourceAttrSet("data.garage_cluster_capacity.test", "free_space"),
+					resource.TestCheckResourceAttr("data.garage_cluster_capacity.test", "nodes_without_free_space.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestCapacityHeadroom(t *testing.T) {
+	int64Ptr := func(v int64) *int64 { return &v }
+
+	layout := &client.ClusterLayout{
+		PartitionSize: 1000,
+		Roles: []client.LayoutNodeRole{
+			{ID: "a", Zone: "dc1", Capacity: int64Ptr(300_000), StoredPartitions: int64Ptr(256)},
+			{ID: "b", Zone: "dc2", Capacity: int64Ptr(200_000), StoredPartitions: int64Ptr(128)},
+			{ID: "c", Zone: "dc2", Capacity: int64Ptr(200_000), StoredPartitions: int64Ptr(128)},
+			{ID: "gw", Zone: "dc1"},
+		},
+	}
+
+	status := &client.ClusterStatus{
+		Nodes: []client.NodeStatus{
+			{ID: "a", IsUp: true, DataPartition: &client.FreeSpaceResponse{Available: 100_000, Total: 400_000}},
+			{ID: "b", IsUp: true, DataPartition: &client.FreeSpaceResponse{Available: 20_000, Total: 300_000}},
+			{ID: "c", IsUp: false},
+			{ID: "gw", IsUp: true},
+		},
+	}
+
+	headroom := capacityHeadroom(layout, status)
+
+	if headroom.ReplicationFactor != 2 {
+		t.Errorf("Expected replication factor 2, got %d", headroom.ReplicationFactor)
+	}
+	if headroom.RawCapacity != 700_000 {
+		t.Errorf("Expected raw capacity 700000, got %d", headroom.RawCapacity)
+	}
+	if headroom.UsableCapacity != 256_000 {
+		t.Errorf("Expected usable capacity 256000, got %d", headroom.UsableCapacity)
+	}
+	if headroom.RawFreeSpace != 120_000 {
+		t.Errorf("Expected raw free space 120000, got %d", headroom.RawFreeSpace)
+	}
+
+	// Node b holds half of the partitions, so it fills after 40000 bytes
+	if headroom.FreeSpace == nil || *headroom.FreeSpace != 40_000 {
+		t.Errorf("Expected free space 40000, got %v", headroom.FreeSpace)
+	}
+
+	if len(headroom.NodesWithoutFreeSpace) != 1 || headroom.NodesWithoutFreeSpace[0] != "c" {
+		t.Errorf("Expected node c without free space, got %v", headroom.NodesWithoutFreeSpace)
+	}
+}
+
+const testAccClusterCapacityDataSourceConfig = `
+data "garage_cluster_capacity" "test" {}
+`
@@ -0,0 +1,356 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// clusterLayoutID is the ID of the garage_cluster_layout resource, since a
+// cluster has a single layout.
+const clusterLayoutID = "cluster"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ClusterLayoutResource{}
+var _ resource.ResourceWithModifyPlan = &ClusterLayoutResource{}
+var _ resource.ResourceWithImportState = &ClusterLayoutResource{}
+
+func NewClusterLayoutResource() resource.Resource {
+	return &ClusterLayoutResource{}
+}
+
+// ClusterLayoutResource defines the resource implementation.
+type ClusterLayoutResource struct {
+	client *client.Client
+}
+
+// ClusterLayoutResourceModel describes the resource data model.
+type ClusterLayoutResourceModel struct {
+	ID             types.String                      `tfsdk:"id"`
+	Roles          map[string]ClusterLayoutRoleModel `tfsdk:"roles"`
+	ZoneRedundancy types.Int64                       `tfsdk:"zone_redundancy"`
+	Version        types.Int64                       `tfsdk:"version"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// ClusterLayoutRoleModel describes the role of a node in the layout.
+type ClusterLayoutRoleModel struct {
+	Zone     types.String `tfsdk:"zone"`
+	Capacity types.Int64  `tfsdk:"capacity"`
+	Tags     types.List   `tfsdk:"tags"`
+}
+
+func (r *ClusterLayoutResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_layout"
+}
+
+func (r *ClusterLayoutResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the whole layout of a Garage cluster: the role of every node and the zone redundancy. " +
+			"Every change is staged and applied as a new layout version. Nodes that have a role but are not listed in `roles` are removed from the layout, " +
+			"so this resource must not be combined with `garage_cluster_node_role`. Destroying the resource leaves the layout in place.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the layout, always `cluster`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"roles": schema.MapNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "The roles of the nodes of the cluster, keyed by full node ID.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"zone": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The zone the node belongs to.",
+						},
+						"capacity": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "The storage capacity of the node in bytes. Leave unset to make the node a gateway that stores no data.",
+						},
+						"tags": schema.ListAttribute{
+							Optional:            true,
+							Computed:            true,
+							ElementType:         types.StringType,
+							Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
+							MarkdownDescription: "Free-form labels attached to the node in the layout, such as its rack or room.",
+						},
+					},
+				},
+			},
+			"zone_redundancy": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The minimum number of zones each partition is stored in. Leave unset to spread partitions over as many zones as possible.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"version": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The version of the current cluster layout.",
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			// Delete makes no API call, so it takes no timeout
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *ClusterLayoutResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ClusterLayoutResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	validateTokenScope(ctx, r.client, resourceScopes{
+		Create: []string{"GetClusterLayout", "UpdateClusterLayout", "ApplyClusterLayout"},
+		Update: []string{"GetClusterLayout", "UpdateClusterLayout", "ApplyClusterLayout"},
+	}, req, resp)
+}
+
+func (r *ClusterLayoutResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ClusterLayoutResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	r.apply(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(clusterLayoutID)
+
+	tflog.Trace(ctx, "Created cluster layout resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterLayoutResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ClusterLayoutResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	layout, err := r.client.GetClusterLayout(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read cluster layout", err)
+		return
+	}
+
+	resp.Diagnostics.Append(data.fromLayout(ctx, layout)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterLayoutResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ClusterLayoutResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	r.apply(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Updated cluster layout resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterLayoutResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The layout is left in place: removing every role would take the whole
+	// cluster offline.
+	tflog.Trace(ctx, "Deleted cluster layout resource")
+}
+
+func (r *ClusterLayoutResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Any import ID adopts the layout of the cluster the provider talks to
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), clusterLayoutID)...)
+}
+
+// apply stages the changes needed for the layout to match the model and
+// applies them as a new layout version.
+func (r *ClusterLayoutResource) apply(ctx context.Context, data *ClusterLayoutResourceModel, diags *diag.Diagnostics) {
+	roles := make(map[string]client.NodeRoleChange, len(data.Roles))
+	for nodeID, role := range data.Roles {
+		var tags []string
+		diags.Append(role.Tags.ElementsAs(ctx, &tags, false)...)
+
+		roles[nodeID] = client.NodeRoleChange{
+			ID:       nodeID,
+			Zone:     role.Zone.ValueString(),
+			Capacity: role.Capacity.ValueInt64Pointer(),
+			Tags:     tags,
+		}
+	}
+	if diags.HasError() {
+		return
+	}
+
+	// Hold the lock until the update is applied, so that no other layout
+	// change lands between reading the layout and staging the difference
+	layoutMutex.Lock()
+	defer layoutMutex.Unlock()
+
+	layout, err := r.client.GetClusterLayout(ctx)
+	if err != nil {
+		addClientError(diags, "read cluster layout", err)
+		return
+	}
+
+	update := client.UpdateClusterLayoutRequest{
+		Roles: layoutChanges(layout, roles),
+	}
+
+	parameters := client.LayoutParameters{
+		ZoneRedundancy: client.ZoneRedundancy{AtLeast: data.ZoneRedundancy.ValueInt64Pointer()},
+	}
+	if !sameZoneRedundancy(layout.Parameters.ZoneRedundancy, parameters.ZoneRedundancy) {
+		update.Parameters = &parameters
+	}
+
+	if len(update.Roles) > 0 || update.Parameters != nil {
+		tflog.Debug(ctx, "Updating cluster layout", map[string]interface{}{
+			"role_changes":      len(update.Roles),
+			"parameters_change": update.Parameters != nil,
+		})
+
+		layout, err = applyLayoutUpdateLocked(ctx, r.client, update)
+		if err != nil {
+			addClientError(diags, "update cluster layout", err)
+			return
+		}
+	}
+
+	data.Version = types.Int64Value(layout.Version)
+}
+
+// fromLayout updates the model from the current cluster layout.
+func (m *ClusterLayoutResourceModel) fromLayout(ctx context.Context, layout *client.ClusterLayout) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.Roles = make(map[string]ClusterLayoutRoleModel, len(layout.Roles))
+	for _, role := range layout.Roles {
+		tags := role.Tags
+		if tags == nil {
+			tags = []string{}
+		}
+		tagList, d := types.ListValueFrom(ctx, types.StringType, tags)
+		diags.Append(d...)
+
+		m.Roles[role.ID] = ClusterLayoutRoleModel{
+			Zone:     types.StringValue(role.Zone),
+			Capacity: types.Int64PointerValue(role.Capacity),
+			Tags:     tagList,
+		}
+	}
+
+	m.ZoneRedundancy = types.Int64PointerValue(layout.Parameters.ZoneRedundancy.AtLeast)
+	m.Version = types.Int64Value(layout.Version)
+
+	return diags
+}
+
+// layoutChanges returns the role changes that make the layout match the
+// given roles: roles that differ are assigned and nodes that are not listed
+// are removed. Changes are sorted by node ID.
+func layoutChanges(layout *client.ClusterLayout, roles map[string]client.NodeRoleChange) []client.NodeRoleChange {
+	var changes []client.NodeRoleChange
+
+	for nodeID, role := range roles {
+		current := findLayoutRole(layout, nodeID)
+		if current != nil && current.Zone == role.Zone && sameCapacity(current.Capacity, role.Capacity) && sameTags(current.Tags, role.Tags) {
+			continue
+		}
+		changes = append(changes, role)
+	}
+
+	for _, role := range layout.Roles {
+		if _, ok := roles[role.ID]; !ok {
+			changes = append(changes, client.NodeRoleChange{ID: role.ID, Remove: true})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].ID < changes[j].ID })
+
+	return changes
+}
+
+func sameCapacity(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func sameTags(a, b []string) bool {
+	return slices.Equal(a, b)
+}
+
+func sameZoneRedundancy(a, b client.ZoneRedundancy) bool {
+	return sameCapacity(a.AtLeast, b.AtLeast)
+}
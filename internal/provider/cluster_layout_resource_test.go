@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-garage/internal/client"
+)
+
+func TestAccClusterLayoutResource_basic(t *testing.T) {
+	nodeID := os.Getenv("GARAGE_TEST_NODE_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckNode(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Declare the layout
+			{
+				Config: testAccClusterLayoutResourceConfig(nodeID, "test-zone", 1073741824),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_cluster_layout.test", "id", "cluster"),
+					resource.TestCheckResourceAttr("garage_cluster_layout.test", "roles.%", "1"),
+					resource.TestCheckResourceAttr("garage_cluster_layout.test", "roles."+nodeID+".zone", "test-zone"),
+					resource.TestCheckResourceAttr("garage_cluster_layout.test", "roles."+nodeID+".capacity", "1073741824"),
+					resource.TestCheckResourceAttrSet("garage_cluster_layout.test", "version"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "garage_cluster_layout.test",
+				ImportState:       true,
+				ImportStateId:     "cluster",
+				ImportStateVerify: true,
+			},
+			// Change the capacity in place
+			{
+				Config: testAccClusterLayoutResourceConfig(nodeID, "test-zone", 2147483648),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_cluster_layout.test", "roles."+nodeID+".capacity", "2147483648"),
+				),
+			},
+			// Destroying leaves the layout in place
+		},
+	})
+}
+
+func TestLayoutChanges(t *testing.T) {
+	capacity := int64(1000)
+	otherCapacity := int64(2000)
+
+	layout := &client.ClusterLayout{
+		Roles: []client.LayoutNodeRole{
+			{ID: "node-a", Zone: "dc1", Capacity: &capacity, Tags: []string{"rack-1"}},
+			{ID: "node-b", Zone: "dc1", Capacity: &capacity},
+			{ID: "node-c", Zone: "dc2", Capacity: &capacity},
+		},
+	}
+
+	changes := layoutChanges(layout, map[string]client.NodeRoleChange{
+		// Unchanged
+		"node-a": {ID: "node-a", Zone: "dc1", Capacity: &capacity, Tags: []string{"rack-1"}},
+		// New capacity
+		"node-b": {ID: "node-b", Zone: "dc1", Capacity: &otherCapacity},
+		// New node, as a gateway
+		"node-d": {ID: "node-d", Zone: "dc2"},
+	})
+
+	want := []client.NodeRoleChange{
+		{ID: "node-b", Zone: "dc1", Capacity: &otherCapacity},
+		{ID: "node-c", Remove: true},
+		{ID: "node-d", Zone: "dc2"},
+	}
+
+	if len(changes) != len(want) {
+		t.Fatalf("Expected %d changes, got %+v", len(want), changes)
+	}
+	for i := range want {
+		got := changes[i]
+		if got.ID != want[i].ID || got.Remove != want[i].Remove || got.Zone != want[i].Zone || !sameCapacity(got.Capacity, want[i].Capacity) {
+			t.Errorf("Expected change %+v at position %d, got %+v", want[i], i, got)
+		}
+	}
+}
+
+func testAccClusterLayoutResourceConfig(nodeID, zone string, capacity int64) string {
+	return fmt.Sprintf(`
+resource "garage_cluster_layout" "test" {
+  roles = {
+    %[1]q = {
+      zone     = %[2]q
+      capacity = %[3]d
+    }
+  }
+}
+`, nodeID, zone, capacity)
+}
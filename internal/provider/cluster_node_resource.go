@@ -0,0 +1,510 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ClusterNodeResource{}
+var _ resource.ResourceWithImportState = &ClusterNodeResource{}
+var _ resource.ResourceWithModifyPlan = &ClusterNodeResource{}
+
+func NewClusterNodeResource() resource.Resource {
+	return &ClusterNodeResource{}
+}
+
+// ClusterNodeResource defines the resource implementation.
+type ClusterNodeResource struct {
+	client *client.Client
+}
+
+// ClusterNodeResourceModel describes the resource data model.
+type ClusterNodeResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	Address   types.String `tfsdk:"address"`
+	Addresses types.List   `tfsdk:"addresses"`
+	Connected types.Bool   `tfsdk:"connected"`
+	Nodes     types.List   `tfsdk:"nodes"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// ClusterNodeConnectionModel describes the connection of one of the nodes.
+type ClusterNodeConnectionModel struct {
+	Address   types.String `tfsdk:"address"`
+	ID        types.String `tfsdk:"id"`
+	Connected types.Bool   `tfsdk:"connected"`
+	Error     types.String `tfsdk:"error"`
+}
+
+// clusterNodeConnectionAttributeTypes are the attribute types of an element
+// of the nodes attribute.
+var clusterNodeConnectionAttributeTypes = map[string]attr.Type{
+	"address":   types.StringType,
+	"id":        types.StringType,
+	"connected": types.BoolType,
+	"error":     types.StringType,
+}
+
+func (r *ClusterNodeResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_node"
+}
+
+func (r *ClusterNodeResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Connects one or several nodes to the cluster, so that they can be given a role in the same apply. " +
+			"A node that is no longer connected is connected again on the next apply. Destroying the resource does not disconnect the nodes.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The full ID of the node. With `addresses`, the IDs of the nodes separated by commas.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"address": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The address of the node, in the form `<node ID>@<host>:<port>` printed by `garage node id`. The apply fails if the node cannot be connected. Exactly one of `address` and `addresses` must be set.",
+				Validators: []validator.String{
+					nodeAddress(),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("addresses")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"addresses": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				MarkdownDescription: "The addresses of several nodes, connected in a single call. A node that cannot be connected does not fail the apply: " +
+					"its error is reported in `nodes`, and it is connected again on the next apply.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(nodeAddress()),
+				},
+			},
+			"connected": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the cluster currently reaches the node, or all the nodes with `addresses`.",
+			},
+			"nodes": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The result of connecting each node, in the order of `addresses` (or the single `address`).",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"address": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The address of the node.",
+						},
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The full ID of the node.",
+						},
+						"connected": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the cluster reaches the node.",
+						},
+						"error": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Why the node could not be connected, or null.",
+						},
+					},
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			// Delete makes no API call, so it takes no timeout
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *ClusterNodeResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ClusterNodeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	validateTokenScope(ctx, r.client, resourceScopes{
+		Create: []string{"ConnectClusterNodes", "GetClusterStatus"},
+		Update: []string{"ConnectClusterNodes", "GetClusterStatus"},
+	}, req, resp)
+
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data ClusterNodeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A single node is always expected to be connected, so a node that was
+	// found disconnected by the last refresh plans an update that reconnects it
+	if data.Addresses.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("connected"), true)...)
+		return
+	}
+
+	known := !data.Addresses.IsUnknown()
+	for _, address := range data.Addresses.Elements() {
+		known = known && !address.IsUnknown()
+	}
+	if !known {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+		return
+	}
+
+	// Connecting several nodes may leave some of them disconnected, so the
+	// result is only known after the apply. The IDs are known from the
+	// addresses.
+	addresses, diags := clusterNodeAddresses(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), clusterNodeIDs(addresses))...)
+
+	var state ClusterNodeResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if req.State.Raw.IsNull() || !state.Connected.ValueBool() || !state.Addresses.Equal(data.Addresses) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("connected"), types.BoolUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("nodes"), types.ListUnknown(types.ObjectType{AttrTypes: clusterNodeConnectionAttributeTypes}))...)
+	}
+}
+
+func (r *ClusterNodeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ClusterNodeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	r.connect(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Created cluster node resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterNodeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ClusterNodeResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	if !data.Addresses.IsNull() {
+		r.readNodes(ctx, &data, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	nodeID, err := nodeAddressID(data.Address.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("address"), "Invalid Node Address", err.Error())
+		return
+	}
+
+	status, err := r.client.GetClusterStatus(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read cluster status", err)
+		return
+	}
+
+	node := findNodeStatus(status, nodeID)
+	if node == nil {
+		// The cluster has forgotten the node, connect it again
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if !node.IsUp {
+		tflog.Debug(ctx, "Cluster node is disconnected", map[string]interface{}{
+			"node_id":            nodeID,
+			"last_seen_secs_ago": node.LastSeenSecsAgo,
+		})
+	}
+
+	data.ID = types.StringValue(nodeID)
+	data.Connected = types.BoolValue(node.IsUp)
+
+	var connectError *string
+	if !node.IsUp {
+		message := "the node is disconnected"
+		connectError = &message
+	}
+	nodes, diags := clusterNodeConnectionsValue(ctx, []ClusterNodeConnectionModel{
+		clusterNodeConnection(data.Address.ValueString(), nodeID, node.IsUp, connectError),
+	})
+	resp.Diagnostics.Append(diags...)
+	data.Nodes = nodes
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterNodeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ClusterNodeResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	r.connect(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Updated cluster node resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterNodeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Garage has no way to disconnect a node: it leaves the cluster when it is
+	// shut down, or is kept out of the data path by removing its layout role.
+	tflog.Trace(ctx, "Deleted cluster node resource")
+}
+
+func (r *ClusterNodeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("address"), req, resp)
+}
+
+// connect asks the cluster to connect to the nodes and records the result.
+// With a single address, failing to connect the node is an error. With
+// addresses, the nodes that could not be connected are only reported.
+func (r *ClusterNodeResource) connect(ctx context.Context, data *ClusterNodeResourceModel, diags *diag.Diagnostics) {
+	addresses, d := clusterNodeAddresses(ctx, *data)
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+
+	for _, address := range addresses {
+		if _, err := nodeAddressID(address); err != nil {
+			diags.AddError("Invalid Node Address", err.Error())
+			return
+		}
+	}
+
+	tflog.Debug(ctx, "Connecting cluster nodes", map[string]interface{}{
+		"addresses": addresses,
+	})
+
+	results, err := r.client.ConnectClusterNodes(ctx, addresses)
+	if err != nil {
+		addClientError(diags, "connect cluster node", err)
+		return
+	}
+
+	if len(results) != len(addresses) {
+		diags.AddError(
+			"Unexpected Connect Result",
+			fmt.Sprintf("Expected the result of connecting %d nodes, got %d.", len(addresses), len(results)),
+		)
+		return
+	}
+
+	connections := make([]ClusterNodeConnectionModel, 0, len(addresses))
+	var failed []string
+	for i, address := range addresses {
+		nodeID, _ := nodeAddressID(address)
+
+		connectError := results[i].Error
+		if !results[i].Success && connectError == nil {
+			message := "unknown error"
+			connectError = &message
+		}
+		if !results[i].Success {
+			failed = append(failed, fmt.Sprintf("%s: %s", address, *connectError))
+		}
+
+		connections = append(connections, clusterNodeConnection(address, nodeID, results[i].Success, connectError))
+	}
+
+	switch {
+	case len(failed) > 0 && data.Addresses.IsNull():
+		diags.AddError(
+			"Unable to Connect Node",
+			fmt.Sprintf("The cluster could not connect to node %s. Check that the node is running and that its RPC port is reachable from the cluster.", failed[0]),
+		)
+		return
+	case len(failed) > 0:
+		diags.AddWarning(
+			"Unable to Connect Nodes",
+			fmt.Sprintf("The cluster could not connect to some of the nodes, which will be connected again on the next apply. "+
+				"Check that they are running and that their RPC port is reachable from the cluster.\n\n%s", strings.Join(failed, "\n")),
+		)
+	}
+
+	nodes, d := clusterNodeConnectionsValue(ctx, connections)
+	diags.Append(d...)
+
+	data.ID = types.StringValue(clusterNodeIDs(addresses))
+	data.Connected = types.BoolValue(len(failed) == 0)
+	data.Nodes = nodes
+}
+
+// readNodes refreshes whether the cluster reaches each of the nodes of
+// addresses.
+func (r *ClusterNodeResource) readNodes(ctx context.Context, data *ClusterNodeResourceModel, diags *diag.Diagnostics) {
+	addresses, d := clusterNodeAddresses(ctx, *data)
+	diags.Append(d...)
+	if diags.HasError() {
+		return
+	}
+
+	status, err := r.client.GetClusterStatus(ctx)
+	if err != nil {
+		addClientError(diags, "read cluster status", err)
+		return
+	}
+
+	connections := make([]ClusterNodeConnectionModel, 0, len(addresses))
+	connected := true
+	for _, address := range addresses {
+		nodeID, err := nodeAddressID(address)
+		if err != nil {
+			diags.AddAttributeError(path.Root("addresses"), "Invalid Node Address", err.Error())
+			return
+		}
+
+		var connectError *string
+		node := findNodeStatus(status, nodeID)
+		switch {
+		case node == nil:
+			message := "the cluster does not know the node"
+			connectError = &message
+		case !node.IsUp:
+			message := "the node is disconnected"
+			connectError = &message
+		}
+
+		connected = connected && connectError == nil
+		connections = append(connections, clusterNodeConnection(address, nodeID, connectError == nil, connectError))
+	}
+
+	nodes, d := clusterNodeConnectionsValue(ctx, connections)
+	diags.Append(d...)
+
+	data.ID = types.StringValue(clusterNodeIDs(addresses))
+	data.Connected = types.BoolValue(connected)
+	data.Nodes = nodes
+}
+
+// clusterNodeAddresses returns the addresses of the nodes to connect, from
+// either address or addresses.
+func clusterNodeAddresses(ctx context.Context, data ClusterNodeResourceModel) ([]string, diag.Diagnostics) {
+	if data.Addresses.IsNull() {
+		return []string{data.Address.ValueString()}, nil
+	}
+
+	var addresses []string
+	diags := data.Addresses.ElementsAs(ctx, &addresses, false)
+	return addresses, diags
+}
+
+// clusterNodeIDs returns the IDs of the nodes at addresses, separated by
+// commas. Invalid addresses are reported when connecting.
+func clusterNodeIDs(addresses []string) string {
+	ids := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		nodeID, _ := nodeAddressID(address)
+		ids = append(ids, nodeID)
+	}
+	return strings.Join(ids, ",")
+}
+
+// clusterNodeConnection returns the connection result of a node.
+func clusterNodeConnection(address, nodeID string, connected bool, connectError *string) ClusterNodeConnectionModel {
+	return ClusterNodeConnectionModel{
+		Address:   types.StringValue(address),
+		ID:        types.StringValue(nodeID),
+		Connected: types.BoolValue(connected),
+		Error:     types.StringPointerValue(connectError),
+	}
+}
+
+// clusterNodeConnectionsValue converts connection results to the value of the
+// nodes attribute.
+func clusterNodeConnectionsValue(ctx context.Context, connections []ClusterNodeConnectionModel) (types.List, diag.Diagnostics) {
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: clusterNodeConnectionAttributeTypes}, connections)
+}
+
+// findNodeStatus returns the status of the node with the given ID, or nil if
+// the cluster does not know the node.
+func findNodeStatus(status *client.ClusterStatus, nodeID string) *client.NodeStatus {
+	for i := range status.Nodes {
+		if status.Nodes[i].ID == nodeID {
+			return &status.Nodes[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+
+	"terraform-provider-garage/internal/client"
+)
+
+func TestAccClusterNodeResource_basic(t *testing.T) {
+	address := os.Getenv("GARAGE_TEST_NODE_ADDRESS")
+	nodeID, _, _ := strings.Cut(address, "@")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckNodeAddress(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Connect the node
+			{
+				Config: testAccClusterNodeResourceConfig(address),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_cluster_node.test", "id", nodeID),
+					resource.TestCheckResourceAttr("garage_cluster_node.test", "address", address),
+					resource.TestCheckResourceAttr("garage_cluster_node.test", "connected", "true"),
+				),
+			},
+			// A connected node plans no changes
+			{
+				Config: testAccClusterNodeResourceConfig(address),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "garage_cluster_node.test",
+				ImportState:                          true,
+				ImportStateId:                        address,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "address",
+			},
+		},
+	})
+}
+
+func TestAccClusterNodeResource_addresses(t *testing.T) {
+	address := os.Getenv("GARAGE_TEST_NODE_ADDRESS")
+	nodeID, _, _ := strings.Cut(address, "@")
+
+	// No node listens on this address
+	unreachableID := strings.Repeat("0", 64)
+	unreachable := unreachableID + "@127.0.0.1:1"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckNodeAddress(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// An unreachable node does not fail the apply
+			{
+				Config: testAccClusterNodeResourceConfig_addresses(address, unreachable),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_cluster_node.test", "id", nodeID+","+unreachableID),
+					resource.TestCheckResourceAttr("garage_cluster_node.test", "connected", "false"),
+					resource.TestCheckResourceAttr("garage_cluster_node.test", "nodes.#", "2"),
+					resource.TestCheckResourceAttr("garage_cluster_node.test", "nodes.0.connected", "true"),
+					resource.TestCheckNoResourceAttr("garage_cluster_node.test", "nodes.0.error"),
+					resource.TestCheckResourceAttr("garage_cluster_node.test", "nodes.1.id", unreachableID),
+					resource.TestCheckResourceAttr("garage_cluster_node.test", "nodes.1.connected", "false"),
+					resource.TestCheckResourceAttrSet("garage_cluster_node.test", "nodes.1.error"),
+				),
+			},
+			// Once every node is connected, the plan is empty
+			{
+				Config: testAccClusterNodeResourceConfig_addresses(address),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_cluster_node.test", "connected", "true"),
+					resource.TestCheckResourceAttr("garage_cluster_node.test", "nodes.#", "1"),
+				),
+			},
+			{
+				Config: testAccClusterNodeResourceConfig_addresses(address),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestClusterNodeIDs(t *testing.T) {
+	addresses := []string{
+		strings.Repeat("a", 64) + "@10.0.0.1:3901",
+		strings.Repeat("b", 64) + "@[fd00::2]:3901",
+	}
+
+	want := strings.Repeat("a", 64) + "," + strings.Repeat("b", 64)
+	if got := clusterNodeIDs(addresses); got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func TestFindNodeStatus(t *testing.T) {
+	status := &client.ClusterStatus{
+		Nodes: []client.NodeStatus{
+			{ID: "node-1", IsUp: true},
+			{ID: "node-2", IsUp: false},
+		},
+	}
+
+	if node := findNodeStatus(status, "node-2"); node == nil || node.IsUp {
+		t.Errorf("Expected disconnected node-2, got %+v", node)
+	}
+
+	if node := findNodeStatus(status, "node-3"); node != nil {
+		t.Errorf("Expected no status for unknown node-3, got %+v", node)
+	}
+}
+
+func testAccClusterNodeResourceConfig(address string) string {
+	return fmt.Sprintf(`
+resource "garage_cluster_node" "test" {
+  address = %[1]q
+}
+`, address)
+}
+
+func testAccClusterNodeResourceConfig_addresses(addresses ...string) string {
+	quoted := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		quoted = append(quoted, fmt.Sprintf("%q", address))
+	}
+
+	return fmt.Sprintf(`
+resource "garage_cluster_node" "test" {
+  addresses = [%s]
+}
+`, strings.Join(quoted, ", "))
+}
@@ -0,0 +1,480 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// layoutMutex serializes layout changes made by this provider, since staged
+// changes are shared cluster-wide and applying them bumps the layout version.
+var layoutMutex sync.Mutex
+
+// drainPollInterval is the delay between cluster status checks while waiting
+// for a removed node to drain.
+var drainPollInterval = 10 * time.Second
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ClusterNodeRoleResource{}
+var _ resource.ResourceWithModifyPlan = &ClusterNodeRoleResource{}
+var _ resource.ResourceWithImportState = &ClusterNodeRoleResource{}
+
+func NewClusterNodeRoleResource() resource.Resource {
+	return &ClusterNodeRoleResource{}
+}
+
+// ClusterNodeRoleResource defines the resource implementation.
+type ClusterNodeRoleResource struct {
+	client *client.Client
+}
+
+// ClusterNodeRoleResourceModel describes the resource data model.
+type ClusterNodeRoleResourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	NodeID       types.String `tfsdk:"node_id"`
+	Zone         types.String `tfsdk:"zone"`
+	Capacity     types.Int64  `tfsdk:"capacity"`
+	Tags         types.List   `tfsdk:"tags"`
+	WaitForDrain types.Bool   `tfsdk:"wait_for_drain"`
+	DrainTimeout types.String `tfsdk:"drain_timeout"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *ClusterNodeRoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_node_role"
+}
+
+func (r *ClusterNodeRoleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the layout role of a single Garage node. Every change is staged and applied as a new layout version. " +
+			"Destroying the resource decommissions the node: its role is removed, the layout is applied and, optionally, Terraform waits until the node has drained its data.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the node role (same as `node_id`).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"node_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The full ID of the node.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"zone": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The zone the node belongs to.",
+			},
+			"capacity": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The storage capacity of the node in bytes. Leave unset to make the node a gateway that stores no data.",
+			},
+			"tags": schema.ListAttribute{
+				Optional:            true,
+				Computed:            true,
+				ElementType:         types.StringType,
+				Default:             listdefault.StaticValue(types.ListValueMust(types.StringType, []attr.Value{})),
+				MarkdownDescription: "Free-form labels attached to the node in the layout, such as its rack or room.",
+			},
+			"wait_for_drain": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "When destroying, wait until the node has handed its data over to the remaining nodes before completing.",
+			},
+			"drain_timeout": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("1h"),
+				MarkdownDescription: "How long to wait for the node to drain when `wait_for_drain` is set, as a Go duration (e.g., '30m'). Extends the delete timeout when it is longer. Defaults to '1h'.",
+				Validators: []validator.String{
+					positiveDuration(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *ClusterNodeRoleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ClusterNodeRoleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	validateTokenScope(ctx, r.client, resourceScopes{
+		Create: []string{"UpdateClusterLayout", "ApplyClusterLayout"},
+		Update: []string{"UpdateClusterLayout", "ApplyClusterLayout"},
+		Delete: []string{"UpdateClusterLayout", "ApplyClusterLayout", "GetClusterStatus"},
+	}, req, resp)
+
+	r.previewCapacityChange(ctx, req, resp)
+}
+
+// previewCapacityChange shows the capacity deltas of the planned role change
+// as a warning, so that the amount of data a layout change will move is
+// visible before it is applied.
+func (r *ClusterNodeRoleResource) previewCapacityChange(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || req.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+
+	var change client.NodeRoleChange
+
+	if req.Plan.Raw.IsNull() {
+		var state ClusterNodeRoleResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		change = client.NodeRoleChange{ID: state.NodeID.ValueString(), Remove: true}
+	} else {
+		var plan ClusterNodeRoleResourceModel
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if plan.NodeID.IsUnknown() || plan.Zone.IsUnknown() || plan.Capacity.IsUnknown() {
+			return
+		}
+		change = client.NodeRoleChange{
+			ID:       plan.NodeID.ValueString(),
+			Zone:     plan.Zone.ValueString(),
+			Capacity: plan.Capacity.ValueInt64Pointer(),
+		}
+	}
+
+	layout, err := r.client.GetClusterLayout(ctx)
+	if err != nil {
+		// The preview is informational, the apply reports real errors
+		tflog.Debug(ctx, "Unable to preview layout change", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	preview := previewRoleChange(layout, change)
+	if len(preview.Zones) == 0 && len(preview.Nodes) == 0 {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Cluster Capacity Change",
+		fmt.Sprintf("Applying this change to layout version %d changes the cluster capacity as follows:\n\n%s", layout.Version, preview),
+	)
+}
+
+func (r *ClusterNodeRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ClusterNodeRoleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	tflog.Debug(ctx, "Assigning node role", map[string]interface{}{
+		"node_id": data.NodeID.ValueString(),
+		"zone":    data.Zone.ValueString(),
+	})
+
+	change, diags := r.roleChange(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := applyLayoutChanges(ctx, r.client, []client.NodeRoleChange{change})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "assign node role", err)
+		return
+	}
+
+	data.ID = data.NodeID
+
+	tflog.Trace(ctx, "Created cluster node role resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterNodeRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ClusterNodeRoleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	layout, err := r.client.GetClusterLayout(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read cluster layout", err)
+		return
+	}
+
+	role := findLayoutRole(layout, data.NodeID.ValueString())
+	if role == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Zone = types.StringValue(role.Zone)
+	data.Capacity = types.Int64PointerValue(role.Capacity)
+
+	tags := role.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	tagList, diags := types.ListValueFrom(ctx, types.StringType, tags)
+	resp.Diagnostics.Append(diags...)
+	data.Tags = tagList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterNodeRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ClusterNodeRoleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	change, diags := r.roleChange(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := applyLayoutChanges(ctx, r.client, []client.NodeRoleChange{change})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "update node role", err)
+		return
+	}
+
+	tflog.Trace(ctx, "Updated cluster node role resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ClusterNodeRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ClusterNodeRoleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	drainTimeout, err := time.ParseDuration(data.DrainTimeout.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Drain Timeout", fmt.Sprintf("Unable to parse drain_timeout %q: %s", data.DrainTimeout.ValueString(), err))
+		return
+	}
+
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+	resp.Diagnostics.Append(diags...)
+
+	// The drain wait is not cut short by a shorter delete timeout
+	if data.WaitForDrain.ValueBool() {
+		deleteTimeout = max(deleteTimeout, drainTimeout)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	nodeID := data.NodeID.ValueString()
+
+	tflog.Debug(ctx, "Removing node from layout", map[string]interface{}{
+		"node_id": nodeID,
+	})
+
+	_, err = applyLayoutChanges(ctx, r.client, []client.NodeRoleChange{{ID: nodeID, Remove: true}})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "remove node from layout", err)
+		return
+	}
+
+	if data.WaitForDrain.ValueBool() {
+		if err := waitForNodeDrained(ctx, r.client, nodeID, drainTimeout); err != nil {
+			resp.Diagnostics.AddError("Node Drain Incomplete", fmt.Sprintf("Node %s was removed from the layout but did not finish draining: %s", nodeID, err))
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "Deleted cluster node role resource")
+}
+
+func (r *ClusterNodeRoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID format: node_id. Zone, capacity and tags are read from the
+	// current layout.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("node_id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_drain"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("drain_timeout"), "1h")...)
+}
+
+// roleChange builds the staged role assignment for the model.
+func (r *ClusterNodeRoleResource) roleChange(ctx context.Context, data ClusterNodeRoleResourceModel) (client.NodeRoleChange, diag.Diagnostics) {
+	var tags []string
+	diags := data.Tags.ElementsAs(ctx, &tags, false)
+
+	return client.NodeRoleChange{
+		ID:       data.NodeID.ValueString(),
+		Zone:     data.Zone.ValueString(),
+		Capacity: data.Capacity.ValueInt64Pointer(),
+		Tags:     tags,
+	}, diags
+}
+
+// applyLayoutChanges stages the given role changes and applies them as the
+// next layout version.
+func applyLayoutChanges(ctx context.Context, c *client.Client, changes []client.NodeRoleChange) (*client.ClusterLayout, error) {
+	return applyLayoutUpdate(ctx, c, client.UpdateClusterLayoutRequest{Roles: changes})
+}
+
+// applyLayoutUpdate stages the given role and parameter changes and applies
+// them as the next layout version.
+func applyLayoutUpdate(ctx context.Context, c *client.Client, update client.UpdateClusterLayoutRequest) (*client.ClusterLayout, error) {
+	layoutMutex.Lock()
+	defer layoutMutex.Unlock()
+
+	return applyLayoutUpdateLocked(ctx, c, update)
+}
+
+// applyLayoutUpdateLocked is applyLayoutUpdate for callers already holding
+// layoutMutex, such as those computing the update from the current layout.
+func applyLayoutUpdateLocked(ctx context.Context, c *client.Client, update client.UpdateClusterLayoutRequest) (*client.ClusterLayout, error) {
+	layout, err := c.UpdateClusterLayout(ctx, update)
+	if err != nil {
+		return nil, fmt.Errorf("unable to stage layout changes: %w", err)
+	}
+
+	result, err := c.ApplyClusterLayout(ctx, client.ApplyClusterLayoutRequest{Version: layout.NextVersion()})
+	if err != nil {
+		// Left staged, the changes would be applied along with the next ones
+		if _, revertErr := c.RevertClusterLayout(ctx); revertErr != nil {
+			tflog.Warn(ctx, "Unable to revert staged layout changes", map[string]interface{}{
+				"error": revertErr.Error(),
+			})
+		}
+		return nil, fmt.Errorf("unable to apply layout version %d: %w", layout.NextVersion(), err)
+	}
+
+	for _, message := range result.Message {
+		tflog.Debug(ctx, "Layout applied", map[string]interface{}{
+			"version": result.Layout.Version,
+			"message": message,
+		})
+	}
+
+	return &result.Layout, nil
+}
+
+// findLayoutRole returns the role of a node in the current layout, or nil if
+// the node has no role.
+func findLayoutRole(layout *client.ClusterLayout, nodeID string) *client.LayoutNodeRole {
+	for i := range layout.Roles {
+		if layout.Roles[i].ID == nodeID {
+			return &layout.Roles[i]
+		}
+	}
+	return nil
+}
+
+// waitForNodeDrained polls the cluster status until the node no longer holds
+// data from an older layout version, for at most timeout or until the
+// deadline of ctx, whichever comes first.
+func waitForNodeDrained(ctx context.Context, c *client.Client, nodeID string, timeout time.Duration) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = min(timeout, time.Until(deadline).Round(time.Second))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+
+	for {
+		status, err := c.GetClusterStatus(ctx)
+		if err != nil {
+			return err
+		}
+
+		draining := false
+		for _, node := range status.Nodes {
+			if node.ID == nodeID {
+				draining = node.Draining
+				break
+			}
+		}
+
+		if !draining {
+			return nil
+		}
+
+		tflog.Info(ctx, "Waiting for node to drain", map[string]interface{}{
+			"node_id":        nodeID,
+			"layout_version": status.LayoutVersion,
+		})
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out after %s", timeout)
+		case <-ticker.C:
+		}
+	}
+}
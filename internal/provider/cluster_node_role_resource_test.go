@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-garage/internal/client"
+)
+
+func TestAccClusterNodeRoleResource_basic(t *testing.T) {
+	nodeID := os.Getenv("GARAGE_TEST_NODE_ID")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckNode(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Assign a storage role
+			{
+				Config: testAccClusterNodeRoleResourceConfig_capacity(nodeID, "test-zone", 1073741824),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_cluster_node_role.test", "node_id", nodeID),
+					resource.TestCheckResourceAttr("garage_cluster_node_role.test", "zone", "test-zone"),
+					resource.TestCheckResourceAttr("garage_cluster_node_role.test", "capacity", "1073741824"),
+					resource.TestCheckResourceAttr("garage_cluster_node_role.test", "tags.#", "0"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "garage_cluster_node_role.test",
+				ImportState:             true,
+				ImportStateId:           nodeID,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_drain", "drain_timeout"},
+			},
+			// Change zone and capacity in place
+			{
+				Config: testAccClusterNodeRoleResourceConfig_capacity(nodeID, "test-zone-2", 2147483648),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_cluster_node_role.test", "zone", "test-zone-2"),
+					resource.TestCheckResourceAttr("garage_cluster_node_role.test", "capacity", "2147483648"),
+				),
+			},
+			// Set tags
+			{
+				Config: testAccClusterNodeRoleResourceConfig_tags(nodeID, "test-zone-2", "rack-a", "room-1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_cluster_node_role.test", "tags.#", "2"),
+					resource.TestCheckResourceAttr("garage_cluster_node_role.test", "tags.0", "rack-a"),
+					resource.TestCheckResourceAttr("garage_cluster_node_role.test", "tags.1", "room-1"),
+				),
+			},
+			// Turn the node into a gateway
+			{
+				Config: testAccClusterNodeRoleResourceConfig_gateway(nodeID, "test-zone-2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("garage_cluster_node_role.test", "capacity"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// Test configuration functions
+
+func testAccClusterNodeRoleResourceConfig_capacity(nodeID, zone string, capacity int64) string {
+	return fmt.Sprintf(`
+resource "garage_cluster_node_role" "test" {
+  node_id        = %[1]q
+  zone           = %[2]q
+  capacity       = %[3]d
+  wait_for_drain = true
+  drain_timeout  = "5m"
+}
+`, nodeID, zone, capacity)
+}
+
+func testAccClusterNodeRoleResourceConfig_tags(nodeID, zone, tag1, tag2 string) string {
+	return fmt.Sprintf(`
+resource "garage_cluster_node_role" "test" {
+  node_id        = %[1]q
+  zone           = %[2]q
+  capacity       = 2147483648
+  tags           = [%[3]q, %[4]q]
+  wait_for_drain = true
+  drain_timeout  = "5m"
+}
+`, nodeID, zone, tag1, tag2)
+}
+
+func testAccClusterNodeRoleResourceConfig_gateway(nodeID, zone string) string {
+	return fmt.Sprintf(`
+resource "garage_cluster_node_role" "test" {
+  node_id        = %[1]q
+  zone           = %[2]q
+  wait_for_drain = true
+  drain_timeout  = "5m"
+}
+`, nodeID, zone)
+}
+
+func TestWaitForNodeDrained_deadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"layoutVersion": 2, "nodes": [{"id": "node-1", "draining": true}]}`))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	err := waitForNodeDrained(ctx, client.NewClient(server.URL, "test-token"), "node-1", time.Hour)
+	if err == nil || err.Error() != "timed out after 1s" {
+		t.Errorf("Expected the deadline of the context to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ClusterPartitionBalanceDataSource{}
+
+func NewClusterPartitionBalanceDataSource() datasource.DataSource {
+	return &ClusterPartitionBalanceDataSource{}
+}
+
+// ClusterPartitionBalanceDataSource defines the data source implementation.
+type ClusterPartitionBalanceDataSource struct {
+	client *client.Client
+}
+
+// ClusterPartitionBalanceDataSourceModel describes the data source data model.
+type ClusterPartitionBalanceDataSourceModel struct {
+	LayoutVersion         types.Int64                 `tfsdk:"layout_version"`
+	PartitionSize         types.Int64                 `tfsdk:"partition_size"`
+	TotalStoredPartitions types.Int64                 `tfsdk:"total_stored_partitions"`
+	Nodes                 []PartitionBalanceNodeModel `tfsdk:"nodes"`
+}
+
+// PartitionBalanceNodeModel describes the partition balance of a single node.
+type PartitionBalanceNodeModel struct {
+	ID               types.String  `tfsdk:"id"`
+	Zone             types.String  `tfsdk:"zone"`
+	Capacity         types.Int64   `tfsdk:"capacity"`
+	StoredPartitions types.Int64   `tfsdk:"stored_partitions"`
+	UsableCapacity   types.Int64   `tfsdk:"usable_capacity"`
+	DataShare        types.Float64 `tfsdk:"data_share"`
+}
+
+func (d *ClusterPartitionBalanceDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_partition_balance"
+}
+
+func (d *ClusterPartitionBalanceDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves how the partitions of the current cluster layout are spread over the storage nodes, " +
+			"so that configurations can check that a layout change achieved the expected balance.",
+
+		Attributes: map[string]schema.Attribute{
+			"layout_version": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The version of the current cluster layout.",
+			},
+			"partition_size": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The size of a partition in bytes.",
+			},
+			"total_stored_partitions": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of partition copies stored over all nodes.",
+			},
+			"nodes": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The storage nodes of the current layout. Gateway nodes are not included.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the node.",
+						},
+						"zone": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The zone the node belongs to.",
+						},
+						"capacity": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The storage capacity assigned to the node in bytes.",
+						},
+						"stored_partitions": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The number of partitions stored on the node.",
+						},
+						"usable_capacity": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The part of the node capacity actually used to store partitions, in bytes.",
+						},
+						"data_share": schema.Float64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The fraction of all stored partitions held by the node, between 0 and 1.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ClusterPartitionBalanceDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ClusterPartitionBalanceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ClusterPartitionBalanceDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	layout, err := d.client.GetClusterLayout(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read cluster layout", err)
+		return
+	}
+
+	data = partitionBalanceFromLayout(layout)
+
+	tflog.Trace(ctx, "Read cluster partition balance data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// partitionBalanceFromLayout computes the per-node partition balance of a layout.
+func partitionBalanceFromLayout(layout *client.ClusterLayout) ClusterPartitionBalanceDataSourceModel {
+	var total int64
+	for _, role := range layout.Roles {
+		if role.Capacity != nil && role.StoredPartitions != nil {
+			total += *role.StoredPartitions
+		}
+	}
+
+	nodes := make([]PartitionBalanceNodeModel, 0, len(layout.Roles))
+	for _, role := range layout.Roles {
+		if role.Capacity == nil {
+			continue
+		}
+
+		var stored int64
+		if role.StoredPartitions != nil {
+			stored = *role.StoredPartitions
+		}
+
+		var usable int64
+		if role.UsableCapacity != nil {
+			usable = *role.UsableCapacity
+		}
+
+		share := 0.0
+		if total > 0 {
+			share = float64(stored) / float64(total)
+		}
+
+		nodes = append(nodes, PartitionBalanceNodeModel{
+			ID:               types.StringValue(role.ID),
+			Zone:             types.StringValue(role.Zone),
+			Capacity:         types.Int64Value(*role.Capacity),
+			StoredPartitions: types.Int64Value(stored),
+			UsableCapacity:   types.Int64Value(usable),
+			DataShare:        types.Float64Value(share),
+		})
+	}
+
+	return ClusterPartitionBalanceDataSourceModel{
+		LayoutVersion:         types.Int64Value(layout.Version),
+		PartitionSize:         types.Int64Value(layout.PartitionSize),
+		TotalStoredPartitions: types.Int64Value(total),
+		Nodes:                 nodes,
+	}
+}
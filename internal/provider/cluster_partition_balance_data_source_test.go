@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-garage/internal/client"
+)
+
+func TestAccClusterPartitionBalanceDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterPartitionBalanceDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.garage_cluster_partition_balance.test", "layout_version"),
+					resource.TestCheckResourceAttrSet("data.garage_cluster_partition_balance.test", "total_stored_partitions"),
+					resource.TestCheckResourceAttrSet("data.garage_cluster_partition_balance.test", "nodes.0.id"),
+					resource.TestCheckResourceAttrSet("data.garage_cluster_partition_balance.test", "nodes.0.data_share"),
+				),
+			},
+		},
+	})
+}
+
+func TestPartitionBalanceFromLayout(t *testing.T) {
+	int64Ptr := func(v int64) *int64 { return &v }
+
+	layout := &client.ClusterLayout{
+		Version:       3,
+		PartitionSize: 1000,
+		Roles: []client.LayoutNodeRole{
+			{ID: "a", Zone: "dc1", Capacity: int64Ptr(100), StoredPartitions: int64Ptr(192), UsableCapacity: int64Ptr(96)},
+			{ID: "b", Zone: "dc2", Capacity: int64Ptr(50), StoredPartitions: int64Ptr(64), UsableCapacity: int64Ptr(32)},
+			{ID: "gw", Zone: "dc1"},
+		},
+	}
+
+	balance := partitionBalanceFromLayout(layout)
+
+	if balance.TotalStoredPartitions.ValueInt64() != 256 {
+		t.Errorf("expected 256 stored partitions, got %d", balance.TotalStoredPartitions.ValueInt64())
+	}
+
+	if len(balance.Nodes) != 2 {
+		t.Fatalf("expected gateway node to be excluded, got %d nodes", len(balance.Nodes))
+	}
+
+	if share := balance.Nodes[0].DataShare.ValueFloat64(); share != 0.75 {
+		t.Errorf("expected node a to hold 0.75 of the data, got %v", share)
+	}
+
+	if share := balance.Nodes[1].DataShare.ValueFloat64(); share != 0.25 {
+		t.Errorf("expected node b to hold 0.25 of the data, got %v", share)
+	}
+}
+
+const testAccClusterPartitionBalanceDataSourceConfig = `
+data "garage_cluster_partition_balance" "test" {}
+`
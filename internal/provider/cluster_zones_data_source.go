@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ClusterZonesDataSource{}
+
+func NewClusterZonesDataSource() datasource.DataSource {
+	return &ClusterZonesDataSource{}
+}
+
+// ClusterZonesDataSource defines the data source implementation.
+type ClusterZonesDataSource struct {
+	client *client.Client
+}
+
+// ClusterZonesDataSourceModel describes the data source data model.
+type ClusterZonesDataSourceModel struct {
+	LayoutVersion types.Int64                 `tfsdk:"layout_version"`
+	ZoneCount     types.Int64                 `tfsdk:"zone_count"`
+	Zones         map[string]ClusterZoneModel `tfsdk:"zones"`
+}
+
+// ClusterZoneModel describes the nodes of a single zone.
+type ClusterZoneModel struct {
+	NodeCount        types.Int64 `tfsdk:"node_count"`
+	StorageNodeCount types.Int64 `tfsdk:"storage_node_count"`
+	ConnectedCount   types.Int64 `tfsdk:"connected_count"`
+	Capacity         types.Int64 `tfsdk:"capacity"`
+}
+
+func (d *ClusterZonesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_zones"
+}
+
+func (d *ClusterZonesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Summarizes the nodes of the current cluster layout by zone, so that zone redundancy can be checked in preconditions.",
+
+		Attributes: map[string]schema.Attribute{
+			"layout_version": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The version of the current cluster layout.",
+			},
+			"zone_count": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of zones with at least one node.",
+			},
+			"zones": schema.MapNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The zones of the current layout, keyed by zone name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"node_count": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The number of nodes with a role in the zone, including gateways.",
+						},
+						"storage_node_count": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The number of nodes of the zone that store data.",
+						},
+						"connected_count": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The number of nodes of the zone that are currently connected to the cluster.",
+						},
+						"capacity": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The sum of the storage capacities assigned to the nodes of the zone, in bytes.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ClusterZonesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ClusterZonesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ClusterZonesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	status, err := d.client.GetClusterStatus(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read cluster status", err)
+		return
+	}
+
+	data.LayoutVersion = types.Int64Value(status.LayoutVersion)
+	data.Zones = zonesFromStatus(status)
+	data.ZoneCount = types.Int64Value(int64(len(data.Zones)))
+
+	tflog.Trace(ctx, "Read cluster zones data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// zonesFromStatus aggregates the nodes that have a role in the current layout
+// by zone. Nodes without a role are not part of any zone.
+func zonesFromStatus(status *client.ClusterStatus) map[string]ClusterZoneModel {
+	type zoneTotals struct {
+		nodes, storageNodes, connected, capacity int64
+	}
+
+	totals := map[string]*zoneTotals{}
+	for _, node := range status.Nodes {
+		if node.Role == nil {
+			continue
+		}
+
+		zone, ok := totals[node.Role.Zone]
+		if !ok {
+			zone = &zoneTotals{}
+			totals[node.Role.Zone] = zone
+		}
+
+		zone.nodes++
+		if node.Role.Capacity != nil {
+			zone.storageNodes++
+			zone.capacity += *node.Role.Capacity
+		}
+		if node.IsUp {
+			zone.connected++
+		}
+	}
+
+	zones := make(map[string]ClusterZoneModel, len(totals))
+	for name, zone := range totals {
+		zones[name] = ClusterZoneModel{
+			NodeCount:        types.Int64Value(zone.nodes),
+			StorageNodeCount: types.Int64Value(zone.storageNodes),
+			ConnectedCount:   types.Int64Value(zone.connected),
+			Capacity:         types.Int64Value(zone.capacity),
+		}
+	}
+
+	return zones
+}
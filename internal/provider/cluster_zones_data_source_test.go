@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-garage/internal/client"
+)
+
+func TestAccClusterZonesDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccClusterZonesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.garage_cluster_zones.test", "layout_version"),
+					resource.TestCheckResourceAttrSet("data.garage_cluster_zones.test", "zone_count"),
+				),
+			},
+		},
+	})
+}
+
+func TestZonesFromStatus(t *testing.T) {
+	int64Ptr := func(v int64) *int64 { return &v }
+
+	status := &client.ClusterStatus{
+		Nodes: []client.NodeStatus{
+			{ID: "a", IsUp: true, Role: &client.NodeAssignedRole{Zone: "dc1", Capacity: int64Ptr(100)}},
+			{ID: "b", IsUp: false, Role: &client.NodeAssignedRole{Zone: "dc1", Capacity: int64Ptr(200)}},
+			{ID: "gw", IsUp: true, Role: &client.NodeAssignedRole{Zone: "dc1"}},
+			{ID: "c", IsUp: true, Role: &client.NodeAssignedRole{Zone: "dc2", Capacity: int64Ptr(300)}},
+			{ID: "new", IsUp: true},
+		},
+	}
+
+	zones := zonesFromStatus(status)
+
+	if len(zones) != 2 {
+		t.Fatalf("Expected 2 zones, got %d", len(zones))
+	}
+
+	dc1 := zones["dc1"]
+	if dc1.NodeCount.ValueInt64() != 3 || dc1.StorageNodeCount.ValueInt64() != 2 || dc1.ConnectedCount.ValueInt64() != 2 || dc1.Capacity.ValueInt64() != 300 {
+		t.Errorf("Unexpected dc1 summary: %+v", dc1)
+	}
+
+	dc2 := zones["dc2"]
+	if dc2.NodeCount.ValueInt64() != 1 || dc2.ConnectedCount.ValueInt64() != 1 || dc2.Capacity.ValueInt64() != 300 {
+		t.Errorf("Unexpected dc2 summary: %+v", dc2)
+	}
+}
+
+const testAccClusterZonesDataSourceConfig = `
+data "garage_cluster_zones" "test" {}
+`
@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// apiErrorHint explains a Garage error code and how to resolve it.
+type apiErrorHint struct {
+	Explanation string
+	Fix         string
+}
+
+// apiErrorHints maps Garage error codes to explanations shown to users.
+var apiErrorHints = map[string]apiErrorHint{
+	"BucketNotEmpty": {
+		Explanation: "The bucket still contains objects or unfinished multipart uploads.",
+		Fix:         "Delete the objects in the bucket before destroying it, or set force_destroy = true on the garage_bucket resource to have them deleted on destroy.",
+	},
+	"BucketAlreadyExists": {
+		Explanation: "Another bucket already uses this global alias.",
+		Fix:         "Choose a different global_alias, or import the existing bucket with `terraform import`.",
+	},
+	"NoSuchBucket": {
+		Explanation: "The bucket does not exist.",
+		Fix:         "Check the bucket ID or alias. If the bucket was deleted outside of Terraform, run `terraform apply -refresh-only` to update the state.",
+	},
+	"NoSuchAccessKey": {
+		Explanation: "The access key does not exist.",
+		Fix:         "Check the access key ID. If the key was deleted outside of Terraform, run `terraform apply -refresh-only` to update the state.",
+	},
+	"KeyAlreadyExists": {
+		Explanation: "An access key with this ID already exists.",
+		Fix:         "Use a different access key ID, or import the existing key with `terraform import`.",
+	},
+	"InvalidBucketName": {
+		Explanation: "The bucket name is not a valid S3 bucket name.",
+		Fix:         "Use 3 to 63 lowercase letters, digits, dots and hyphens, starting and ending with a letter or digit.",
+	},
+	"InvalidRequest": {
+		Explanation: "Garage rejected the request as invalid.",
+		Fix:         "Check the resource arguments against the message above.",
+	},
+	"Forbidden": {
+		Explanation: "The admin token is not allowed to perform this operation.",
+		Fix:         "Use a token whose scope includes this endpoint, or enable validate_token_scope to detect missing scopes at plan time.",
+	},
+}
+
+// notFoundHint explains 404 responses whose error code has no specific hint.
+var notFoundHint = apiErrorHint{
+	Explanation: "The object no longer exists in Garage.",
+	Fix:         "If it was deleted outside of Terraform, run `terraform apply -refresh-only` to update the state.",
+}
+
+// addClientError adds an error diagnostic for a failed Garage API call. When
+// Garage returned a structured error, the diagnostic shows the error code, an
+// explanation and a suggested fix instead of the raw response.
+func addClientError(diags *diag.Diagnostics, action string, err error) {
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to %s, got error: %s", action, err))
+		return
+	}
+
+	if apiErr.StatusCode == http.StatusUnauthorized {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to %s: Garage rejected the admin token. Check the provider token setting.", action))
+		return
+	}
+
+	detail := fmt.Sprintf("Unable to %s, got error: %s", action, err)
+	if apiErr.Code != "" {
+		detail = fmt.Sprintf("Unable to %s: Garage returned %s (HTTP %d): %s", action, apiErr.Code, apiErr.StatusCode, apiErr.Message)
+	}
+
+	hint, ok := apiErrorHints[apiErr.Code]
+	if !ok && errors.Is(err, client.ErrNotFound) {
+		hint, ok = notFoundHint, true
+	}
+	if ok {
+		detail += "\n\n" + hint.Explanation + "\n" + hint.Fix
+	}
+
+	diags.AddError("Client Error", detail)
+}
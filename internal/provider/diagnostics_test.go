@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"terraform-provider-garage/internal/client"
+)
+
+func TestAddClientError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		contains []string
+	}{
+		{
+			name: "known code",
+			err: &client.APIError{
+				StatusCode: http.StatusConflict,
+				Code:       "BucketNotEmpty",
+				Message:    "Bucket is not empty",
+			},
+			contains: []string{
+				"Unable to delete bucket: Garage returned BucketNotEmpty (HTTP 409): Bucket is not empty",
+				apiErrorHints["BucketNotEmpty"].Fix,
+			},
+		},
+		{
+			name: "unknown code",
+			err: &client.APIError{
+				StatusCode: http.StatusInternalServerError,
+				Code:       "InternalError",
+				Message:    "something broke",
+			},
+			contains: []string{"Garage returned InternalError (HTTP 500): something broke"},
+		},
+		{
+			name:     "not found without code",
+			err:      &client.APIError{StatusCode: http.StatusNotFound, Body: "not found"},
+			contains: []string{"status 404", notFoundHint.Fix},
+		},
+		{
+			name:     "rejected token",
+			err:      &client.APIError{StatusCode: http.StatusUnauthorized, Body: "invalid token"},
+			contains: []string{"Garage rejected the admin token"},
+		},
+		{
+			name:     "other error",
+			err:      errors.New("connection refused"),
+			contains: []string{"Unable to delete bucket, got error: connection refused"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			addClientError(&diags, "delete bucket", tt.err)
+
+			if len(diags) != 1 {
+				t.Fatalf("Expected 1 diagnostic, got %d", len(diags))
+			}
+
+			detail := diags[0].Detail()
+			for _, s := range tt.contains {
+				if !strings.Contains(detail, s) {
+					t.Errorf("Expected detail to contain %q, got %q", s, detail)
+				}
+			}
+		})
+	}
+}
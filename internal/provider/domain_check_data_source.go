@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DomainCheckDataSource{}
+
+func NewDomainCheckDataSource() datasource.DataSource {
+	return &DomainCheckDataSource{}
+}
+
+// DomainCheckDataSource defines the data source implementation.
+type DomainCheckDataSource struct {
+	client *client.Client
+}
+
+// DomainCheckDataSourceModel describes the data source data model.
+type DomainCheckDataSourceModel struct {
+	Domain   types.String `tfsdk:"domain"`
+	Served   types.Bool   `tfsdk:"served"`
+	BucketID types.String `tfsdk:"bucket_id"`
+}
+
+func (d *DomainCheckDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain_check"
+}
+
+func (d *DomainCheckDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Checks whether Garage serves a website for a domain, using the same endpoint reverse proxies query before requesting a TLS certificate. " +
+			"A domain is served when it is the global alias of a bucket with website hosting enabled, or a subdomain of the web root domain naming such a bucket. " +
+			"Use it in preconditions to verify DNS and website configurations agree.",
+
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The domain to check (e.g., `www.example.com`).",
+			},
+			"served": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether Garage serves a website for the domain.",
+			},
+			"bucket_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the bucket whose global alias is the domain, or null if there is none.",
+			},
+		},
+	}
+}
+
+func (d *DomainCheckDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DomainCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DomainCheckDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := data.Domain.ValueString()
+
+	tflog.Debug(ctx, "Checking domain", map[string]interface{}{
+		"domain": domain,
+	})
+
+	served, err := d.client.CheckDomain(ctx, domain)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "check domain", err)
+		return
+	}
+
+	bucket, err := d.client.GetBucketInfo(ctx, client.GetBucketInfoRequest{GlobalAlias: &domain})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read bucket", err)
+		return
+	}
+
+	data.Served = types.BoolValue(served)
+	if bucket != nil {
+		data.BucketID = types.StringValue(bucket.ID)
+	} else {
+		data.BucketID = types.StringNull()
+	}
+
+	tflog.Trace(ctx, "Read domain check data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
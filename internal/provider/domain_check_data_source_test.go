@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDomainCheckDataSource_served(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDomainCheckDataSourceConfig("test-domain-check.example.com", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.garage_domain_check.test", "served", "true"),
+					resource.TestCheckResourceAttrPair("data.garage_domain_check.test", "bucket_id", "garage_bucket.test", "id"),
+				),
+			},
+			// A bucket without website hosting is not served
+			{
+				Config: testAccDomainCheckDataSourceConfig("test-domain-check.example.com", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.garage_domain_check.test", "served", "false"),
+					resource.TestCheckResourceAttrPair("data.garage_domain_check.test", "bucket_id", "garage_bucket.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDomainCheckDataSource_unknown(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "garage_domain_check" "test" {
+  domain = "test-domain-check-unknown.example.com"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.garage_domain_check.test", "served", "false"),
+					resource.TestCheckNoResourceAttr("data.garage_domain_check.test", "bucket_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDomainCheckDataSourceConfig(domain string, websiteEnabled bool) string {
+	return fmt.Sprintf(`
+resource "garage_bucket" "test" {
+  global_alias    = %[1]q
+  website_enabled = %[2]t
+}
+
+data "garage_domain_check" "test" {
+  domain = garage_bucket.test.global_alias
+
+  depends_on = [garage_bucket.test]
+}
+`, domain, websiteEnabled)
+}
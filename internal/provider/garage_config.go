@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// garageDaemonConfig is the subset of the Garage daemon configuration file
+// (garage.toml) the provider can derive connection settings from.
+type garageDaemonConfig struct {
+	Admin struct {
+		APIBindAddr    string `toml:"api_bind_addr"`
+		AdminToken     string `toml:"admin_token"`
+		AdminTokenFile string `toml:"admin_token_file"`
+	} `toml:"admin"`
+	S3API struct {
+		APIBindAddr string `toml:"api_bind_addr"`
+		S3Region    string `toml:"s3_region"`
+	} `toml:"s3_api"`
+	K2VAPI struct {
+		APIBindAddr string `toml:"api_bind_addr"`
+	} `toml:"k2v_api"`
+}
+
+// loadGarageConfig derives connection settings from the garage.toml at path.
+// Relative token file paths are resolved against the directory of the file.
+func loadGarageConfig(path string) (*providerProfile, error) {
+	var config garageDaemonConfig
+	if _, err := toml.DecodeFile(path, &config); err != nil {
+		return nil, fmt.Errorf("unable to read Garage configuration file %s: %w", path, err)
+	}
+
+	profile := &providerProfile{
+		S3Region: config.S3API.S3Region,
+	}
+
+	if config.Admin.APIBindAddr != "" {
+		endpoint, err := bindAddrToEndpoint(config.Admin.APIBindAddr)
+		if err != nil {
+			return nil, fmt.Errorf("unable to use admin.api_bind_addr from %s: %w", path, err)
+		}
+		profile.Endpoint = endpoint
+	}
+
+	if config.S3API.APIBindAddr != "" {
+		// The S3 endpoint is optional, so an unusable bind address is ignored.
+		if endpoint, err := bindAddrToEndpoint(config.S3API.APIBindAddr); err == nil {
+			profile.S3Endpoint = endpoint
+		}
+	}
+
+	if config.K2VAPI.APIBindAddr != "" {
+		if endpoint, err := bindAddrToEndpoint(config.K2VAPI.APIBindAddr); err == nil {
+			profile.K2VEndpoint = endpoint
+		}
+	}
+
+	switch {
+	case config.Admin.AdminToken != "":
+		profile.Token = config.Admin.AdminToken
+	case config.Admin.AdminTokenFile != "":
+		tokenFile := config.Admin.AdminTokenFile
+		if !filepath.IsAbs(tokenFile) {
+			tokenFile = filepath.Join(filepath.Dir(path), tokenFile)
+		}
+
+		token, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read admin token file referenced by %s: %w", path, err)
+		}
+		profile.Token = strings.TrimSpace(string(token))
+	}
+
+	return profile, nil
+}
+
+// bindAddrToEndpoint turns a Garage bind address into a URL the provider can
+// connect to. Wildcard addresses are replaced with localhost.
+func bindAddrToEndpoint(bindAddr string) (string, error) {
+	if strings.HasPrefix(bindAddr, "/") {
+		return "", fmt.Errorf("unix socket %s is not supported", bindAddr)
+	}
+
+	host, port, err := net.SplitHostPort(bindAddr)
+	if err != nil {
+		return "", err
+	}
+
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+
+	return "http://" + net.JoinHostPort(host, port), nil
+}
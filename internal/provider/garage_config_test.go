@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadGarageConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "garage.toml")
+	content := `
+metadata_dir = "/var/lib/garage/meta"
+replication_factor = 3
+
+[s3_api]
+s3_region = "garage"
+api_bind_addr = "[::]:3900"
+
+[k2v_api]
+api_bind_addr = "[::]:3904"
+
+[admin]
+api_bind_addr = "0.0.0.0:3903"
+admin_token_file = "admin.token"
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "admin.token"), []byte("file-token\n"), 0o600); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	profile, err := loadGarageConfig(path)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if profile.Endpoint != "http://localhost:3903" {
+		t.Errorf("Expected endpoint http://localhost:3903, got %s", profile.Endpoint)
+	}
+	if profile.S3Endpoint != "http://localhost:3900" {
+		t.Errorf("Expected S3 endpoint http://localhost:3900, got %s", profile.S3Endpoint)
+	}
+	if profile.K2VEndpoint != "http://localhost:3904" {
+		t.Errorf("Expected K2V endpoint http://localhost:3904, got %s", profile.K2VEndpoint)
+	}
+	if profile.Token != "file-token" {
+		t.Errorf("Expected token from token file, got %s", profile.Token)
+	}
+	if profile.S3Region != "garage" {
+		t.Errorf("Expected S3 region garage, got %s", profile.S3Region)
+	}
+}
+
+func TestBindAddrToEndpoint(t *testing.T) {
+	tests := map[string]string{
+		"[::]:3903":         "http://localhost:3903",
+		"0.0.0.0:3903":      "http://localhost:3903",
+		"127.0.0.1:3903":    "http://127.0.0.1:3903",
+		"[fd00::1]:3903":    "http://[fd00::1]:3903",
+		"garage.local:3903": "http://garage.local:3903",
+	}
+
+	for bindAddr, expected := range tests {
+		endpoint, err := bindAddrToEndpoint(bindAddr)
+		if err != nil {
+			t.Errorf("Expected no error for %s, got %v", bindAddr, err)
+			continue
+		}
+		if endpoint != expected {
+			t.Errorf("Expected %s for %s, got %s", expected, bindAddr, endpoint)
+		}
+	}
+
+	if _, err := bindAddrToEndpoint("/run/garage/admin.sock"); err == nil {
+		t.Error("Expected error for unix socket bind address")
+	}
+}
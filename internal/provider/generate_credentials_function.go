@@ -0,0 +1,101 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// minCredentialsSeedLength is the shortest seed generate_credentials accepts,
+// so that the secret cannot be guessed from a short seed.
+const minCredentialsSeedLength = 32
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &GenerateCredentialsFunction{}
+
+// generateCredentialsAttributeTypes are the attributes of the
+// generate_credentials result.
+var generateCredentialsAttributeTypes = map[string]attr.Type{
+	"access_key_id":     types.StringType,
+	"secret_access_key": types.StringType,
+}
+
+func NewGenerateCredentialsFunction() function.Function {
+	return &GenerateCredentialsFunction{}
+}
+
+// GenerateCredentialsFunction defines the function implementation.
+type GenerateCredentialsFunction struct{}
+
+func (f *GenerateCredentialsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "generate_credentials"
+}
+
+func (f *GenerateCredentialsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Generates Garage-format access key credentials from a seed",
+		MarkdownDescription: "Derives an access key ID (`GK` followed by 24 hexadecimal characters) and a 64 hexadecimal character secret from a random seed, " +
+			"for credentials that must exist before the key is imported into Garage with `garage_key`. " +
+			"Provider functions must return the same result for the same arguments, so the randomness comes from the seed, " +
+			fmt.Sprintf("such as the `result` of a `random_password` resource, which must be at least %d characters long. ", minCredentialsSeedLength) +
+			"The same seed always gives the same credentials.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "seed",
+				MarkdownDescription: "A random, secret value the credentials are derived from.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: generateCredentialsAttributeTypes,
+		},
+	}
+}
+
+func (f *GenerateCredentialsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var seed string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &seed))
+	if resp.Error != nil {
+		return
+	}
+
+	if len(seed) < minCredentialsSeedLength {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("The seed must be at least %d characters long.", minCredentialsSeedLength))
+		return
+	}
+
+	accessKeyID, secretAccessKey := generateCredentials(seed)
+
+	result, diags := types.ObjectValue(generateCredentialsAttributeTypes, map[string]attr.Value{
+		"access_key_id":     types.StringValue(accessKeyID),
+		"secret_access_key": types.StringValue(secretAccessKey),
+	})
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
+
+// generateCredentials derives an access key ID and secret from the seed, each
+// with its own HMAC so that the ID reveals nothing about the secret.
+func generateCredentials(seed string) (accessKeyID, secretAccessKey string) {
+	derive := func(label string) []byte {
+		mac := hmac.New(sha256.New, []byte(seed))
+		mac.Write([]byte(label))
+		return mac.Sum(nil)
+	}
+
+	return "GK" + hex.EncodeToString(derive("access_key_id")[:12]), hex.EncodeToString(derive("secret_access_key"))
+}
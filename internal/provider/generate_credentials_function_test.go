@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestGenerateCredentialsFunction(t *testing.T) {
+	run := func(seed string) *function.RunResponse {
+		req := function.RunRequest{
+			Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(seed)}),
+		}
+		resp := &function.RunResponse{
+			Result: function.NewResultData(types.ObjectUnknown(generateCredentialsAttributeTypes)),
+		}
+
+		NewGenerateCredentialsFunction().Run(context.Background(), req, resp)
+		return resp
+	}
+
+	resp := run("an example seed that is long enough")
+	if resp.Error != nil {
+		t.Fatalf("Expected no error, got %v", resp.Error)
+	}
+
+	result := resp.Result.Value().(types.Object).Attributes()
+	accessKeyID := result["access_key_id"].(types.String).ValueString()
+	secretAccessKey := result["secret_access_key"].(types.String).ValueString()
+
+	if !regexp.MustCompile(`^GK[0-9a-f]{24}$`).MatchString(accessKeyID) {
+		t.Errorf("Expected a Garage access key ID, got %s", accessKeyID)
+	}
+	if !regexp.MustCompile(`^[0-9a-f]{64}$`).MatchString(secretAccessKey) {
+		t.Errorf("Expected a 64 hexadecimal character secret, got %s", secretAccessKey)
+	}
+
+	// The same seed gives the same credentials, another seed different ones
+	if again := run("an example seed that is long enough").Result.Value(); !again.Equal(resp.Result.Value()) {
+		t.Errorf("Expected the same credentials for the same seed, got %s and %s", resp.Result.Value(), again)
+	}
+	if other := run("another example seed that is long enough").Result.Value(); other.Equal(resp.Result.Value()) {
+		t.Error("Expected different credentials for different seeds")
+	}
+
+	if resp := run("too short"); resp.Error == nil {
+		t.Error("Expected an error for a short seed")
+	}
+}
@@ -0,0 +1,242 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &K2VItemsDataSource{}
+
+func NewK2VItemsDataSource() datasource.DataSource {
+	return &K2VItemsDataSource{}
+}
+
+// K2VItemsDataSource defines the data source implementation.
+type K2VItemsDataSource struct {
+	client *client.Client
+}
+
+// K2VItemsDataSourceModel describes the data source data model.
+type K2VItemsDataSourceModel struct {
+	Bucket          types.String   `tfsdk:"bucket"`
+	PartitionKey    types.String   `tfsdk:"partition_key"`
+	Prefix          types.String   `tfsdk:"prefix"`
+	Start           types.String   `tfsdk:"start"`
+	End             types.String   `tfsdk:"end"`
+	Limit           types.Int64    `tfsdk:"limit"`
+	AccessKeyID     types.String   `tfsdk:"access_key_id"`
+	SecretAccessKey types.String   `tfsdk:"secret_access_key"`
+	Items           []K2VItemModel `tfsdk:"items"`
+}
+
+// K2VItemModel describes a single item of the partition.
+type K2VItemModel struct {
+	SortKey        types.String   `tfsdk:"sort_key"`
+	CausalityToken types.String   `tfsdk:"causality_token"`
+	ValuesBase64   []types.String `tfsdk:"values_base64"`
+	Value          types.String   `tfsdk:"value"`
+}
+
+func (d *K2VItemsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_k2v_items"
+}
+
+func (d *K2VItemsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads the items of a partition through the Garage K2V API, to verify bootstrap data or feed it to other resources. " +
+			"Deleted items are not returned. Requires the provider `k2v_endpoint` to be set and Garage to be built with the `k2v` feature.",
+
+		Attributes: map[string]schema.Attribute{
+			"bucket": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the bucket: a global alias of the bucket, or a local alias of the access key.",
+			},
+			"partition_key": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The partition key of the items.",
+			},
+			"prefix": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return items whose sort key starts with this prefix.",
+			},
+			"start": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return items whose sort key is greater than or equal to this one.",
+			},
+			"end": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return items whose sort key is lower than this one.",
+			},
+			"limit": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The maximum number of items to return. All matching items are returned when omitted.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"access_key_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The ID of an access key with read permission on the bucket. Defaults to the provider `s3_access_key_id`.",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("secret_access_key")),
+				},
+			},
+			"secret_access_key": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The secret of the access key.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.AlsoRequires(path.MatchRoot("access_key_id")),
+				},
+			},
+			"items": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The items of the partition, ordered by sort key.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"sort_key": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The sort key of the item.",
+						},
+						"causality_token": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The causality token of the item, to pass when writing the item again.",
+						},
+						"values_base64": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The values of the item, base64-encoded. Concurrent writes leave several values until one of them is written again.",
+						},
+						"value": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The value of the item as a UTF-8 string, or null if the item has concurrent values or its value is not valid UTF-8.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *K2VItemsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *K2VItemsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data K2VItemsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	k2v, err := d.client.NewK2VClient(data.AccessKeyID.ValueString(), data.SecretAccessKey.ValueString())
+	if errors.Is(err, client.ErrK2VEndpointNotConfigured) {
+		resp.Diagnostics.AddError(
+			"K2V Endpoint Not Configured",
+			"The garage_k2v_items data source requires the provider k2v_endpoint (or GARAGE_K2V_ENDPOINT) to be configured.",
+		)
+		return
+	}
+	if errors.Is(err, client.ErrS3CredentialsNotConfigured) {
+		resp.Diagnostics.AddError(
+			"Missing Access Key",
+			"The garage_k2v_items data source requires access_key_id and secret_access_key, or the provider s3_access_key_id and s3_secret_access_key (or GARAGE_S3_ACCESS_KEY_ID and GARAGE_S3_SECRET_ACCESS_KEY) to be configured.",
+		)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read K2V items", err)
+		return
+	}
+
+	query := client.K2VRangeQuery{
+		PartitionKey: data.PartitionKey.ValueString(),
+		Prefix:       data.Prefix.ValueStringPointer(),
+		Start:        data.Start.ValueStringPointer(),
+		End:          data.End.ValueStringPointer(),
+		Limit:        data.Limit.ValueInt64Pointer(),
+	}
+
+	tflog.Debug(ctx, "Reading K2V items", map[string]interface{}{
+		"bucket":        data.Bucket.ValueString(),
+		"partition_key": query.PartitionKey,
+	})
+
+	data.Items = []K2VItemModel{}
+	err = k2v.EachItem(ctx, data.Bucket.ValueString(), query, func(item client.K2VItem) error {
+		data.Items = append(data.Items, k2vItemModel(item))
+		return nil
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read K2V items", err)
+		return
+	}
+
+	tflog.Trace(ctx, "Read K2V items data source", map[string]interface{}{
+		"items": len(data.Items),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// k2vItemModel converts a K2V item to its model, leaving out the deletions
+// among its concurrent values.
+func k2vItemModel(item client.K2VItem) K2VItemModel {
+	model := K2VItemModel{
+		SortKey:        types.StringValue(item.SortKey),
+		CausalityToken: types.StringValue(item.CausalityToken),
+		ValuesBase64:   []types.String{},
+		Value:          types.StringNull(),
+	}
+
+	for _, value := range item.Values {
+		if value != nil {
+			model.ValuesBase64 = append(model.ValuesBase64, types.StringValue(*value))
+		}
+	}
+
+	if len(model.ValuesBase64) == 1 {
+		decoded, err := base64.StdEncoding.DecodeString(model.ValuesBase64[0].ValueString())
+		if err == nil && utf8.Valid(decoded) {
+			model.Value = types.StringValue(string(decoded))
+		}
+	}
+
+	return model
+}
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-garage/internal/client"
+)
+
+func TestAccK2VItemsDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckK2V(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccK2VItemsDataSourceConfig_basic,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.garage_k2v_items.test", "partition_key", "users"),
+					resource.TestCheckResourceAttr("data.garage_k2v_items.test", "items.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccK2VItemsDataSourceConfig_basic = `
+resource "garage_bucket" "test" {
+  global_alias = "test-k2v-items-bucket"
+}
+
+resource "garage_key" "test" {
+  name = "test-k2v-items-bucket-key"
+}
+
+resource "garage_bucket_permission" "test" {
+  bucket_id     = garage_bucket.test.id
+  access_key_id = garage_key.test.id
+  read          = true
+}
+
+data "garage_k2v_items" "test" {
+  bucket            = garage_bucket.test.global_alias
+  partition_key     = "users"
+  access_key_id     = garage_bucket_permission.test.access_key_id
+  secret_access_key = garage_key.test.secret_access_key
+}
+`
+
+func TestK2VItemModel(t *testing.T) {
+	hello, world := "aGVsbG8=", "d29ybGQ="
+	binary := "/w=="
+
+	tests := []struct {
+		name   string
+		values []*string
+		base64 []string
+		value  types.String
+	}{
+		{
+			name:   "single value",
+			values: []*string{&hello},
+			base64: []string{hello},
+			value:  types.StringValue("hello"),
+		},
+		{
+			name:   "concurrent values",
+			values: []*string{&hello, &world},
+			base64: []string{hello, world},
+			value:  types.StringNull(),
+		},
+		{
+			name:   "value concurrent with a deletion",
+			values: []*string{nil, &world},
+			base64: []string{world},
+			value:  types.StringValue("world"),
+		},
+		{
+			name:   "binary value",
+			values: []*string{&binary},
+			base64: []string{binary},
+			value:  types.StringNull(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := k2vItemModel(client.K2VItem{SortKey: "user-1", CausalityToken: "ct", Values: tt.values})
+
+			if len(model.ValuesBase64) != len(tt.base64) {
+				t.Fatalf("Expected %d values, got %d", len(tt.base64), len(model.ValuesBase64))
+			}
+			for i, value := range tt.base64 {
+				if model.ValuesBase64[i].ValueString() != value {
+					t.Errorf("Expected value %d to be %s, got %s", i, value, model.ValuesBase64[i].ValueString())
+				}
+			}
+
+			if !model.Value.Equal(tt.value) {
+				t.Errorf("Expected value %s, got %s", tt.value, model.Value)
+			}
+		})
+	}
+}
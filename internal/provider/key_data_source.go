@@ -0,0 +1,237 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &KeyDataSource{}
+
+func NewKeyDataSource() datasource.DataSource {
+	return &KeyDataSource{}
+}
+
+// KeyDataSource defines the data source implementation.
+type KeyDataSource struct {
+	client *client.Client
+}
+
+// KeyDataSourceModel describes the data source data model.
+type KeyDataSourceModel struct {
+	ID           types.String           `tfsdk:"id"`
+	Search       types.String           `tfsdk:"search"`
+	Name         types.String           `tfsdk:"name"`
+	Created      types.String           `tfsdk:"created"`
+	Expiration   types.String           `tfsdk:"expiration"`
+	Expired      types.Bool             `tfsdk:"expired"`
+	CreateBucket types.Bool             `tfsdk:"create_bucket"`
+	Buckets      []KeyBucketAccessModel `tfsdk:"buckets"`
+}
+
+// KeyBucketAccessModel describes the access of a key to a single bucket.
+type KeyBucketAccessModel struct {
+	ID            types.String   `tfsdk:"id"`
+	GlobalAliases []types.String `tfsdk:"global_aliases"`
+	LocalAliases  []types.String `tfsdk:"local_aliases"`
+	Read          types.Bool     `tfsdk:"read"`
+	Write         types.Bool     `tfsdk:"write"`
+	Owner         types.Bool     `tfsdk:"owner"`
+}
+
+func (d *KeyDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_key"
+}
+
+func (d *KeyDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the details of a single access key and the buckets it has access to. The secret access key is never returned.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The access key ID. Either id or search must be specified.",
+			},
+			"search": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The name of the key, or a prefix of its access key ID. It must match exactly one key. Either id or search must be specified.",
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the key.",
+			},
+			"created": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the key was created.",
+			},
+			"expiration": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the key expires, or null if it never expires.",
+			},
+			"expired": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the key has expired.",
+			},
+			"create_bucket": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the key is allowed to create buckets.",
+			},
+			"buckets": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The buckets the key has access to.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the bucket.",
+						},
+						"global_aliases": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The global aliases of the bucket.",
+						},
+						"local_aliases": schema.ListAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The aliases of the bucket local to the key.",
+						},
+						"read": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the key can read from the bucket.",
+						},
+						"write": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the key can write to the bucket.",
+						},
+						"owner": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the key owns the bucket.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *KeyDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *KeyDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data KeyDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Validate that either ID or Search is provided
+	if data.ID.IsNull() && data.Search.IsNull() {
+		resp.Diagnostics.AddError(
+			"Missing Required Attribute",
+			"Either 'id' or 'search' must be specified.",
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Reading key data source", map[string]interface{}{
+		"id":     data.ID.ValueString(),
+		"search": data.Search.ValueString(),
+	})
+
+	getKeyReq := client.GetKeyInfoRequest{ID: data.ID.ValueString()}
+	if data.ID.IsNull() {
+		getKeyReq.Search = data.Search.ValueString()
+	}
+
+	key, err := d.client.GetKeyInfo(ctx, getKeyReq)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read key", err)
+		return
+	}
+
+	if key == nil {
+		resp.Diagnostics.AddError(
+			"Key Not Found",
+			"The specified key could not be found.",
+		)
+		return
+	}
+
+	// When both are given, the key found by ID must also match the search
+	if !data.ID.IsNull() && !data.Search.IsNull() && !keyMatchesSearch(key, data.Search.ValueString()) {
+		resp.Diagnostics.AddError(
+			"Key Search Mismatch",
+			fmt.Sprintf("Key %s is named %q, which does not match the search %q.", key.AccessKeyID, key.Name, data.Search.ValueString()),
+		)
+		return
+	}
+
+	data.ID = types.StringValue(key.AccessKeyID)
+	data.Name = types.StringValue(key.Name)
+	data.Created = types.StringPointerValue(key.Created)
+	data.Expiration = types.StringPointerValue(key.Expiration)
+	data.Expired = types.BoolValue(key.Expired)
+	data.CreateBucket = types.BoolValue(key.Permissions.CreateBucket)
+
+	data.Buckets = make([]KeyBucketAccessModel, 0, len(key.Buckets))
+	for _, bucket := range key.Buckets {
+		data.Buckets = append(data.Buckets, KeyBucketAccessModel{
+			ID:            types.StringValue(bucket.ID),
+			GlobalAliases: stringValues(bucket.GlobalAliases),
+			LocalAliases:  stringValues(bucket.LocalAliases),
+			Read:          types.BoolValue(bucket.Permissions.Read),
+			Write:         types.BoolValue(bucket.Permissions.Write),
+			Owner:         types.BoolValue(bucket.Permissions.Owner),
+		})
+	}
+
+	tflog.Trace(ctx, "Read key data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// keyMatchesSearch reports whether Garage would find the key with the given
+// search: by its exact name, or by a prefix of its access key ID.
+func keyMatchesSearch(key *client.AccessKey, search string) bool {
+	return key.Name == search || strings.HasPrefix(key.AccessKeyID, search)
+}
+
+// stringValues converts a list of strings to framework values, turning a nil
+// list into an empty one.
+func stringValues(values []string) []types.String {
+	result := make([]types.String, 0, len(values))
+	for _, value := range values {
+		result = append(result, types.StringValue(value))
+	}
+	return result
+}
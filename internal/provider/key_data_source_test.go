@@ -0,0 +1,80 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-garage/internal/client"
+)
+
+func TestAccKeyDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKeyDataSourceConfig("test-key-lookup", "test-key-lookup-bucket"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.garage_key.by_search", "id", "garage_key.test", "id"),
+					resource.TestCheckResourceAttr("data.garage_key.by_search", "name", "test-key-lookup"),
+					resource.TestCheckResourceAttr("data.garage_key.by_search", "expired", "false"),
+					resource.TestCheckResourceAttr("data.garage_key.by_search", "create_bucket", "false"),
+					resource.TestCheckResourceAttrSet("data.garage_key.by_search", "created"),
+					resource.TestCheckResourceAttr("data.garage_key.by_id", "name", "test-key-lookup"),
+					resource.TestCheckResourceAttr("data.garage_key.by_id", "buckets.#", "1"),
+					resource.TestCheckResourceAttrPair("data.garage_key.by_id", "buckets.0.id", "garage_bucket.test", "id"),
+					resource.TestCheckResourceAttr("data.garage_key.by_id", "buckets.0.global_aliases.0", "test-key-lookup-bucket"),
+					resource.TestCheckResourceAttr("data.garage_key.by_id", "buckets.0.read", "true"),
+					resource.TestCheckResourceAttr("data.garage_key.by_id", "buckets.0.write", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestKeyMatchesSearch(t *testing.T) {
+	key := &client.AccessKey{AccessKeyID: "GK31c2f218a2e44f485b94239e", Name: "ci-deploy"}
+
+	if !keyMatchesSearch(key, "ci-deploy") {
+		t.Error("Expected the exact name to match")
+	}
+	if !keyMatchesSearch(key, "GK31c2") {
+		t.Error("Expected an ID prefix to match")
+	}
+	if keyMatchesSearch(key, "ci-") {
+		t.Error("Expected a name prefix not to match")
+	}
+}
+
+func testAccKeyDataSourceConfig(name, bucket string) string {
+	return fmt.Sprintf(`
+resource "garage_key" "test" {
+  name = %[1]q
+}
+
+resource "garage_bucket" "test" {
+  global_alias = %[2]q
+}
+
+resource "garage_bucket_permission" "test" {
+  bucket_id     = garage_bucket.test.id
+  access_key_id = garage_key.test.id
+  read          = true
+}
+
+data "garage_key" "by_search" {
+  search = garage_key.test.name
+}
+
+data "garage_key" "by_id" {
+  id = garage_key.test.id
+
+  depends_on = [garage_bucket_permission.test]
+}
+`, name, bucket)
+}
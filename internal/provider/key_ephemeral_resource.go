@@ -0,0 +1,287 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// defaultEphemeralKeyTTL is how long an ephemeral key stays valid when ttl is not set.
+const defaultEphemeralKeyTTL = time.Hour
+
+// ephemeralKeyPrivateKey is the private state key holding the ID of the key to delete on close.
+const ephemeralKeyPrivateKey = "access_key_id"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &KeyEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &KeyEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &KeyEphemeralResource{}
+
+func NewKeyEphemeralResource() ephemeral.EphemeralResource {
+	return &KeyEphemeralResource{}
+}
+
+// KeyEphemeralResource defines the ephemeral resource implementation.
+type KeyEphemeralResource struct {
+	client *client.Client
+}
+
+// KeyEphemeralResourceModel describes the ephemeral resource data model.
+type KeyEphemeralResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	TTL             types.String `tfsdk:"ttl"`
+	Permissions     types.List   `tfsdk:"bucket_permissions"`
+	SecretAccessKey types.String `tfsdk:"secret_access_key"`
+	Expiration      types.String `tfsdk:"expiration"`
+}
+
+// KeyEphemeralPermissionModel describes a bucket permission granted to an
+// ephemeral key.
+type KeyEphemeralPermissionModel struct {
+	BucketID types.String `tfsdk:"bucket_id"`
+	Read     types.Bool   `tfsdk:"read"`
+	Write    types.Bool   `tfsdk:"write"`
+	Owner    types.Bool   `tfsdk:"owner"`
+}
+
+func (e *KeyEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_key"
+}
+
+func (e *KeyEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Creates a short-lived access key for the duration of a Terraform run and deletes it afterwards, so that the secret is never stored in the state. " +
+			"The key also expires on its own after `ttl`, in case it cannot be deleted.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "A human-friendly name for the access key, after the provider's `key_name_prefix`. Defaults to `terraform-ephemeral`.",
+			},
+			"ttl": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "How long the access key stays valid (e.g., `30m`). Defaults to `1h`.",
+				Validators: []validator.String{
+					positiveDuration(),
+				},
+			},
+			"bucket_permissions": schema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Bucket permissions to grant the access key for its lifetime.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"bucket_id": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The ID of the bucket.",
+						},
+						"read": schema.BoolAttribute{
+							Optional:            true,
+							MarkdownDescription: "Allow reading objects from the bucket. Defaults to `false`.",
+						},
+						"write": schema.BoolAttribute{
+							Optional:            true,
+							MarkdownDescription: "Allow writing objects to the bucket. Defaults to `false`.",
+						},
+						"owner": schema.BoolAttribute{
+							Optional:            true,
+							MarkdownDescription: "Allow managing the bucket through the S3 API. Defaults to `false`.",
+						},
+					},
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The access key ID.",
+			},
+			"secret_access_key": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The secret access key.",
+			},
+			"expiration": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the access key expires, as an RFC3339 timestamp.",
+			},
+		},
+	}
+}
+
+func (e *KeyEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	e.client = client
+}
+
+func (e *KeyEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data KeyEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ttl := defaultEphemeralKeyTTL
+	if !data.TTL.IsNull() {
+		parsed, err := time.ParseDuration(data.TTL.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Duration", fmt.Sprintf("Unable to parse ttl: %s", err))
+			return
+		}
+		ttl = parsed
+	}
+
+	name := "terraform-ephemeral"
+	if !data.Name.IsNull() {
+		name = data.Name.ValueString()
+	}
+	name = e.client.KeyNamePrefix() + name
+
+	expiration := time.Now().Add(ttl).UTC().Format(time.RFC3339)
+
+	tflog.Debug(ctx, "Creating ephemeral key", map[string]interface{}{
+		"name":       name,
+		"expiration": expiration,
+	})
+
+	key, err := e.client.CreateKey(ctx, client.CreateKeyRequest{
+		Name:       &name,
+		Expiration: &expiration,
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "create ephemeral key", err)
+		return
+	}
+
+	// Terraform does not call Close when Open fails, so delete the key here
+	defer func() {
+		if resp.Diagnostics.HasError() {
+			e.deleteKey(ctx, key.AccessKeyID, &resp.Diagnostics)
+		}
+	}()
+
+	resp.Diagnostics.Append(setEphemeralPrivateID(ctx, resp.Private, ephemeralKeyPrivateKey, key.AccessKeyID)...)
+
+	var permissions []KeyEphemeralPermissionModel
+	resp.Diagnostics.Append(data.Permissions.ElementsAs(ctx, &permissions, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, permission := range permissions {
+		_, err := e.client.AllowBucketKey(ctx, client.BucketKeyPermRequest{
+			BucketID:    permission.BucketID.ValueString(),
+			AccessKeyID: key.AccessKeyID,
+			Permissions: client.Permissions{
+				Read:  permission.Read.ValueBool(),
+				Write: permission.Write.ValueBool(),
+				Owner: permission.Owner.ValueBool(),
+			},
+		})
+		if err != nil {
+			addClientError(&resp.Diagnostics, "grant bucket permission to ephemeral key", err)
+			return
+		}
+	}
+
+	if key.SecretAccessKey == nil {
+		resp.Diagnostics.AddError("Missing Secret", fmt.Sprintf("Garage did not return a secret for access key %s.", key.AccessKeyID))
+		return
+	}
+
+	data.ID = types.StringValue(key.AccessKeyID)
+	data.Name = types.StringValue(name)
+	data.SecretAccessKey = types.StringValue(*key.SecretAccessKey)
+	data.Expiration = types.StringValue(expiration)
+	if key.Expiration != nil {
+		data.Expiration = types.StringValue(*key.Expiration)
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (e *KeyEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	accessKeyID, diags := ephemeralPrivateID(ctx, req.Private, ephemeralKeyPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || accessKeyID == "" {
+		return
+	}
+
+	e.deleteKey(ctx, accessKeyID, &resp.Diagnostics)
+}
+
+// deleteKey deletes an ephemeral key, ignoring keys that no longer exist.
+func (e *KeyEphemeralResource) deleteKey(ctx context.Context, accessKeyID string, diags *diag.Diagnostics) {
+	tflog.Debug(ctx, "Deleting ephemeral key", map[string]interface{}{
+		"access_key_id": accessKeyID,
+	})
+
+	err := e.client.DeleteKey(ctx, client.DeleteKeyRequest{ID: accessKeyID})
+	if err != nil && !isNoSuchAccessKeyError(err) {
+		addClientError(diags, "delete ephemeral key", err)
+	}
+}
+
+// privateState is the private state of an ephemeral resource, as exposed by
+// both the Open response and the Close request.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// setEphemeralPrivateID records in private state the ID of the object an
+// ephemeral resource must delete on close.
+func setEphemeralPrivateID(ctx context.Context, private privateState, key, id string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	value, err := json.Marshal(id)
+	if err != nil {
+		diags.AddError("Invalid Private State", fmt.Sprintf("Unable to record the ID %s: %s", id, err))
+		return diags
+	}
+
+	return private.SetKey(ctx, key, value)
+}
+
+// ephemeralPrivateID returns the ID recorded by setEphemeralPrivateID, or ""
+// when none was recorded.
+func ephemeralPrivateID(ctx context.Context, private privateState, key string) (string, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, key)
+	if diags.HasError() || value == nil {
+		return "", diags
+	}
+
+	var id string
+	if err := json.Unmarshal(value, &id); err != nil {
+		diags.AddError("Invalid Private State", fmt.Sprintf("Unable to read the recorded ID: %s", err))
+	}
+
+	return id, diags
+}
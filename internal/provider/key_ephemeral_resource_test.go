@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+
+	"terraform-provider-garage/internal/client"
+)
+
+func TestAccKeyEphemeralResource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKeyEphemeralResourceConfig_basic("test-ephemeral-key"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("name"), knownvalue.StringExact("test-ephemeral-key")),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("id"), knownvalue.StringRegexp(regexp.MustCompile(`^GK[0-9a-f]{24}$`))),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("secret_access_key"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("expiration"), knownvalue.NotNull()),
+				},
+			},
+			{
+				Config: testAccKeyEphemeralResourceConfig_bucketPermissions("test-ephemeral-key-bucket"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("bucket_permissions").AtSliceIndex(0).AtMapKey("write"), knownvalue.Bool(true)),
+					statecheck.ExpectKnownValue("echo.test", tfjsonpath.New("data").AtMapKey("id"), knownvalue.StringRegexp(regexp.MustCompile(`^GK[0-9a-f]{24}$`))),
+				},
+			},
+		},
+	})
+}
+
+func TestAccKeyEphemeralResource_openFails(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		// Terraform does not close an ephemeral resource whose Open failed
+		CheckDestroy: func(*terraform.State) error {
+			c := client.NewClient(os.Getenv("GARAGE_ENDPOINT"), os.Getenv("GARAGE_TOKEN"))
+			keys, err := c.SearchKeys(context.Background(), "test-ephemeral-key-open-fails")
+			if err != nil {
+				return err
+			}
+			if len(keys) != 0 {
+				return fmt.Errorf("expected the ephemeral key to be deleted, got %d keys", len(keys))
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccKeyEphemeralResourceConfig_missingBucket("test-ephemeral-key-open-fails"),
+				ExpectError: regexp.MustCompile("grant bucket permission to ephemeral key"),
+			},
+		},
+	})
+}
+
+func testAccKeyEphemeralResourceConfig_basic(name string) string {
+	return `
+ephemeral "garage_key" "test" {
+  name = "` + name + `"
+  ttl  = "10m"
+}
+
+provider "echo" {
+  data = ephemeral.garage_key.test
+}
+
+resource "echo" "test" {}
+`
+}
+
+func testAccKeyEphemeralResourceConfig_bucketPermissions(bucketName string) string {
+	return `
+resource "garage_bucket" "test" {
+  global_alias = "` + bucketName + `"
+}
+
+ephemeral "garage_key" "test" {
+  ttl = "10m"
+
+  bucket_permissions = [{
+    bucket_id = garage_bucket.test.id
+    read      = true
+    write     = true
+  }]
+}
+
+provider "echo" {
+  data = ephemeral.garage_key.test
+}
+
+resource "echo" "test" {}
+`
+}
+
+func testAccKeyEphemeralResourceConfig_missingBucket(name string) string {
+	return `
+ephemeral "garage_key" "test" {
+  name = "` + name + `"
+  ttl  = "10m"
+
+  bucket_permissions = [{
+    bucket_id = "` + strings.Repeat("0", 64) + `"
+    read      = true
+  }]
+}
+
+provider "echo" {
+  data = ephemeral.garage_key.test
+}
+
+resource "echo" "test" {}
+`
+}
@@ -5,13 +5,25 @@ package provider
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -21,6 +33,10 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &KeyResource{}
 var _ resource.ResourceWithImportState = &KeyResource{}
+var _ resource.ResourceWithModifyPlan = &KeyResource{}
+
+// accessKeyIDPattern matches the access key IDs generated by Garage.
+var accessKeyIDPattern = regexp.MustCompile(`^GK[0-9a-f]{24}$`)
 
 func NewKeyResource() resource.Resource {
 	return &KeyResource{}
@@ -35,7 +51,18 @@ type KeyResource struct {
 type KeyResourceModel struct {
 	ID              types.String `tfsdk:"id"`
 	Name            types.String `tfsdk:"name"`
+	NamePrefix      types.String `tfsdk:"name_prefix"`
+	FullName        types.String `tfsdk:"full_name"`
 	SecretAccessKey types.String `tfsdk:"secret_access_key"`
+	Created         types.String `tfsdk:"created"`
+	Expiration      types.String `tfsdk:"expiration"`
+	Expired         types.Bool   `tfsdk:"expired"`
+	CreateBucket    types.Bool   `tfsdk:"create_bucket"`
+
+	SecretAccessKeyWO      types.String `tfsdk:"secret_access_key_wo"`
+	SecretAccessKeyVersion types.Int64  `tfsdk:"secret_access_key_version"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *KeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -59,7 +86,24 @@ func (r *KeyResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 			"name": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "A human-friendly name for the access key.",
+				MarkdownDescription: "A human-friendly name for the access key. Renaming the key updates it in place. Conflicts with `name_prefix`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Creates a unique name beginning with the specified prefix. Conflicts with `name`.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("name")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"full_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the key in Garage, that is `name` after the provider's `key_name_prefix`.",
 			},
 			"secret_access_key": schema.StringAttribute{
 				Optional:            true,
@@ -71,6 +115,54 @@ func (r *KeyResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"secret_access_key_wo": schema.StringAttribute{
+				Optional:            true,
+				WriteOnly:           true,
+				Sensitive:           true,
+				MarkdownDescription: "The secret access key of a key imported with `id`, as a write-only value that is never stored in the state. Requires Terraform >= 1.11. Conflicts with `secret_access_key`; change `secret_access_key_version` to import the key again with a new secret.",
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("secret_access_key")),
+					stringvalidator.AlsoRequires(path.MatchRoot("id")),
+				},
+			},
+			"secret_access_key_version": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "A version number for `secret_access_key_wo`. As the write-only secret is not stored, changing this number is what replaces the key with one imported with the current secret.",
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("secret_access_key_wo")),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"created": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "When the access key was created, as an RFC3339 timestamp.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"expiration": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "When the access key expires, as an RFC3339 timestamp. Changing or removing the expiration updates the key in place. The key never expires when not set.",
+				Validators: []validator.String{
+					timestamp(),
+				},
+			},
+			"expired": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the access key has expired.",
+			},
+			"create_bucket": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the access key is allowed to create buckets. Defaults to `false`.",
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
 		},
 	}
 }
@@ -94,6 +186,55 @@ func (r *KeyResource) Configure(ctx context.Context, req resource.ConfigureReque
 	r.client = client
 }
 
+func (r *KeyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	scopes := resourceScopes{
+		Create: []string{"CreateKey"},
+		Update: []string{"UpdateKey"},
+		Delete: []string{"DeleteKey"},
+	}
+
+	// Keys with a given secret are imported rather than created. Settings
+	// the create call does not take are set with a second call.
+	if !req.Plan.Raw.IsNull() {
+		var secret, secretWO, expiration types.String
+		var createBucket types.Bool
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("secret_access_key"), &secret)...)
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("secret_access_key_wo"), &secretWO)...)
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("expiration"), &expiration)...)
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("create_bucket"), &createBucket)...)
+		imported := !secret.IsNull() || !secretWO.IsNull()
+		if imported {
+			scopes.Create = []string{"ImportKey"}
+		}
+		if (imported && !expiration.IsNull()) || createBucket.ValueBool() {
+			scopes.Create = append(scopes.Create, "UpdateKey")
+		}
+
+		// A write-only secret is kept out of the state
+		if !secretWO.IsNull() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("secret_access_key"), types.StringNull())...)
+		}
+
+		// Names set in the configuration are stored after the provider's
+		// key_name_prefix, so changing the prefix renames the key. Other keys
+		// keep the name they have in Garage.
+		var name types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name"), &name)...)
+		switch {
+		case r.client != nil && !name.IsNull() && !name.IsUnknown():
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("full_name"), r.client.KeyNamePrefix()+name.ValueString())...)
+		case !req.State.Raw.IsNull() && name.IsNull():
+			var fullName types.String
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("full_name"), &fullName)...)
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("full_name"), fullName)...)
+		}
+	}
+
+	planFutureExpiration(ctx, req, resp)
+
+	validateTokenScope(ctx, r.client, scopes, req, resp)
+}
+
 func (r *KeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data KeyResourceModel
 
@@ -103,9 +244,24 @@ func (r *KeyResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	// Write-only values are only available in the configuration
+	var secretWO types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("secret_access_key_wo"), &secretWO)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Determine whether to use ImportKey or CreateKey
 	hasID := !data.ID.IsNull() && !data.ID.IsUnknown()
 	hasSecret := !data.SecretAccessKey.IsNull() && !data.SecretAccessKey.IsUnknown()
+	secret := data.SecretAccessKey.ValueString()
+	if !secretWO.IsNull() {
+		hasSecret = true
+		secret = secretWO.ValueString()
+	}
 
 	// If both ID and secret are provided, use ImportKey
 	if hasID && hasSecret {
@@ -116,48 +272,71 @@ func (r *KeyResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 		importReq := client.ImportKeyRequest{
 			AccessKeyID:     data.ID.ValueString(),
-			SecretAccessKey: data.SecretAccessKey.ValueString(),
-		}
-		if !data.Name.IsNull() {
-			name := data.Name.ValueString()
-			importReq.Name = &name
+			SecretAccessKey: secret,
 		}
+		importReq.Name = r.keyName(data)
 
 		key, err := r.client.ImportKey(ctx, importReq)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to import access key, got error: %s", err))
+			addClientError(&resp.Diagnostics, "import access key", err)
 			return
 		}
 
-		data.ID = types.StringValue(key.AccessKeyID)
-		data.Name = types.StringValue(key.Name)
-		data.SecretAccessKey = types.StringValue(data.SecretAccessKey.ValueString()) // Keep the provided secret
+		// ImportKey takes no expiration or permissions, so they are set in a second call
+		if !data.Expiration.IsNull() || data.CreateBucket.ValueBool() {
+			updateReq := client.UpdateKeyRequest{
+				Expiration: data.Expiration.ValueStringPointer(),
+			}
+			if data.CreateBucket.ValueBool() {
+				updateReq.Allow = &client.KeyPermissions{CreateBucket: true}
+			}
+
+			key, err = r.client.UpdateKey(ctx, key.AccessKeyID, updateReq)
+			if err != nil {
+				addClientError(&resp.Diagnostics, "update imported access key", err)
+				return
+			}
+		}
+
+		updateStateFromKey(&data, key, r.client.KeyNamePrefix())
 
 		tflog.Trace(ctx, "Imported access key resource")
 	} else if !hasID && !hasSecret {
 		// Neither ID nor secret provided, use CreateKey
 		tflog.Debug(ctx, "Creating access key", map[string]interface{}{
-			"name": data.Name.ValueString(),
+			"name":        data.Name.ValueString(),
+			"name_prefix": data.NamePrefix.ValueString(),
 		})
 
-		createReq := client.CreateKeyRequest{}
-		if !data.Name.IsNull() {
-			name := data.Name.ValueString()
-			createReq.Name = &name
+		createReq := client.CreateKeyRequest{
+			Name:       r.keyName(data),
+			Expiration: data.Expiration.ValueStringPointer(),
 		}
 
 		key, err := r.client.CreateKey(ctx, createReq)
 		if err != nil {
-			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create access key, got error: %s", err))
+			addClientError(&resp.Diagnostics, "create access key", err)
 			return
 		}
 
-		data.ID = types.StringValue(key.AccessKeyID)
-		data.Name = types.StringValue(key.Name)
+		// The secret is only returned on creation, keep it before updating the key
 		if key.SecretAccessKey != nil {
 			data.SecretAccessKey = types.StringValue(*key.SecretAccessKey)
 		}
 
+		// CreateKey takes no permissions, so they are set in a second call
+		if data.CreateBucket.ValueBool() {
+			key, err = r.client.UpdateKey(ctx, key.AccessKeyID, client.UpdateKeyRequest{
+				Allow: &client.KeyPermissions{CreateBucket: true},
+			})
+			if err != nil {
+				addClientError(&resp.Diagnostics, "allow access key to create buckets", err)
+				return
+			}
+		}
+
+		updateStateFromKey(&data, key, r.client.KeyNamePrefix())
+
 		tflog.Trace(ctx, "Created access key resource")
 	} else {
 		// Invalid combination: only one of ID or secret provided
@@ -180,13 +359,16 @@ func (r *KeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	keyID := data.ID.ValueString()
 	key, err := r.client.GetKeyInfo(ctx, client.GetKeyInfoRequest{
 		ID: keyID,
 	})
 
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read access key, got error: %s", err))
+		addClientError(&resp.Diagnostics, "read access key", err)
 		return
 	}
 
@@ -196,9 +378,7 @@ func (r *KeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 	}
 
 	// Update state with key information
-	data.ID = types.StringValue(key.AccessKeyID)
-	data.Name = types.StringValue(key.Name)
-	// Note: SecretAccessKey is not returned by GetKeyInfo (only on creation), so we keep the existing value
+	updateStateFromKey(&data, key, r.client.KeyNamePrefix())
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -212,10 +392,25 @@ func (r *KeyResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
-	// Note: UpdateKey is available in the API but we're not implementing it for now
-	// The name field is optional and computed, so updates aren't critical for tests
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
 
-	tflog.Trace(ctx, "Updated access key resource (no-op)")
+	tflog.Debug(ctx, "Updating access key", map[string]interface{}{
+		"id":            data.ID.ValueString(),
+		"name":          data.Name.ValueString(),
+		"expiration":    data.Expiration.ValueString(),
+		"create_bucket": data.CreateBucket.ValueBool(),
+	})
+
+	key, err := r.client.UpdateKey(ctx, data.ID.ValueString(), keyUpdateRequest(data, r.client.KeyNamePrefix()))
+	if err != nil {
+		addClientError(&resp.Diagnostics, "update access key", err)
+		return
+	}
+
+	updateStateFromKey(&data, key, r.client.KeyNamePrefix())
+
+	tflog.Trace(ctx, "Updated access key resource")
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -229,6 +424,9 @@ func (r *KeyResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, defaultDeleteTimeout, &resp.Diagnostics)
+	defer cancel()
+
 	tflog.Debug(ctx, "Deleting access key", map[string]interface{}{
 		"id": data.ID.ValueString(),
 	})
@@ -237,8 +435,9 @@ func (r *KeyResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		ID: data.ID.ValueString(),
 	})
 
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete access key, got error: %s", err))
+	// A key already deleted outside Terraform is gone as planned
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		addClientError(&resp.Diagnostics, "delete access key", err)
 		return
 	}
 
@@ -246,5 +445,100 @@ func (r *KeyResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 }
 
 func (r *KeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Keys can also be imported by name or by a unique prefix of their ID
+	keyID := req.ID
+	if !accessKeyIDPattern.MatchString(keyID) {
+		key, err := r.client.SearchKey(ctx, keyID)
+		if err != nil {
+			addClientError(&resp.Diagnostics, "find access key", err)
+			return
+		}
+
+		if key == nil {
+			resp.Diagnostics.AddError(
+				"Access Key Not Found",
+				fmt.Sprintf("No access key has the ID, ID prefix or name %q.", keyID),
+			)
+			return
+		}
+
+		keyID = key.AccessKeyID
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), keyID)...)
+}
+
+// keyUpdateRequest builds the update request for the planned key. Every
+// setting is sent, so the key converges to the configuration whichever
+// attributes changed. Without an expiration the key is explicitly set to
+// never expire, so that removing the expiration from the configuration also
+// removes it in Garage. The key is renamed to the planned full name, or to
+// the name after namePrefix when the full name could not be planned. A name
+// left unknown keeps the current name.
+func keyUpdateRequest(data KeyResourceModel, namePrefix string) client.UpdateKeyRequest {
+	req := client.UpdateKeyRequest{
+		Expiration:   data.Expiration.ValueStringPointer(),
+		NeverExpires: data.Expiration.IsNull(),
+	}
+
+	if !data.FullName.IsNull() && !data.FullName.IsUnknown() {
+		req.Name = data.FullName.ValueStringPointer()
+	} else if !data.Name.IsNull() && !data.Name.IsUnknown() {
+		name := namePrefix + data.Name.ValueString()
+		req.Name = &name
+	}
+
+	if data.CreateBucket.ValueBool() {
+		req.Allow = &client.KeyPermissions{CreateBucket: true}
+	} else {
+		req.Deny = &client.KeyPermissions{CreateBucket: true}
+	}
+
+	return req
+}
+
+// updateStateFromKey updates the resource state from key info, with namePrefix
+// removed from the name. The secret access key is only returned on creation,
+// so it is left unchanged.
+func updateStateFromKey(data *KeyResourceModel, key *client.AccessKey, namePrefix string) {
+	data.ID = types.StringValue(key.AccessKeyID)
+	data.Name = types.StringValue(strings.TrimPrefix(key.Name, namePrefix))
+	data.FullName = types.StringValue(key.Name)
+	data.Created = types.StringPointerValue(key.Created)
+	data.Expired = types.BoolValue(key.Expired)
+	data.CreateBucket = types.BoolValue(key.Permissions.CreateBucket)
+
+	// Keep the configured timestamp when Garage formats the same instant differently
+	if key.Expiration == nil || !sameInstant(data.Expiration.ValueString(), *key.Expiration) {
+		data.Expiration = types.StringPointerValue(key.Expiration)
+	}
+}
+
+// keyName returns the name to give a new key, after the provider's
+// key_name_prefix, or nil to let Garage name it.
+func (r *KeyResource) keyName(data KeyResourceModel) *string {
+	var name string
+	switch {
+	case !data.Name.IsNull() && !data.Name.IsUnknown():
+		name = data.Name.ValueString()
+	case !data.NamePrefix.IsNull():
+		name = prefixedUniqueName(data.NamePrefix.ValueString())
+	default:
+		return nil
+	}
+
+	name = r.client.KeyNamePrefix() + name
+	return &name
+}
+
+// prefixedUniqueName returns a name starting with prefix followed by a
+// timestamp and a random suffix, so that names generated concurrently for
+// several resources do not collide.
+func prefixedUniqueName(prefix string) string {
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		panic(fmt.Sprintf("failed to generate random name suffix: %v", err))
+	}
+
+	return prefix + time.Now().UTC().Format("20060102150405") + hex.EncodeToString(suffix)
 }
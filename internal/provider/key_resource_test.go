@@ -10,7 +10,10 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 // generateGarageKeyID generates a random Garage key ID (GK + 24 hex characters).
@@ -41,6 +44,7 @@ func TestAccKeyResource_basic(t *testing.T) {
 				Config: testAccKeyResourceConfig_basic("test-key-basic"),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("garage_key.test", "name", "test-key-basic"),
+					resource.TestCheckResourceAttrSet("garage_key.test", "created"),
 					resource.TestCheckResourceAttrSet("garage_key.test", "id"),
 					resource.TestCheckResourceAttrSet("garage_key.test", "secret_access_key"),
 				),
@@ -53,6 +57,14 @@ func TestAccKeyResource_basic(t *testing.T) {
 				// Note: We need to ignore both secret_access_key (only on creation) and name (computed field)
 				ImportStateVerifyIgnore: []string{"secret_access_key"},
 			},
+			// Import by key name
+			{
+				ResourceName:            "garage_key.test",
+				ImportState:             true,
+				ImportStateId:           "test-key-basic",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret_access_key"},
+			},
 			// Delete testing automatically occurs in TestCase
 		},
 	})
@@ -77,6 +89,40 @@ func TestAccKeyResource_withoutName(t *testing.T) {
 	})
 }
 
+func TestAccKeyResource_namePrefix(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKeyResourceConfig_namePrefix("test-key-prefix-"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_key.test", "name_prefix", "test-key-prefix-"),
+					resource.TestMatchResourceAttr("garage_key.test", "name", regexp.MustCompile(`^test-key-prefix-\d{14}[0-9a-f]{8}$`)),
+				),
+			},
+			// name and name_prefix are mutually exclusive
+			{
+				Config:      testAccKeyResourceConfig_nameAndPrefix("test-key", "test-key-prefix-"),
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+		},
+	})
+}
+
+func TestPrefixedUniqueName(t *testing.T) {
+	first := prefixedUniqueName("app-")
+	second := prefixedUniqueName("app-")
+
+	if !regexp.MustCompile(`^app-\d{14}[0-9a-f]{8}$`).MatchString(first) {
+		t.Errorf("Unexpected generated name %q", first)
+	}
+
+	if first == second {
+		t.Errorf("Expected unique names, got %q twice", first)
+	}
+}
+
 func TestAccKeyResource_multipleKeys(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -177,6 +223,53 @@ func TestAccKeyResource_importWithPredefinedCredentials(t *testing.T) {
 	})
 }
 
+func TestAccKeyResource_importWriteOnlySecret(t *testing.T) {
+	keyID := generateGarageKeyID()
+	secret1 := generateGarageSecret()
+	secret2 := generateGarageSecret()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Import key with a write-only secret, which stays out of the state
+			{
+				Config: testAccKeyResourceConfig_importWriteOnly(keyID, secret1, 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_key.test", "id", keyID),
+					resource.TestCheckNoResourceAttr("garage_key.test", "secret_access_key"),
+					resource.TestCheckNoResourceAttr("garage_key.test", "secret_access_key_wo"),
+				),
+			},
+			// A new secret alone is not detected
+			{
+				Config: testAccKeyResourceConfig_importWriteOnly(keyID, secret2, 1),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			// Bumping the version imports the key again with the new secret
+			{
+				Config: testAccKeyResourceConfig_importWriteOnly(keyID, secret2, 2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("garage_key.test", plancheck.ResourceActionReplace),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_key.test", "secret_access_key_version", "2"),
+					resource.TestCheckNoResourceAttr("garage_key.test", "secret_access_key"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccKeyResource_importWithoutName(t *testing.T) {
 	keyID := generateGarageKeyID()
 	secret := generateGarageSecret()
@@ -243,6 +336,186 @@ func TestAccKeyResource_changesRequireReplacement(t *testing.T) {
 
 // Test configuration functions
 
+func TestAccKeyResource_expiration(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create a key that expires
+			{
+				Config: testAccKeyResourceConfig_expiration("test-key-expiration", "2099-01-01T00:00:00Z"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_key.test", "expiration", "2099-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("garage_key.test", "expired", "false"),
+				),
+			},
+			// Move the expiration in place
+			{
+				Config: testAccKeyResourceConfig_expiration("test-key-expiration", "2098-06-01T00:00:00Z"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("garage_key.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_key.test", "expiration", "2098-06-01T00:00:00Z"),
+					resource.TestCheckResourceAttrSet("garage_key.test", "secret_access_key"),
+				),
+			},
+			// Remove the expiration again
+			{
+				Config: testAccKeyResourceConfig_basic("test-key-expiration"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("garage_key.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("garage_key.test", "expiration"),
+					resource.TestCheckResourceAttr("garage_key.test", "expired", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeyResource_update(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKeyResourceConfig_basic("test-key-update"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_key.test", "name", "test-key-update"),
+					resource.TestCheckResourceAttr("garage_key.test", "create_bucket", "false"),
+				),
+			},
+			// Rename the key and allow it to create buckets in place
+			{
+				Config: testAccKeyResourceConfig_createBucket("test-key-update-renamed", true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("garage_key.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_key.test", "name", "test-key-update-renamed"),
+					resource.TestCheckResourceAttr("garage_key.test", "create_bucket", "true"),
+					resource.TestCheckResourceAttrSet("garage_key.test", "secret_access_key"),
+				),
+			},
+			// Refreshing reflects the new name and permission
+			{
+				RefreshState: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_key.test", "name", "test-key-update-renamed"),
+					resource.TestCheckResourceAttr("garage_key.test", "create_bucket", "true"),
+				),
+			},
+			// Revoke the permission again
+			{
+				Config: testAccKeyResourceConfig_createBucket("test-key-update-renamed", false),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("garage_key.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_key.test", "create_bucket", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccKeyResource_namePrefixFromProvider(t *testing.T) {
+	t.Setenv("GARAGE_KEY_NAME_PREFIX", "tfacc-dev-")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKeyResourceConfig_basic("test-key-prefix"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_key.test", "name", "test-key-prefix"),
+					resource.TestCheckResourceAttr("garage_key.test", "full_name", "tfacc-dev-test-key-prefix"),
+				),
+			},
+			// Changing the prefix renames the key in place
+			{
+				PreConfig: func() { t.Setenv("GARAGE_KEY_NAME_PREFIX", "tfacc-stg-") },
+				Config:    testAccKeyResourceConfig_basic("test-key-prefix"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("garage_key.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_key.test", "name", "test-key-prefix"),
+					resource.TestCheckResourceAttr("garage_key.test", "full_name", "tfacc-stg-test-key-prefix"),
+				),
+			},
+		},
+	})
+}
+
+func TestKeyUpdateRequest(t *testing.T) {
+	req := keyUpdateRequest(KeyResourceModel{
+		Name:         types.StringValue("renamed"),
+		FullName:     types.StringValue("dev-renamed"),
+		Expiration:   types.StringNull(),
+		CreateBucket: types.BoolValue(true),
+	}, "dev-")
+
+	if req.Name == nil || *req.Name != "dev-renamed" {
+		t.Errorf("Expected name dev-renamed, got %v", req.Name)
+	}
+	if !req.NeverExpires || req.Expiration != nil {
+		t.Errorf("Expected the key to never expire, got %+v", req)
+	}
+	if req.Allow == nil || !req.Allow.CreateBucket || req.Deny != nil {
+		t.Errorf("Expected createBucket to be allowed, got allow %v deny %v", req.Allow, req.Deny)
+	}
+
+	req = keyUpdateRequest(KeyResourceModel{
+		Name:         types.StringUnknown(),
+		FullName:     types.StringUnknown(),
+		Expiration:   types.StringValue("2099-01-01T00:00:00Z"),
+		CreateBucket: types.BoolValue(false),
+	}, "dev-")
+
+	if req.Name != nil {
+		t.Errorf("Expected an unknown name to be left unchanged, got %q", *req.Name)
+	}
+	if req.NeverExpires || req.Expiration == nil {
+		t.Errorf("Expected an expiration, got %+v", req)
+	}
+	if req.Deny == nil || !req.Deny.CreateBucket || req.Allow != nil {
+		t.Errorf("Expected createBucket to be denied, got allow %v deny %v", req.Allow, req.Deny)
+	}
+}
+
+func TestAccKeyResource_importWithExpiration(t *testing.T) {
+	keyID := generateGarageKeyID()
+	secret := generateGarageSecret()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKeyResourceConfig_importExpiration(keyID, secret, "2099-01-01T00:00:00Z"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_key.test", "id", keyID),
+					resource.TestCheckResourceAttr("garage_key.test", "expiration", "2099-01-01T00:00:00Z"),
+				),
+			},
+		},
+	})
+}
+
 func testAccKeyResourceConfig_basic(name string) string {
 	return fmt.Sprintf(`
 resource "garage_key" "test" {
@@ -258,6 +531,23 @@ resource "garage_key" "test" {
 `
 }
 
+func testAccKeyResourceConfig_namePrefix(prefix string) string {
+	return fmt.Sprintf(`
+resource "garage_key" "test" {
+  name_prefix = %[1]q
+}
+`, prefix)
+}
+
+func testAccKeyResourceConfig_nameAndPrefix(name, prefix string) string {
+	return fmt.Sprintf(`
+resource "garage_key" "test" {
+  name        = %[1]q
+  name_prefix = %[2]q
+}
+`, name, prefix)
+}
+
 func testAccKeyResourceConfig_multiple(name1, name2 string) string {
 	return fmt.Sprintf(`
 resource "garage_key" "test1" {
@@ -309,6 +599,16 @@ resource "garage_key" "test" {
 `, id, secret)
 }
 
+func testAccKeyResourceConfig_importWriteOnly(id, secret string, version int) string {
+	return fmt.Sprintf(`
+resource "garage_key" "test" {
+  id                        = %[1]q
+  secret_access_key_wo      = %[2]q
+  secret_access_key_version = %[3]d
+}
+`, id, secret, version)
+}
+
 func testAccKeyResourceConfig_onlyID(id string) string {
 	return fmt.Sprintf(`
 resource "garage_key" "test" {
@@ -316,3 +616,31 @@ resource "garage_key" "test" {
 }
 `, id)
 }
+
+func testAccKeyResourceConfig_expiration(name, expiration string) string {
+	return fmt.Sprintf(`
+resource "garage_key" "test" {
+  name       = %[1]q
+  expiration = %[2]q
+}
+`, name, expiration)
+}
+
+func testAccKeyResourceConfig_importExpiration(id, secret, expiration string) string {
+	return fmt.Sprintf(`
+resource "garage_key" "test" {
+  id                = %[1]q
+  secret_access_key = %[2]q
+  expiration        = %[3]q
+}
+`, id, secret, expiration)
+}
+
+func testAccKeyResourceConfig_createBucket(name string, createBucket bool) string {
+	return fmt.Sprintf(`
+resource "garage_key" "test" {
+  name          = %[1]q
+  create_bucket = %[2]t
+}
+`, name, createBucket)
+}
@@ -0,0 +1,236 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &KeysDataSource{}
+
+func NewKeysDataSource() datasource.DataSource {
+	return &KeysDataSource{}
+}
+
+// KeysDataSource defines the data source implementation.
+type KeysDataSource struct {
+	client *client.Client
+}
+
+// KeysDataSourceModel describes the data source data model.
+type KeysDataSourceModel struct {
+	Search         types.String   `tfsdk:"search"`
+	NameRegex      types.String   `tfsdk:"name_regex"`
+	IncludeExpired types.Bool     `tfsdk:"include_expired"`
+	OnlyExpired    types.Bool     `tfsdk:"only_expired"`
+	IDs            []types.String `tfsdk:"ids"`
+	Keys           []KeyListModel `tfsdk:"keys"`
+}
+
+// KeyListModel describes a single access key of the list.
+type KeyListModel struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Created    types.String `tfsdk:"created"`
+	Expiration types.String `tfsdk:"expiration"`
+	Expired    types.Bool   `tfsdk:"expired"`
+}
+
+func (d *KeysDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_keys"
+}
+
+func (d *KeysDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the access keys of the cluster, optionally filtered by name and expiration, " +
+			"to audit credentials or reference keys created outside Terraform. Secret access keys are never returned.",
+
+		Attributes: map[string]schema.Attribute{
+			"search": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only list the keys Garage would match for this pattern: keys whose access key ID starts with it or whose name is exactly it.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"name_regex": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "A regular expression the key name must match, e.g. `^ci-`. The expression is unanchored, so it matches anywhere in the name unless `^` or `$` are used.",
+				Validators: []validator.String{
+					regularExpression(),
+				},
+			},
+			"include_expired": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether expired keys are listed. Defaults to `true`.",
+			},
+			"only_expired": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "List only the keys that have expired. Defaults to `false`. Cannot be combined with `include_expired = false`.",
+			},
+			"ids": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The access key IDs of the listed keys.",
+			},
+			"keys": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The listed keys, in the order returned by Garage.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The access key ID.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the key.",
+						},
+						"created": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "When the key was created, if known.",
+						},
+						"expiration": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "When the key expires, or null if it never expires.",
+						},
+						"expired": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the key has expired.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *KeysDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *KeysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data KeysDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.OnlyExpired.ValueBool() && !data.IncludeExpired.IsNull() && !data.IncludeExpired.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("only_expired"),
+			"Conflicting Expiration Filters",
+			"only_expired cannot be true when include_expired is false, as no key could be listed.",
+		)
+		return
+	}
+
+	filter := keyFilter{
+		includeExpired: data.IncludeExpired.IsNull() || data.IncludeExpired.ValueBool(),
+		onlyExpired:    data.OnlyExpired.ValueBool(),
+	}
+
+	if !data.NameRegex.IsNull() {
+		pattern, err := regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid Regular Expression", err.Error())
+			return
+		}
+		filter.name = pattern
+	}
+
+	data.IDs = []types.String{}
+	data.Keys = []KeyListModel{}
+	listed := 0
+	keep := func(key client.KeyListItem) error {
+		listed++
+		if !filter.matches(key) {
+			return nil
+		}
+
+		data.IDs = append(data.IDs, types.StringValue(key.ID))
+		data.Keys = append(data.Keys, KeyListModel{
+			ID:         types.StringValue(key.ID),
+			Name:       types.StringValue(key.Name),
+			Created:    types.StringPointerValue(key.Created),
+			Expiration: types.StringPointerValue(key.Expiration),
+			Expired:    types.BoolValue(key.Expired),
+		})
+		return nil
+	}
+
+	// Keys are filtered as the list is received, so that only the kept keys
+	// are held in memory
+	var err error
+	if data.Search.IsNull() {
+		err = d.client.EachKey(ctx, keep)
+	} else {
+		var keys []client.KeyListItem
+		keys, err = d.client.SearchKeys(ctx, data.Search.ValueString())
+		for _, key := range keys {
+			_ = keep(key)
+		}
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, "list keys", err)
+		return
+	}
+
+	tflog.Trace(ctx, "Read keys data source", map[string]interface{}{
+		"listed": listed,
+		"kept":   len(data.Keys),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// keyFilter selects the keys listed by the garage_keys data source.
+type keyFilter struct {
+	name           *regexp.Regexp
+	includeExpired bool
+	onlyExpired    bool
+}
+
+// matches reports whether the key passes the filter.
+func (f keyFilter) matches(key client.KeyListItem) bool {
+	if f.name != nil && !f.name.MatchString(key.Name) {
+		return false
+	}
+
+	if key.Expired {
+		return f.includeExpired
+	}
+
+	return !f.onlyExpired
+}
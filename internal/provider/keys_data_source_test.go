@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-garage/internal/client"
+)
+
+func TestAccKeysDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccKeysDataSourceConfig("test-keys-list"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.garage_keys.test", "keys.#", "2"),
+					resource.TestCheckResourceAttr("data.garage_keys.test", "ids.#", "2"),
+					resource.TestCheckTypeSetElemAttrPair("data.garage_keys.test", "ids.*", "garage_key.first", "id"),
+					resource.TestCheckTypeSetElemAttrPair("data.garage_keys.test", "ids.*", "garage_key.second", "id"),
+					resource.TestCheckResourceAttr("data.garage_keys.only_expired", "keys.#", "0"),
+					resource.TestCheckResourceAttr("data.garage_keys.search", "ids.#", "1"),
+					resource.TestCheckResourceAttrPair("data.garage_keys.search", "ids.0", "garage_key.second", "id"),
+				),
+			},
+			{
+				Config:      testAccKeysDataSourceConfig_conflictingFilters(),
+				ExpectError: regexp.MustCompile("Conflicting Expiration Filters"),
+			},
+		},
+	})
+}
+
+func TestKeyFilter(t *testing.T) {
+	active := client.KeyListItem{ID: "GK1", Name: "ci-deploy"}
+	expired := client.KeyListItem{ID: "GK2", Name: "ci-old", Expired: true}
+	other := client.KeyListItem{ID: "GK3", Name: "backup"}
+
+	tests := []struct {
+		name   string
+		filter keyFilter
+		want   []string
+	}{
+		{name: "all", filter: keyFilter{includeExpired: true}, want: []string{"GK1", "GK2", "GK3"}},
+		{name: "name", filter: keyFilter{name: regexp.MustCompile("^ci-"), includeExpired: true}, want: []string{"GK1", "GK2"}},
+		{name: "exclude expired", filter: keyFilter{}, want: []string{"GK1", "GK3"}},
+		{name: "only expired", filter: keyFilter{includeExpired: true, onlyExpired: true}, want: []string{"GK2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, key := range []client.KeyListItem{active, expired, other} {
+				if tt.filter.matches(key) {
+					got = append(got, key.ID)
+				}
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("Expected keys %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func testAccKeysDataSourceConfig(prefix string) string {
+	return fmt.Sprintf(`
+resource "garage_key" "first" {
+  name = "%[1]s-first"
+}
+
+resource "garage_key" "second" {
+  name = "%[1]s-second"
+}
+
+data "garage_keys" "test" {
+  name_regex = "^%[1]s-"
+
+  depends_on = [garage_key.first, garage_key.second]
+}
+
+data "garage_keys" "only_expired" {
+  name_regex   = "^%[1]s-"
+  only_expired = true
+
+  depends_on = [garage_key.first, garage_key.second]
+}
+
+data "garage_keys" "search" {
+  search = garage_key.second.name
+}
+`, prefix)
+}
+
+func testAccKeysDataSourceConfig_conflictingFilters() string {
+	return `
+data "garage_keys" "test" {
+  include_expired = false
+  only_expired    = true
+}
+`
+}
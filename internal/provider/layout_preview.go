@@ -0,0 +1,163 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// capacityDelta is the storage capacity of a zone or node before and after a
+// layout change, in bytes.
+type capacityDelta struct {
+	Name   string
+	Before int64
+	After  int64
+}
+
+// layoutPreview describes how a role change affects the capacity of the
+// cluster. MovedFraction estimates the fraction of the data that changes
+// nodes, assuming data is spread in proportion to capacity.
+type layoutPreview struct {
+	Zones         []capacityDelta
+	Nodes         []capacityDelta
+	MovedFraction float64
+}
+
+// previewRoleChange computes the capacity deltas caused by applying a role
+// change to the current layout. Gateway nodes have no capacity and only show
+// up when they become or stop being storage nodes.
+func previewRoleChange(layout *client.ClusterLayout, change client.NodeRoleChange) layoutPreview {
+	type role struct {
+		zone     string
+		capacity int64
+	}
+
+	before := make(map[string]role, len(layout.Roles))
+	for _, r := range layout.Roles {
+		if r.Capacity != nil {
+			before[r.ID] = role{zone: r.Zone, capacity: *r.Capacity}
+		}
+	}
+
+	after := make(map[string]role, len(before)+1)
+	for id, r := range before {
+		after[id] = r
+	}
+	delete(after, change.ID)
+	if !change.Remove && change.Capacity != nil {
+		after[change.ID] = role{zone: change.Zone, capacity: *change.Capacity}
+	}
+
+	zones := map[string]*capacityDelta{}
+	zone := func(name string) *capacityDelta {
+		if zones[name] == nil {
+			zones[name] = &capacityDelta{Name: name}
+		}
+		return zones[name]
+	}
+
+	var totalBefore, totalAfter int64
+	for _, r := range before {
+		zone(r.zone).Before += r.capacity
+		totalBefore += r.capacity
+	}
+	for _, r := range after {
+		zone(r.zone).After += r.capacity
+		totalAfter += r.capacity
+	}
+
+	var preview layoutPreview
+
+	for _, z := range zones {
+		if z.Before != z.After {
+			preview.Zones = append(preview.Zones, *z)
+		}
+	}
+	sort.Slice(preview.Zones, func(i, j int) bool { return preview.Zones[i].Name < preview.Zones[j].Name })
+
+	if before[change.ID] != after[change.ID] {
+		preview.Nodes = append(preview.Nodes, capacityDelta{
+			Name:   change.ID,
+			Before: before[change.ID].capacity,
+			After:  after[change.ID].capacity,
+		})
+	}
+
+	// Data moves to the nodes whose share of the total capacity grows
+	if totalBefore > 0 && totalAfter > 0 {
+		ids := map[string]bool{}
+		for id := range before {
+			ids[id] = true
+		}
+		for id := range after {
+			ids[id] = true
+		}
+
+		for id := range ids {
+			shareBefore := float64(before[id].capacity) / float64(totalBefore)
+			shareAfter := float64(after[id].capacity) / float64(totalAfter)
+			if shareAfter > shareBefore {
+				preview.MovedFraction += shareAfter - shareBefore
+			}
+		}
+	}
+
+	return preview
+}
+
+// String formats the preview for a plan diagnostic.
+func (p layoutPreview) String() string {
+	var b strings.Builder
+
+	for _, z := range p.Zones {
+		fmt.Fprintf(&b, "Zone %s: %s\n", z.Name, z.format())
+	}
+	for _, n := range p.Nodes {
+		fmt.Fprintf(&b, "Node %s: %s\n", shortNodeID(n.Name), n.format())
+	}
+	fmt.Fprintf(&b, "Estimated data moved: %.1f%%", p.MovedFraction*100)
+
+	return b.String()
+}
+
+func (d capacityDelta) format() string {
+	sign := "+"
+	if d.After < d.Before {
+		sign = "-"
+	}
+	diff := d.After - d.Before
+	if diff < 0 {
+		diff = -diff
+	}
+	return fmt.Sprintf("%s -> %s (%s%s)", formatBytes(d.Before), formatBytes(d.After), sign, formatBytes(diff))
+}
+
+// formatBytes formats a size in bytes using decimal units, as Garage does.
+func formatBytes(n int64) string {
+	const unit = 1000
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	value := float64(n)
+	suffixes := []string{"KB", "MB", "GB", "TB", "PB", "EB"}
+	i := -1
+	for value >= unit && i < len(suffixes)-1 {
+		value /= unit
+		i++
+	}
+	return fmt.Sprintf("%.1f %s", value, suffixes[i])
+}
+
+// shortNodeID abbreviates a node ID the way the Garage CLI displays it.
+func shortNodeID(id string) string {
+	if len(id) > 16 {
+		return id[:16]
+	}
+	return id
+}
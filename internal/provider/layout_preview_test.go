@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"terraform-provider-garage/internal/client"
+)
+
+func TestPreviewRoleChange(t *testing.T) {
+	capacity := func(n int64) *int64 { return &n }
+
+	layout := &client.ClusterLayout{
+		Roles: []client.LayoutNodeRole{
+			{ID: "node1", Zone: "dc1", Capacity: capacity(100)},
+			{ID: "node2", Zone: "dc2", Capacity: capacity(100)},
+			{ID: "gateway", Zone: "dc1"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		change client.NodeRoleChange
+		zones  []capacityDelta
+		nodes  []capacityDelta
+		moved  float64
+	}{
+		{
+			name:   "add node",
+			change: client.NodeRoleChange{ID: "node3", Zone: "dc1", Capacity: capacity(200)},
+			zones:  []capacityDelta{{Name: "dc1", Before: 100, After: 300}},
+			nodes:  []capacityDelta{{Name: "node3", Before: 0, After: 200}},
+			moved:  0.5,
+		},
+		{
+			name:   "remove node",
+			change: client.NodeRoleChange{ID: "node2", Remove: true},
+			zones:  []capacityDelta{{Name: "dc2", Before: 100, After: 0}},
+			nodes:  []capacityDelta{{Name: "node2", Before: 100, After: 0}},
+			moved:  0.5,
+		},
+		{
+			name:   "unchanged",
+			change: client.NodeRoleChange{ID: "node1", Zone: "dc1", Capacity: capacity(100)},
+		},
+		{
+			name:   "gateway stays gateway",
+			change: client.NodeRoleChange{ID: "gateway", Zone: "dc2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			preview := previewRoleChange(layout, tt.change)
+
+			if len(preview.Zones) != len(tt.zones) {
+				t.Fatalf("Expected zones %v, got %v", tt.zones, preview.Zones)
+			}
+			for i := range tt.zones {
+				if preview.Zones[i] != tt.zones[i] {
+					t.Errorf("Expected zone %v, got %v", tt.zones[i], preview.Zones[i])
+				}
+			}
+
+			if len(preview.Nodes) != len(tt.nodes) {
+				t.Fatalf("Expected nodes %v, got %v", tt.nodes, preview.Nodes)
+			}
+			for i := range tt.nodes {
+				if preview.Nodes[i] != tt.nodes[i] {
+					t.Errorf("Expected node %v, got %v", tt.nodes[i], preview.Nodes[i])
+				}
+			}
+
+			if math.Abs(preview.MovedFraction-tt.moved) > 1e-9 {
+				t.Errorf("Expected moved fraction %v, got %v", tt.moved, preview.MovedFraction)
+			}
+		})
+	}
+}
+
+func TestLayoutPreviewString(t *testing.T) {
+	preview := layoutPreview{
+		Zones:         []capacityDelta{{Name: "dc1", Before: 1_000_000_000, After: 1_500_000_000}},
+		Nodes:         []capacityDelta{{Name: "563e1ac825ee3323aa441e72c26d1030d6d4414aeb3dd25287c531e7fc2bc95d", Before: 0, After: 500_000_000}},
+		MovedFraction: 1.0 / 3,
+	}
+
+	got := preview.String()
+	for _, want := range []string{
+		"Zone dc1: 1.0 GB -> 1.5 GB (+500.0 MB)",
+		"Node 563e1ac825ee3323: 0 B -> 500.0 MB (+500.0 MB)",
+		"Estimated data moved: 33.3%",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected preview to contain %q, got %q", want, got)
+		}
+	}
+}
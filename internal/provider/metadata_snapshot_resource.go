@@ -0,0 +1,215 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &MetadataSnapshotResource{}
+var _ resource.ResourceWithModifyPlan = &MetadataSnapshotResource{}
+
+func NewMetadataSnapshotResource() resource.Resource {
+	return &MetadataSnapshotResource{}
+}
+
+// MetadataSnapshotResource defines the resource implementation.
+type MetadataSnapshotResource struct {
+	client *client.Client
+}
+
+// MetadataSnapshotResourceModel describes the resource data model.
+type MetadataSnapshotResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Node          types.String `tfsdk:"node"`
+	Triggers      types.Map    `tfsdk:"triggers"`
+	SnapshotNodes types.List   `tfsdk:"snapshot_nodes"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *MetadataSnapshotResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_metadata_snapshot"
+}
+
+func (r *MetadataSnapshotResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Snapshots the metadata database of one or all nodes, like `garage meta snapshot`, for instance right before a layout change. " +
+			"A snapshot is taken when the resource is created and every time it is updated, for instance when `triggers` change. Destroying the resource does not delete the snapshots, which Garage keeps in its `metadata_snapshots_dir`.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the snapshot (same as `node`).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"node": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(client.AllNodes),
+				MarkdownDescription: "The node to snapshot: a node ID, `self` for the node the provider talks to, or `*` for every node of the cluster. Defaults to `*`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Arbitrary values that take a new snapshot when they change, such as the capacities of the nodes about to be changed in the layout.",
+			},
+			"snapshot_nodes": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The IDs of the nodes that took a snapshot the last time it ran.",
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			// Read and Delete make no API call, so they take no timeout
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *MetadataSnapshotResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *MetadataSnapshotResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	validateTokenScope(ctx, r.client, resourceScopes{
+		Create: []string{"CreateMetadataSnapshot"},
+		Update: []string{"CreateMetadataSnapshot"},
+	}, req, resp)
+}
+
+func (r *MetadataSnapshotResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data MetadataSnapshotResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	r.snapshot(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.Node
+
+	tflog.Trace(ctx, "Created metadata snapshot resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MetadataSnapshotResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// A snapshot leaves nothing to refresh
+	var data MetadataSnapshotResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MetadataSnapshotResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data MetadataSnapshotResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	r.snapshot(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Updated metadata snapshot resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *MetadataSnapshotResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Nothing to undo: the snapshots stay in the metadata_snapshots_dir of each node
+	tflog.Trace(ctx, "Deleted metadata snapshot resource")
+}
+
+// snapshot snapshots the metadata database and records the nodes that did.
+func (r *MetadataSnapshotResource) snapshot(ctx context.Context, data *MetadataSnapshotResourceModel, diags *diag.Diagnostics) {
+	node := data.Node.ValueString()
+
+	tflog.Debug(ctx, "Creating metadata snapshot", map[string]interface{}{
+		"node": node,
+	})
+
+	result, err := r.client.CreateMetadataSnapshot(ctx, node)
+	if err != nil {
+		addClientError(diags, "create metadata snapshot", err)
+		return
+	}
+
+	for nodeID, message := range result.Error {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to snapshot the metadata of node %s, got error: %s", nodeID, message))
+	}
+	if diags.HasError() {
+		return
+	}
+
+	snapshotted := make([]string, 0, len(result.Success))
+	for nodeID := range result.Success {
+		snapshotted = append(snapshotted, nodeID)
+	}
+	sort.Strings(snapshotted)
+
+	snapshotNodes, d := types.ListValueFrom(ctx, types.StringType, snapshotted)
+	diags.Append(d...)
+	data.SnapshotNodes = snapshotNodes
+}
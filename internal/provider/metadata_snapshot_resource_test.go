@@ -0,0 +1,47 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccMetadataSnapshotResource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Snapshot every node
+			{
+				Config: testAccMetadataSnapshotResourceConfig("1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_metadata_snapshot.test", "id", "*"),
+					resource.TestCheckResourceAttr("garage_metadata_snapshot.test", "node", "*"),
+					resource.TestCheckResourceAttrSet("garage_metadata_snapshot.test", "snapshot_nodes.0"),
+				),
+			},
+			// Changing the triggers takes a new snapshot
+			{
+				Config: testAccMetadataSnapshotResourceConfig("2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_metadata_snapshot.test", "triggers.run", "2"),
+					resource.TestCheckResourceAttrSet("garage_metadata_snapshot.test", "snapshot_nodes.0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMetadataSnapshotResourceConfig(run string) string {
+	return fmt.Sprintf(`
+resource "garage_metadata_snapshot" "test" {
+  triggers = {
+    run = %[1]q
+  }
+}
+`, run)
+}
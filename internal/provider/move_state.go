@@ -0,0 +1,215 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Other Garage providers name their resources like this provider does, but
+// their attribute names differ. The movers below read the source state as
+// raw JSON so that `moved` blocks work whatever version of the source schema
+// the state was written with. Only the identifiers are carried over: the
+// remaining attributes are refreshed from Garage by the next Read.
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.ResourceWithMoveState = &BucketResource{}
+var _ resource.ResourceWithMoveState = &KeyResource{}
+var _ resource.ResourceWithMoveState = &BucketPermissionResource{}
+
+func (r *BucketResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				attrs := movedAttributes(req, resp, "garage_bucket")
+				if attrs == nil {
+					return
+				}
+
+				data := BucketResourceModel{
+					ID:             attrs.String("id", "bucket_id"),
+					GlobalAlias:    attrs.String("global_alias", "name", "bucket"),
+					GlobalAliases:  types.SetNull(types.StringType),
+					LocalAlias:     types.ObjectNull(bucketLocalAliasAttributeTypes),
+					WebsiteEnabled: attrs.Bool(false, "website_enabled", "website_access"),
+					WebsiteIndex:   attrs.String("website_index_document", "index_document"),
+					WebsiteError:   attrs.String("website_error_document", "error_document"),
+					WebsiteSeed:    types.BoolValue(false),
+					MaxSize:        attrs.Int64("max_size", "quota_max_size"),
+					MaxObjects:     attrs.Int64("max_objects", "quota_max_objects"),
+					SkipDestroy:    types.BoolValue(false),
+					ForceDestroy:   attrs.Bool(false, "force_destroy"),
+					Keys:           types.ListNull(types.ObjectType{AttrTypes: bucketKeyAttributeTypes}),
+
+					CleanupUploadsOlderThan: attrs.String("cleanup_incomplete_uploads_older_than"),
+					UnfinishedUploads:       types.Int64Null(),
+
+					Timeouts: nullTimeouts(),
+				}
+
+				if data.GlobalAlias.IsNull() {
+					data.GlobalAlias = attrs.FirstString("global_aliases", "aliases")
+				}
+
+				if data.ID.IsNull() {
+					resp.Diagnostics.AddError("Unable to Move Resource State", "The source garage_bucket state has no bucket ID.")
+					return
+				}
+
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, &data)...)
+			},
+		},
+	}
+}
+
+func (r *KeyResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				attrs := movedAttributes(req, resp, "garage_key")
+				if attrs == nil {
+					return
+				}
+
+				data := KeyResourceModel{
+					ID:              attrs.String("access_key_id", "key_id", "id"),
+					Name:            attrs.String("name"),
+					NamePrefix:      types.StringNull(),
+					SecretAccessKey: attrs.String("secret_access_key", "secret_key"),
+					Created:         types.StringNull(),
+					Expiration:      types.StringNull(),
+					Expired:         types.BoolNull(),
+					CreateBucket:    attrs.Bool(false, "allow_create_bucket", "create_bucket"),
+
+					SecretAccessKeyWO:      types.StringNull(),
+					SecretAccessKeyVersion: types.Int64Null(),
+
+					Timeouts: nullTimeouts(),
+				}
+
+				if data.ID.IsNull() {
+					resp.Diagnostics.AddError("Unable to Move Resource State", "The source garage_key state has no access key ID.")
+					return
+				}
+
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, &data)...)
+			},
+		},
+	}
+}
+
+func (r *BucketPermissionResource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				attrs := movedAttributes(req, resp, "garage_bucket_key", "garage_bucket_permission")
+				if attrs == nil {
+					return
+				}
+
+				data := BucketPermissionResourceModel{
+					BucketID:       attrs.String("bucket_id"),
+					AccessKeyID:    attrs.String("access_key_id", "key_id"),
+					Read:           attrs.Bool(false, "read", "allow_read"),
+					Write:          attrs.Bool(false, "write", "allow_write"),
+					Owner:          attrs.Bool(false, "owner", "allow_owner"),
+					EffectiveRead:  types.BoolNull(),
+					EffectiveWrite: types.BoolNull(),
+					EffectiveOwner: types.BoolNull(),
+					LocalAliases:   types.ListNull(types.StringType),
+
+					Timeouts: nullTimeouts(),
+				}
+
+				if data.BucketID.IsNull() || data.AccessKeyID.IsNull() {
+					resp.Diagnostics.AddError(
+						"Unable to Move Resource State",
+						fmt.Sprintf("The source %s state must contain both a bucket ID and an access key ID.", req.SourceTypeName),
+					)
+					return
+				}
+
+				data.ID = types.StringValue(fmt.Sprintf("%s/%s", data.BucketID.ValueString(), data.AccessKeyID.ValueString()))
+
+				resp.Diagnostics.Append(resp.TargetState.Set(ctx, &data)...)
+			},
+		},
+	}
+}
+
+// rawAttributes holds the attributes of a resource state written by another provider.
+type rawAttributes map[string]interface{}
+
+// movedAttributes decodes the source state of a move when the source resource
+// type is one of typeNames. It returns nil when the mover does not apply, so
+// that Terraform reports the move as unsupported.
+func movedAttributes(req resource.MoveStateRequest, resp *resource.MoveStateResponse, typeNames ...string) rawAttributes {
+	matched := false
+	for _, typeName := range typeNames {
+		if req.SourceTypeName == typeName {
+			matched = true
+		}
+	}
+	if !matched || req.SourceRawState == nil {
+		return nil
+	}
+
+	var attrs rawAttributes
+	if err := json.Unmarshal(req.SourceRawState.JSON, &attrs); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Move Resource State",
+			fmt.Sprintf("Unable to decode the %s state of provider %s: %s", req.SourceTypeName, req.SourceProviderAddress, err),
+		)
+		return nil
+	}
+
+	return attrs
+}
+
+// String returns the first non-empty string attribute among names.
+func (a rawAttributes) String(names ...string) types.String {
+	for _, name := range names {
+		if value, ok := a[name].(string); ok && value != "" {
+			return types.StringValue(value)
+		}
+	}
+	return types.StringNull()
+}
+
+// FirstString returns the first element of the first non-empty list attribute among names.
+func (a rawAttributes) FirstString(names ...string) types.String {
+	for _, name := range names {
+		if values, ok := a[name].([]interface{}); ok && len(values) > 0 {
+			if value, ok := values[0].(string); ok {
+				return types.StringValue(value)
+			}
+		}
+	}
+	return types.StringNull()
+}
+
+// Bool returns the first boolean attribute among names, or def when none is set.
+func (a rawAttributes) Bool(def bool, names ...string) types.Bool {
+	for _, name := range names {
+		if value, ok := a[name].(bool); ok {
+			return types.BoolValue(value)
+		}
+	}
+	return types.BoolValue(def)
+}
+
+// Int64 returns the first numeric attribute among names.
+func (a rawAttributes) Int64(names ...string) types.Int64 {
+	for _, name := range names {
+		if value, ok := a[name].(float64); ok {
+			return types.Int64Value(int64(value))
+		}
+	}
+	return types.Int64Null()
+}
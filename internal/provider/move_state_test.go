@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// moveState runs the state movers of r on a raw source state and returns the response.
+func moveState(t *testing.T, r resource.ResourceWithMoveState, sourceType, sourceJSON string) *resource.MoveStateResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	req := resource.MoveStateRequest{
+		SourceProviderAddress: "registry.terraform.io/example/garage",
+		SourceTypeName:        sourceType,
+		SourceRawState:        &tfprotov6.RawState{JSON: []byte(sourceJSON)},
+	}
+
+	for _, mover := range r.MoveState(ctx) {
+		resp := &resource.MoveStateResponse{
+			TargetState: tfsdk.State{
+				Schema: schemaResp.Schema,
+				Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+			},
+		}
+		mover.StateMover(ctx, req, resp)
+		if resp.Diagnostics.HasError() || !resp.TargetState.Raw.IsNull() {
+			return resp
+		}
+	}
+
+	return nil
+}
+
+func TestBucketResource_moveState(t *testing.T) {
+	resp := moveState(t, &BucketResource{}, "garage_bucket", `{"id":"b1","name":"assets","website_access":true,"quota_max_size":1024}`)
+	if resp == nil || resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected move result: %+v", resp)
+	}
+
+	var data BucketResourceModel
+	resp.Diagnostics.Append(resp.TargetState.Get(context.Background(), &data)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if data.ID.ValueString() != "b1" || data.GlobalAlias.ValueString() != "assets" {
+		t.Errorf("unexpected identifiers: %s %s", data.ID, data.GlobalAlias)
+	}
+	if !data.WebsiteEnabled.ValueBool() {
+		t.Error("expected website_enabled to be carried over")
+	}
+	if data.MaxSize.ValueInt64() != 1024 || !data.MaxObjects.IsNull() {
+		t.Errorf("unexpected quotas: %s %s", data.MaxSize, data.MaxObjects)
+	}
+}
+
+func TestBucketResource_moveStateAliasList(t *testing.T) {
+	resp := moveState(t, &BucketResource{}, "garage_bucket", `{"id":"b1","global_aliases":["assets","www"]}`)
+	if resp == nil || resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected move result: %+v", resp)
+	}
+
+	var data BucketResourceModel
+	resp.Diagnostics.Append(resp.TargetState.Get(context.Background(), &data)...)
+	if data.GlobalAlias.ValueString() != "assets" {
+		t.Errorf("expected the first alias, got %s", data.GlobalAlias)
+	}
+}
+
+func TestKeyResource_moveState(t *testing.T) {
+	resp := moveState(t, &KeyResource{}, "garage_key", `{"id":"GK123","access_key_id":"GK123","name":"app","secret_access_key":"s3cr3t"}`)
+	if resp == nil || resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected move result: %+v", resp)
+	}
+
+	var data KeyResourceModel
+	resp.Diagnostics.Append(resp.TargetState.Get(context.Background(), &data)...)
+	if data.ID.ValueString() != "GK123" || data.Name.ValueString() != "app" || data.SecretAccessKey.ValueString() != "s3cr3t" {
+		t.Errorf("unexpected key state: %+v", data)
+	}
+}
+
+func TestBucketPermissionResource_moveState(t *testing.T) {
+	resp := moveState(t, &BucketPermissionResource{}, "garage_bucket_key", `{"bucket_id":"b1","key_id":"GK123","read":true,"write":false}`)
+	if resp == nil || resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected move result: %+v", resp)
+	}
+
+	var data BucketPermissionResourceModel
+	resp.Diagnostics.Append(resp.TargetState.Get(context.Background(), &data)...)
+	if data.ID.ValueString() != "b1/GK123" {
+		t.Errorf("unexpected ID: %s", data.ID)
+	}
+	if !data.Read.ValueBool() || data.Write.ValueBool() || data.Owner.ValueBool() {
+		t.Errorf("unexpected permissions: %s %s %s", data.Read, data.Write, data.Owner)
+	}
+}
+
+func TestBucketPermissionResource_moveStateMissingKey(t *testing.T) {
+	resp := moveState(t, &BucketPermissionResource{}, "garage_bucket_key", `{"bucket_id":"b1"}`)
+	if resp == nil || !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for a state without access key ID")
+	}
+}
+
+func TestKeyResource_moveStateOtherType(t *testing.T) {
+	if resp := moveState(t, &KeyResource{}, "garage_bucket", `{"id":"b1"}`); resp != nil {
+		t.Fatalf("expected the move to be unsupported, got: %+v", resp)
+	}
+}
@@ -0,0 +1,219 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &NodeDataSource{}
+
+func NewNodeDataSource() datasource.DataSource {
+	return &NodeDataSource{}
+}
+
+// NodeDataSource defines the data source implementation.
+type NodeDataSource struct {
+	client *client.Client
+}
+
+// NodeDataSourceModel describes the data source data model.
+type NodeDataSourceModel struct {
+	Node              types.String   `tfsdk:"node"`
+	NodeID            types.String   `tfsdk:"node_id"`
+	Hostname          types.String   `tfsdk:"hostname"`
+	GarageVersion     types.String   `tfsdk:"garage_version"`
+	GarageFeatures    []types.String `tfsdk:"garage_features"`
+	RustVersion       types.String   `tfsdk:"rust_version"`
+	DBEngine          types.String   `tfsdk:"db_engine"`
+	DataAvailable     types.Int64    `tfsdk:"data_available"`
+	DataTotal         types.Int64    `tfsdk:"data_total"`
+	MetadataAvailable types.Int64    `tfsdk:"metadata_available"`
+	MetadataTotal     types.Int64    `tfsdk:"metadata_total"`
+	Statistics        types.String   `tfsdk:"statistics"`
+}
+
+func (d *NodeDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_node"
+}
+
+func (d *NodeDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the versions, database engine and disk usage of a single node, for instance to validate node configuration in layout modules.",
+
+		Attributes: map[string]schema.Attribute{
+			"node": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The node to describe: a node ID, or `self` for the node the provider talks to. Defaults to `self`.",
+				Validators: []validator.String{
+					stringvalidator.NoneOf(client.AllNodes),
+				},
+			},
+			"node_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The full ID of the node.",
+			},
+			"hostname": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The hostname of the node, if known to the cluster.",
+			},
+			"garage_version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The version of Garage running on the node.",
+			},
+			"garage_features": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The features Garage was built with on the node, such as `k2v` or `lmdb`.",
+			},
+			"rust_version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The version of Rust Garage was built with.",
+			},
+			"db_engine": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The metadata database engine used by the node.",
+			},
+			"data_available": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The available space on the data partition, in bytes, or null if the node does not report it.",
+			},
+			"data_total": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The total size of the data partition, in bytes, or null if the node does not report it.",
+			},
+			"metadata_available": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The available space on the metadata partition, in bytes, or null if the node does not report it.",
+			},
+			"metadata_total": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The total size of the metadata partition, in bytes, or null if the node does not report it.",
+			},
+			"statistics": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The statistics report of the node, as printed by `garage stats`.",
+			},
+		},
+	}
+}
+
+func (d *NodeDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *NodeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NodeDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Node.IsNull() {
+		data.Node = types.StringValue(client.LocalNode)
+	}
+
+	tflog.Debug(ctx, "Reading node info", map[string]interface{}{
+		"node": data.Node.ValueString(),
+	})
+
+	info, err := d.client.GetNodeInfo(ctx, data.Node.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read node info", err)
+		return
+	}
+
+	for nodeID, message := range info.Error {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read info of node %s, got error: %s", nodeID, message))
+		return
+	}
+
+	if len(info.Success) != 1 {
+		resp.Diagnostics.AddError(
+			"Unexpected Node Info",
+			fmt.Sprintf("Expected information from exactly one node, got %d.", len(info.Success)),
+		)
+		return
+	}
+
+	for nodeID, nodeInfo := range info.Success {
+		data.NodeID = types.StringValue(nodeID)
+		data.GarageVersion = types.StringValue(nodeInfo.GarageVersion)
+		data.GarageFeatures = stringValues(nodeInfo.GarageFeatures)
+		data.RustVersion = types.StringValue(nodeInfo.RustVersion)
+		data.DBEngine = types.StringValue(nodeInfo.DBEngine)
+	}
+
+	// Query the statistics by ID, so that both calls describe the same node
+	stats, err := d.client.GetNodeStatistics(ctx, data.NodeID.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read node statistics", err)
+		return
+	}
+
+	for nodeID, message := range stats.Error {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read statistics of node %s, got error: %s", nodeID, message))
+		return
+	}
+
+	data.Statistics = types.StringValue(stats.Success[data.NodeID.ValueString()].Freeform)
+
+	// Disk usage is only reported through the cluster status
+	status, err := d.client.GetClusterStatus(ctx)
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read cluster status", err)
+		return
+	}
+
+	data.Hostname = types.StringNull()
+	data.DataAvailable = types.Int64Null()
+	data.DataTotal = types.Int64Null()
+	data.MetadataAvailable = types.Int64Null()
+	data.MetadataTotal = types.Int64Null()
+
+	if node := findNodeStatus(status, data.NodeID.ValueString()); node != nil {
+		data.Hostname = types.StringPointerValue(node.Hostname)
+		if node.DataPartition != nil {
+			data.DataAvailable = types.Int64Value(node.DataPartition.Available)
+			data.DataTotal = types.Int64Value(node.DataPartition.Total)
+		}
+		if node.MetadataPartition != nil {
+			data.MetadataAvailable = types.Int64Value(node.MetadataPartition.Available)
+			data.MetadataTotal = types.Int64Value(node.MetadataPartition.Total)
+		}
+	}
+
+	tflog.Trace(ctx, "Read node data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
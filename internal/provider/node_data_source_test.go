@@ -0,0 +1,54 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccNodeDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNodeDataSourceConfig_self(),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.garage_node.self", "node", "self"),
+					resource.TestMatchResourceAttr("data.garage_node.self", "node_id", regexp.MustCompile(`^[0-9a-f]{64}$`)),
+					resource.TestMatchResourceAttr("data.garage_node.self", "garage_version", regexp.MustCompile(`^v\d+\.`)),
+					resource.TestCheckResourceAttrSet("data.garage_node.self", "rust_version"),
+					resource.TestCheckResourceAttrSet("data.garage_node.self", "db_engine"),
+					resource.TestCheckResourceAttrSet("data.garage_node.self", "statistics"),
+					resource.TestCheckResourceAttrPair("data.garage_node.by_id", "garage_version", "data.garage_node.self", "garage_version"),
+				),
+			},
+			{
+				Config:      testAccNodeDataSourceConfig_allNodes(),
+				ExpectError: regexp.MustCompile("Invalid Attribute Value Match"),
+			},
+		},
+	})
+}
+
+func testAccNodeDataSourceConfig_self() string {
+	return `
+data "garage_node" "self" {}
+
+data "garage_node" "by_id" {
+  node = data.garage_node.self.node_id
+}
+`
+}
+
+func testAccNodeDataSourceConfig_allNodes() string {
+	return `
+data "garage_node" "all" {
+  node = "*"
+}
+`
+}
@@ -0,0 +1,321 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Worker variables managed by garage_node_maintenance.
+const (
+	scrubTranquilityVariable  = "scrub-tranquility"
+	resyncTranquilityVariable = "resync-tranquility"
+	resyncWorkerCountVariable = "resync-worker-count"
+)
+
+// maxResyncWorkers is the largest number of resync workers Garage accepts.
+const maxResyncWorkers = 8
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &NodeMaintenanceResource{}
+var _ resource.ResourceWithImportState = &NodeMaintenanceResource{}
+var _ resource.ResourceWithModifyPlan = &NodeMaintenanceResource{}
+
+func NewNodeMaintenanceResource() resource.Resource {
+	return &NodeMaintenanceResource{}
+}
+
+// NodeMaintenanceResource defines the resource implementation.
+type NodeMaintenanceResource struct {
+	client *client.Client
+}
+
+// NodeMaintenanceResourceModel describes the resource data model.
+type NodeMaintenanceResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Node              types.String `tfsdk:"node"`
+	ScrubTranquility  types.Int64  `tfsdk:"scrub_tranquility"`
+	ResyncTranquility types.Int64  `tfsdk:"resync_tranquility"`
+	ResyncWorkerCount types.Int64  `tfsdk:"resync_worker_count"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *NodeMaintenanceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_node_maintenance"
+}
+
+func (r *NodeMaintenanceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the maintenance settings of the background workers of a node, such as how aggressively blocks are scrubbed and resynchronized. " +
+			"Attributes that are not set keep their current value on the node. Destroying the resource leaves the settings in place.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the node the settings apply to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"node": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The node to configure: a node ID, or `self` for the node the provider talks to.",
+				Validators: []validator.String{
+					stringvalidator.NoneOf(client.AllNodes),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scrub_tranquility": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "How long the scrub worker sleeps relative to the time spent working. Higher values make scrubs slower and lighter on the disks.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"resync_tranquility": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "How long the resync workers sleep relative to the time spent working. `0` resynchronizes blocks as fast as possible.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"resync_worker_count": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: fmt.Sprintf("The number of workers resynchronizing blocks in parallel, between 1 and %d.", maxResyncWorkers),
+				Validators: []validator.Int64{
+					int64validator.Between(1, maxResyncWorkers),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			// Delete makes no API call, so it takes no timeout
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *NodeMaintenanceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *NodeMaintenanceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	validateTokenScope(ctx, r.client, resourceScopes{
+		Create: []string{"SetWorkerVariable", "GetWorkerVariable"},
+		Update: []string{"SetWorkerVariable", "GetWorkerVariable"},
+	}, req, resp)
+}
+
+func (r *NodeMaintenanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data NodeMaintenanceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	r.apply(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Created node maintenance resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NodeMaintenanceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data NodeMaintenanceResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	r.read(ctx, &data, &resp.Diagnostics)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NodeMaintenanceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data NodeMaintenanceResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	r.apply(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Updated node maintenance resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NodeMaintenanceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// The settings are left in place: Garage has no notion of resetting a
+	// worker variable to its default.
+	tflog.Trace(ctx, "Deleted node maintenance resource")
+}
+
+func (r *NodeMaintenanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("node"), req, resp)
+}
+
+// apply sets the configured variables on the node, then reads all of them
+// back so that unset attributes reflect the values in use.
+func (r *NodeMaintenanceResource) apply(ctx context.Context, data *NodeMaintenanceResourceModel, diags *diag.Diagnostics) {
+	variables := []struct {
+		name  string
+		value types.Int64
+	}{
+		{scrubTranquilityVariable, data.ScrubTranquility},
+		{resyncTranquilityVariable, data.ResyncTranquility},
+		{resyncWorkerCountVariable, data.ResyncWorkerCount},
+	}
+
+	for _, variable := range variables {
+		if variable.value.IsNull() || variable.value.IsUnknown() {
+			continue
+		}
+
+		tflog.Debug(ctx, "Setting worker variable", map[string]interface{}{
+			"node":     data.Node.ValueString(),
+			"variable": variable.name,
+			"value":    variable.value.ValueInt64(),
+		})
+
+		result, err := r.client.SetWorkerVariable(ctx, data.Node.ValueString(), client.SetWorkerVariableRequest{
+			Variable: variable.name,
+			Value:    strconv.FormatInt(variable.value.ValueInt64(), 10),
+		})
+		if err != nil {
+			addClientError(diags, "set worker variable "+variable.name, err)
+			return
+		}
+
+		for nodeID, message := range result.Error {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to set worker variable %s on node %s, got error: %s", variable.name, nodeID, message))
+			return
+		}
+	}
+
+	r.read(ctx, data, diags)
+}
+
+// read updates the model from the worker variables of the node.
+func (r *NodeMaintenanceResource) read(ctx context.Context, data *NodeMaintenanceResourceModel, diags *diag.Diagnostics) {
+	result, err := r.client.GetWorkerVariable(ctx, data.Node.ValueString(), client.GetWorkerVariableRequest{})
+	if err != nil {
+		addClientError(diags, "read worker variables", err)
+		return
+	}
+
+	for nodeID, message := range result.Error {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read worker variables on node %s, got error: %s", nodeID, message))
+		return
+	}
+
+	if len(result.Success) != 1 {
+		diags.AddError(
+			"Unexpected Worker Variables",
+			fmt.Sprintf("Expected worker variables from exactly one node, got %d.", len(result.Success)),
+		)
+		return
+	}
+
+	for nodeID, values := range result.Success {
+		data.ID = types.StringValue(nodeID)
+		data.ScrubTranquility = workerVariableInt64(values, scrubTranquilityVariable, diags)
+		data.ResyncTranquility = workerVariableInt64(values, resyncTranquilityVariable, diags)
+		data.ResyncWorkerCount = workerVariableInt64(values, resyncWorkerCountVariable, diags)
+	}
+}
+
+// workerVariableInt64 parses a numeric worker variable. Variables the node
+// does not report are null.
+func workerVariableInt64(values map[string]string, name string, diags *diag.Diagnostics) types.Int64 {
+	value, ok := values[name]
+	if !ok {
+		return types.Int64Null()
+	}
+
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		diags.AddError(
+			"Unexpected Worker Variable",
+			fmt.Sprintf("Expected worker variable %s to be a number, got %q.", name, value),
+		)
+		return types.Int64Null()
+	}
+
+	return types.Int64Value(number)
+}
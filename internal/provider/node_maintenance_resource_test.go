@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccNodeMaintenanceResource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Set the resync settings and read the scrub tranquility in use
+			{
+				Config: testAccNodeMaintenanceResourceConfig(4, 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_node_maintenance.test", "node", "self"),
+					resource.TestCheckResourceAttrSet("garage_node_maintenance.test", "id"),
+					resource.TestCheckResourceAttr("garage_node_maintenance.test", "resync_tranquility", "4"),
+					resource.TestCheckResourceAttr("garage_node_maintenance.test", "resync_worker_count", "2"),
+					resource.TestCheckResourceAttrSet("garage_node_maintenance.test", "scrub_tranquility"),
+				),
+			},
+			// Change the settings in place
+			{
+				Config: testAccNodeMaintenanceResourceConfig(0, 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_node_maintenance.test", "resync_tranquility", "0"),
+					resource.TestCheckResourceAttr("garage_node_maintenance.test", "resync_worker_count", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestWorkerVariableInt64(t *testing.T) {
+	var diags diag.Diagnostics
+	values := map[string]string{"resync-tranquility": "2", "scrub-tranquility": "slow"}
+
+	if got := workerVariableInt64(values, "resync-tranquility", &diags); got.ValueInt64() != 2 {
+		t.Errorf("Expected 2, got %s", got)
+	}
+	if got := workerVariableInt64(values, "resync-worker-count", &diags); !got.IsNull() {
+		t.Errorf("Expected a missing variable to be null, got %s", got)
+	}
+	if diags.HasError() {
+		t.Fatalf("Unexpected diagnostics: %v", diags)
+	}
+
+	workerVariableInt64(values, "scrub-tranquility", &diags)
+	if !diags.HasError() {
+		t.Error("Expected an error for a non-numeric value")
+	}
+}
+
+func testAccNodeMaintenanceResourceConfig(resyncTranquility, resyncWorkers int) string {
+	return fmt.Sprintf(`
+resource "garage_node_maintenance" "test" {
+  node                = "self"
+  resync_tranquility  = %[1]d
+  resync_worker_count = %[2]d
+}
+`, resyncTranquility, resyncWorkers)
+}
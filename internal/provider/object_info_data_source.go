@@ -0,0 +1,281 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ObjectInfoDataSource{}
+
+func NewObjectInfoDataSource() datasource.DataSource {
+	return &ObjectInfoDataSource{}
+}
+
+// ObjectInfoDataSource defines the data source implementation.
+type ObjectInfoDataSource struct {
+	client *client.Client
+}
+
+// ObjectInfoDataSourceModel describes the data source data model.
+type ObjectInfoDataSourceModel struct {
+	BucketID  types.String         `tfsdk:"bucket_id"`
+	Key       types.String         `tfsdk:"key"`
+	Exists    types.Bool           `tfsdk:"exists"`
+	Size      types.Int64          `tfsdk:"size"`
+	ETag      types.String         `tfsdk:"etag"`
+	Encrypted types.Bool           `tfsdk:"encrypted"`
+	Versions  []ObjectVersionModel `tfsdk:"versions"`
+}
+
+// ObjectVersionModel describes a single version of the object.
+type ObjectVersionModel struct {
+	UUID         types.String              `tfsdk:"uuid"`
+	Timestamp    types.String              `tfsdk:"timestamp"`
+	Aborted      types.Bool                `tfsdk:"aborted"`
+	DeleteMarker types.Bool                `tfsdk:"delete_marker"`
+	Inline       types.Bool                `tfsdk:"inline"`
+	Uploading    types.Bool                `tfsdk:"uploading"`
+	Encrypted    types.Bool                `tfsdk:"encrypted"`
+	Size         types.Int64               `tfsdk:"size"`
+	ETag         types.String              `tfsdk:"etag"`
+	Headers      map[string]types.String   `tfsdk:"headers"`
+	Blocks       []ObjectVersionBlockModel `tfsdk:"blocks"`
+}
+
+// ObjectVersionBlockModel describes a single data block of a version.
+type ObjectVersionBlockModel struct {
+	PartNumber types.Int64  `tfsdk:"part_number"`
+	Offset     types.Int64  `tfsdk:"offset"`
+	Hash       types.String `tfsdk:"hash"`
+	Size       types.Int64  `tfsdk:"size"`
+}
+
+func (d *ObjectInfoDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_object_info"
+}
+
+func (d *ObjectInfoDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Inspects an object through the Admin API, like `garage bucket inspect-object`, returning its versions, data blocks, encryption and size. " +
+			"Unlike S3 tooling, it needs no access key, so it can verify that seeded objects exist.",
+
+		Attributes: map[string]schema.Attribute{
+			"bucket_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the bucket.",
+			},
+			"key": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The key of the object.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"exists": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the object exists, that is whether its current version is complete and is not a delete marker.",
+			},
+			"size": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The size of the current version, in bytes, or null if the object does not exist.",
+			},
+			"etag": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ETag of the current version, or null if the object does not exist.",
+			},
+			"encrypted": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the current version is encrypted with an SSE-C key, or null if the object does not exist.",
+			},
+			"versions": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Every version Garage stores for the key, from the oldest to the newest, including aborted and ongoing uploads. Empty if the bucket holds no version of the key.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"uuid": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The identifier of the version.",
+						},
+						"timestamp": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "When the version was created (RFC 3339).",
+						},
+						"aborted": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the upload of the version was aborted.",
+						},
+						"delete_marker": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the version marks the deletion of the object.",
+						},
+						"inline": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the data of the version is small enough to be stored in the metadata, without data blocks.",
+						},
+						"uploading": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the version is still being uploaded.",
+						},
+						"encrypted": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether the version is encrypted with an SSE-C key.",
+						},
+						"size": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The size of the version, in bytes, or null while it is uploaded.",
+						},
+						"etag": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ETag of the version, or null while it is uploaded.",
+						},
+						"headers": schema.MapAttribute{
+							Computed:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "The HTTP headers stored with the version, such as `content-type`.",
+						},
+						"blocks": schema.ListNestedAttribute{
+							Computed:            true,
+							MarkdownDescription: "The data blocks of the version, empty for inline versions.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"part_number": schema.Int64Attribute{
+										Computed:            true,
+										MarkdownDescription: "The part of the multipart upload the block belongs to, 1 for single uploads.",
+									},
+									"offset": schema.Int64Attribute{
+										Computed:            true,
+										MarkdownDescription: "The offset of the block in its part, in bytes.",
+									},
+									"hash": schema.StringAttribute{
+										Computed:            true,
+										MarkdownDescription: "The hash of the block.",
+									},
+									"size": schema.Int64Attribute{
+										Computed:            true,
+										MarkdownDescription: "The size of the block, in bytes.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ObjectInfoDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ObjectInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ObjectInfoDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Inspecting object", map[string]interface{}{
+		"bucket_id": data.BucketID.ValueString(),
+		"key":       data.Key.ValueString(),
+	})
+
+	result, err := d.client.InspectObject(ctx, data.BucketID.ValueString(), data.Key.ValueString())
+	switch {
+	case errors.Is(err, client.ErrNotFound):
+		// A missing object is reported through exists, so that it can be
+		// asserted on rather than failing the run
+		result = &client.InspectObjectResponse{}
+	case err != nil:
+		addClientError(&resp.Diagnostics, "inspect object", err)
+		return
+	}
+
+	setObjectInfo(&data, result)
+
+	tflog.Trace(ctx, "Read object info data source", map[string]interface{}{
+		"exists":   data.Exists.ValueBool(),
+		"versions": len(data.Versions),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// setObjectInfo fills the model with the inspected versions, and describes the
+// current one in the top-level attributes.
+func setObjectInfo(data *ObjectInfoDataSourceModel, result *client.InspectObjectResponse) {
+	data.Versions = make([]ObjectVersionModel, 0, len(result.Versions))
+	for _, version := range result.Versions {
+		headers := make(map[string]types.String, len(version.Headers))
+		for _, header := range version.Headers {
+			headers[header[0]] = types.StringValue(header[1])
+		}
+
+		blocks := make([]ObjectVersionBlockModel, 0, len(version.Blocks))
+		for _, block := range version.Blocks {
+			blocks = append(blocks, ObjectVersionBlockModel{
+				PartNumber: types.Int64Value(block.PartNumber),
+				Offset:     types.Int64Value(block.Offset),
+				Hash:       types.StringValue(block.Hash),
+				Size:       types.Int64Value(block.Size),
+			})
+		}
+
+		data.Versions = append(data.Versions, ObjectVersionModel{
+			UUID:         types.StringValue(version.UUID),
+			Timestamp:    types.StringValue(version.Timestamp),
+			Aborted:      types.BoolValue(version.Aborted),
+			DeleteMarker: types.BoolValue(version.DeleteMarker),
+			Inline:       types.BoolValue(version.Inline),
+			Uploading:    types.BoolValue(version.Uploading),
+			Encrypted:    types.BoolValue(version.Encrypted),
+			Size:         types.Int64PointerValue(version.Size),
+			ETag:         types.StringPointerValue(version.ETag),
+			Headers:      headers,
+			Blocks:       blocks,
+		})
+	}
+
+	data.Exists = types.BoolValue(false)
+	data.Size = types.Int64Null()
+	data.ETag = types.StringNull()
+	data.Encrypted = types.BoolNull()
+
+	if current := result.CurrentVersion(); current != nil && !current.DeleteMarker {
+		data.Exists = types.BoolValue(true)
+		data.Size = types.Int64PointerValue(current.Size)
+		data.ETag = types.StringPointerValue(current.ETag)
+		data.Encrypted = types.BoolValue(current.Encrypted)
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-garage/internal/client"
+)
+
+func TestAccObjectInfoDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObjectInfoDataSourceConfig_basic,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.garage_object_info.test", "exists", "true"),
+					resource.TestCheckResourceAttr("data.garage_object_info.test", "size", "20"),
+					resource.TestCheckResourceAttrPair("data.garage_object_info.test", "etag", "garage_object.test", "etag"),
+					resource.TestCheckResourceAttr("data.garage_object_info.test", "encrypted", "false"),
+					resource.TestCheckResourceAttr("data.garage_object_info.test", "versions.#", "1"),
+					resource.TestCheckResourceAttr("data.garage_object_info.test", "versions.0.inline", "true"),
+					resource.TestCheckResourceAttr("data.garage_object_info.missing", "exists", "false"),
+					resource.TestCheckResourceAttr("data.garage_object_info.missing", "versions.#", "0"),
+					resource.TestCheckNoResourceAttr("data.garage_object_info.missing", "size"),
+				),
+			},
+		},
+	})
+}
+
+const testAccObjectInfoDataSourceConfig_basic = `
+resource "garage_bucket" "test" {
+  global_alias  = "test-object-info-bucket"
+  force_destroy = true
+}
+
+resource "garage_key" "test" {
+  name = "test-object-info-bucket-key"
+}
+
+resource "garage_bucket_permission" "test" {
+  bucket_id     = garage_bucket.test.id
+  access_key_id = garage_key.test.id
+  read          = true
+  write         = true
+}
+
+resource "garage_object" "test" {
+  bucket            = garage_bucket.test.global_alias
+  key               = "config/app.json"
+  access_key_id     = garage_bucket_permission.test.access_key_id
+  secret_access_key = garage_key.test.secret_access_key
+  content           = jsonencode({ greeting = "hello" })
+}
+
+data "garage_object_info" "test" {
+  bucket_id = garage_bucket.test.id
+  key       = garage_object.test.key
+}
+
+data "garage_object_info" "missing" {
+  bucket_id  = garage_bucket.test.id
+  key        = "config/missing.json"
+  depends_on = [garage_object.test]
+}
+`
+
+func TestSetObjectInfo(t *testing.T) {
+	size, etag := int64(5), "5d41402abc4b2a76b9719d911017c592"
+
+	tests := []struct {
+		name     string
+		versions []client.ObjectVersion
+		exists   bool
+	}{
+		{
+			name: "no version",
+		},
+		{
+			name: "complete version",
+			versions: []client.ObjectVersion{
+				{UUID: "v1", Size: &size, ETag: &etag, Headers: [][2]string{{"content-type", "text/plain"}}},
+			},
+			exists: true,
+		},
+		{
+			name: "ongoing upload over a complete version",
+			versions: []client.ObjectVersion{
+				{UUID: "v1", Size: &size, ETag: &etag},
+				{UUID: "v2", Uploading: true},
+			},
+			exists: true,
+		},
+		{
+			name: "delete marker",
+			versions: []client.ObjectVersion{
+				{UUID: "v1", Size: &size, ETag: &etag},
+				{UUID: "v2", DeleteMarker: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data ObjectInfoDataSourceModel
+			setObjectInfo(&data, &client.InspectObjectResponse{Versions: tt.versions})
+
+			if len(data.Versions) != len(tt.versions) {
+				t.Fatalf("Expected %d versions, got %d", len(tt.versions), len(data.Versions))
+			}
+
+			if data.Exists.ValueBool() != tt.exists {
+				t.Errorf("Expected exists %t, got %t", tt.exists, data.Exists.ValueBool())
+			}
+
+			if tt.exists && (data.Size.ValueInt64() != size || data.ETag.ValueString() != etag) {
+				t.Errorf("Expected size %d and etag %s, got %s and %s", size, etag, data.Size, data.ETag)
+			}
+
+			if !tt.exists && (!data.Size.IsNull() || !data.ETag.IsNull() || !data.Encrypted.IsNull()) {
+				t.Errorf("Expected null size, etag and encrypted, got %s, %s and %s", data.Size, data.ETag, data.Encrypted)
+			}
+		})
+	}
+}
@@ -0,0 +1,442 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"os"
+	pathpkg "path"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ObjectResource{}
+var _ resource.ResourceWithModifyPlan = &ObjectResource{}
+
+func NewObjectResource() resource.Resource {
+	return &ObjectResource{}
+}
+
+// ObjectResource defines the resource implementation.
+type ObjectResource struct {
+	client *client.Client
+}
+
+// ObjectResourceModel describes the resource data model.
+type ObjectResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Bucket          types.String `tfsdk:"bucket"`
+	Key             types.String `tfsdk:"key"`
+	AccessKeyID     types.String `tfsdk:"access_key_id"`
+	SecretAccessKey types.String `tfsdk:"secret_access_key"`
+	Content         types.String `tfsdk:"content"`
+	ContentBase64   types.String `tfsdk:"content_base64"`
+	Source          types.String `tfsdk:"source"`
+	SourceHash      types.String `tfsdk:"source_hash"`
+	ContentType     types.String `tfsdk:"content_type"`
+	CacheControl    types.String `tfsdk:"cache_control"`
+	ContentEncoding types.String `tfsdk:"content_encoding"`
+	Metadata        types.Map    `tfsdk:"metadata"`
+	ETag            types.String `tfsdk:"etag"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *ObjectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_object"
+}
+
+func (r *ObjectResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Uploads a small object to a bucket through the S3 API, for instance a configuration file or a website asset. " +
+			"The object is uploaded again when its content, headers or metadata change, or when they were changed outside Terraform. Requires the provider `s3_endpoint` to be set.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the object (format: bucket/key).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"bucket": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the bucket in the S3 API: a global alias of the bucket, or a local alias of the access key.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The key of the object.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"access_key_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The ID of an access key with read and write permissions on the bucket. Defaults to the provider `s3_access_key_id`.",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("secret_access_key")),
+				},
+			},
+			"secret_access_key": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The secret of the access key.",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("access_key_id")),
+				},
+			},
+			"content": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The content of the object, as a UTF-8 string. Exactly one of `content`, `content_base64` and `source` must be set.",
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("content_base64"), path.MatchRoot("source")),
+				},
+			},
+			"content_base64": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The content of the object, base64-encoded, for binary content.",
+			},
+			"source": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The path of a local file to upload as the object.",
+			},
+			"source_hash": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "A hash of `source` computed in the configuration (e.g., `filemd5(\"logo.png\")`). Changing it uploads the object again. " +
+					"Not needed to detect changes to the file, which `etag` already plans from its content, but useful to force an upload.",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("source")),
+				},
+			},
+			"content_type": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The MIME type of the object. Defaults to the type matching the extension of the key, or `application/octet-stream`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cache_control": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The `Cache-Control` header of the object (e.g., `max-age=3600`).",
+			},
+			"content_encoding": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The `Content-Encoding` header of the object (e.g., `gzip` for pre-compressed content).",
+			},
+			"metadata": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "User-defined metadata of the object, sent as `x-amz-meta-*` headers. Names must be lowercase.",
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(
+						regexp.MustCompile(`^[a-z0-9_.-]+$`),
+						"must only contain lowercase letters, digits, dots, dashes and underscores",
+					)),
+				},
+			},
+			"etag": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The MD5 hash of the content, as reported by Garage in the object's ETag.",
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *ObjectResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ObjectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var data ObjectResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The content is only known at apply time when it comes from another resource
+	if data.Content.IsUnknown() || data.ContentBase64.IsUnknown() || data.Source.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("etag"), types.StringUnknown())...)
+		return
+	}
+
+	// Plan the ETag of the content, so that both changes to the content and
+	// changes to the object made outside Terraform plan an upload
+	body, err := objectContent(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Object Content", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("etag"), contentETag(body))...)
+}
+
+func (r *ObjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ObjectResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	r.upload(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = types.StringValue(data.Bucket.ValueString() + "/" + data.Key.ValueString())
+
+	tflog.Trace(ctx, "Created object resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ObjectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ObjectResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	s3, err := r.client.NewS3Client(data.AccessKeyID.ValueString(), data.SecretAccessKey.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read object", err)
+		return
+	}
+
+	object, err := s3.HeadObject(ctx, data.Bucket.ValueString(), data.Key.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read object", err)
+		return
+	}
+
+	if object == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Headers changed outside Terraform are set again by the next apply
+	data.ContentType = types.StringValue(object.ContentType)
+	data.CacheControl = optionalHeaderValue(object.CacheControl)
+	data.ContentEncoding = optionalHeaderValue(object.ContentEncoding)
+	data.ETag = types.StringValue(object.ETag)
+
+	if len(object.Metadata) > 0 || !data.Metadata.IsNull() {
+		metadata, diags := types.MapValueFrom(ctx, types.StringType, object.Metadata)
+		resp.Diagnostics.Append(diags...)
+		data.Metadata = metadata
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ObjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ObjectResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	r.upload(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Updated object resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ObjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ObjectResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, defaultDeleteTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	tflog.Debug(ctx, "Deleting object", map[string]interface{}{
+		"bucket": data.Bucket.ValueString(),
+		"key":    data.Key.ValueString(),
+	})
+
+	s3, err := r.client.NewS3Client(data.AccessKeyID.ValueString(), data.SecretAccessKey.ValueString())
+	if err != nil {
+		addClientError(&resp.Diagnostics, "delete object", err)
+		return
+	}
+
+	if err := s3.DeleteObjects(ctx, data.Bucket.ValueString(), []string{data.Key.ValueString()}); err != nil {
+		addClientError(&resp.Diagnostics, "delete object", err)
+		return
+	}
+
+	tflog.Trace(ctx, "Deleted object resource")
+}
+
+// upload uploads the content of the object along with its headers and
+// metadata, and records its content type and ETag.
+func (r *ObjectResource) upload(ctx context.Context, data *ObjectResourceModel, diags *diag.Diagnostics) {
+	body, err := objectContent(*data)
+	if err != nil {
+		diags.AddError("Invalid Object Content", err.Error())
+		return
+	}
+
+	contentType := data.ContentType.ValueString()
+	if data.ContentType.IsNull() || data.ContentType.IsUnknown() {
+		contentType = defaultContentType(data.Key.ValueString())
+	}
+
+	tflog.Debug(ctx, "Uploading object", map[string]interface{}{
+		"bucket": data.Bucket.ValueString(),
+		"key":    data.Key.ValueString(),
+		"size":   len(body),
+	})
+
+	s3, err := r.client.NewS3Client(data.AccessKeyID.ValueString(), data.SecretAccessKey.ValueString())
+	if err != nil {
+		addClientError(diags, "upload object", err)
+		return
+	}
+
+	metadata := map[string]string{}
+	if !data.Metadata.IsNull() {
+		diags.Append(data.Metadata.ElementsAs(ctx, &metadata, false)...)
+		if diags.HasError() {
+			return
+		}
+	}
+
+	_, err = s3.PutObject(ctx, client.PutObjectRequest{
+		Bucket:          data.Bucket.ValueString(),
+		Key:             data.Key.ValueString(),
+		Body:            body,
+		ContentType:     contentType,
+		CacheControl:    data.CacheControl.ValueString(),
+		ContentEncoding: data.ContentEncoding.ValueString(),
+		Metadata:        metadata,
+	})
+	if err != nil {
+		addClientError(diags, "upload object", err)
+		return
+	}
+
+	data.ContentType = types.StringValue(contentType)
+	data.ETag = types.StringValue(contentETag(body))
+}
+
+// objectContent returns the content of the object from whichever of content,
+// content_base64 and source is set.
+func objectContent(data ObjectResourceModel) ([]byte, error) {
+	switch {
+	case !data.Content.IsNull():
+		return []byte(data.Content.ValueString()), nil
+	case !data.ContentBase64.IsNull():
+		body, err := base64.StdEncoding.DecodeString(data.ContentBase64.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("content_base64 is not valid base64: %w", err)
+		}
+		return body, nil
+	case !data.Source.IsNull():
+		body, err := os.ReadFile(data.Source.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("unable to read source: %w", err)
+		}
+		return body, nil
+	default:
+		return nil, fmt.Errorf("one of content, content_base64 and source must be set")
+	}
+}
+
+// contentETag returns the ETag Garage gives an object uploaded in a single
+// request: the hex-encoded MD5 hash of its content.
+func contentETag(body []byte) string {
+	sum := md5.Sum(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// optionalHeaderValue returns the value of an optional header attribute, null
+// when the object does not have the header.
+func optionalHeaderValue(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// defaultContentType returns the MIME type matching the extension of key.
+func defaultContentType(key string) string {
+	if contentType := mime.TypeByExtension(pathpkg.Ext(key)); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
@@ -0,0 +1,275 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccObjectResource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckS3(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObjectResourceConfig_content("test-object-bucket", "hello"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_object.test", "id", "test-object-bucket/config/app.json"),
+					resource.TestCheckResourceAttr("garage_object.test", "content_type", "application/json"),
+					resource.TestCheckResourceAttr("garage_object.test", "etag", contentETag([]byte(`{"greeting":"hello"}`))),
+					resource.TestCheckResourceAttr("data.garage_bucket.test", "objects", "1"),
+				),
+			},
+			// Changing the content uploads the object again
+			{
+				Config: testAccObjectResourceConfig_content("test-object-bucket", "bonjour"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_object.test", "etag", contentETag([]byte(`{"greeting":"bonjour"}`))),
+				),
+			},
+		},
+	})
+}
+
+func TestAccObjectResource_headers(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckS3(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObjectResourceConfig_headers("test-object-headers", "max-age=60", "v1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_object.test", "cache_control", "max-age=60"),
+					resource.TestCheckResourceAttr("garage_object.test", "content_encoding", "identity"),
+					resource.TestCheckResourceAttr("garage_object.test", "metadata.release", "v1"),
+				),
+			},
+			// Changing a header uploads the object again
+			{
+				Config: testAccObjectResourceConfig_headers("test-object-headers", "no-cache", "v2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_object.test", "cache_control", "no-cache"),
+					resource.TestCheckResourceAttr("garage_object.test", "metadata.release", "v2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccObjectResource_sourceHash(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "logo.svg")
+	if err := os.WriteFile(source, []byte("<svg/>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckS3(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObjectResourceConfig_source("test-object-source", source, "v1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_object.test", "source_hash", "v1"),
+					resource.TestCheckResourceAttr("garage_object.test", "etag", contentETag([]byte("<svg/>"))),
+				),
+			},
+			// Changing the hash uploads the object again
+			{
+				Config: testAccObjectResourceConfig_source("test-object-source", source, "v2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_object.test", "source_hash", "v2"),
+				),
+			},
+		},
+	})
+}
+
+func TestObjectContent(t *testing.T) {
+	source := filepath.Join(t.TempDir(), "index.html")
+	if err := os.WriteFile(source, []byte("<html></html>"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		data    ObjectResourceModel
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "content",
+			data: ObjectResourceModel{Content: types.StringValue("hello"), ContentBase64: types.StringNull(), Source: types.StringNull()},
+			want: "hello",
+		},
+		{
+			name: "content_base64",
+			data: ObjectResourceModel{Content: types.StringNull(), ContentBase64: types.StringValue("aGVsbG8="), Source: types.StringNull()},
+			want: "hello",
+		},
+		{
+			name:    "invalid content_base64",
+			data:    ObjectResourceModel{Content: types.StringNull(), ContentBase64: types.StringValue("not base64!"), Source: types.StringNull()},
+			wantErr: true,
+		},
+		{
+			name: "source",
+			data: ObjectResourceModel{Content: types.StringNull(), ContentBase64: types.StringNull(), Source: types.StringValue(source)},
+			want: "<html></html>",
+		},
+		{
+			name:    "missing source",
+			data:    ObjectResourceModel{Content: types.StringNull(), ContentBase64: types.StringNull(), Source: types.StringValue(source + ".missing")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := objectContent(tt.data)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got content %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("expected content %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestOptionalHeaderValue(t *testing.T) {
+	if got := optionalHeaderValue(""); !got.IsNull() {
+		t.Errorf("expected a null value for a missing header, got %s", got)
+	}
+	if got := optionalHeaderValue("gzip"); got.ValueString() != "gzip" {
+		t.Errorf("expected gzip, got %s", got)
+	}
+}
+
+func TestContentETag(t *testing.T) {
+	if got := contentETag([]byte("hello")); got != "5d41402abc4b2a76b9719d911017c592" {
+		t.Errorf("unexpected ETag %s", got)
+	}
+}
+
+func TestDefaultContentType(t *testing.T) {
+	tests := map[string]string{
+		"config/app.json": "application/json",
+		"assets/logo.png": "image/png",
+		"README":          "application/octet-stream",
+	}
+
+	for key, want := range tests {
+		if got := defaultContentType(key); got != want {
+			t.Errorf("defaultContentType(%q): expected %s, got %s", key, want, got)
+		}
+	}
+}
+
+func testAccObjectResourceConfig_content(bucketName, greeting string) string {
+	return fmt.Sprintf(`
+resource "garage_bucket" "test" {
+  global_alias  = %[1]q
+  force_destroy = true
+}
+
+resource "garage_key" "test" {
+  name = "%[1]s-key"
+}
+
+resource "garage_bucket_permission" "test" {
+  bucket_id     = garage_bucket.test.id
+  access_key_id = garage_key.test.id
+  read          = true
+  write         = true
+}
+
+resource "garage_object" "test" {
+  bucket            = garage_bucket.test.global_alias
+  key               = "config/app.json"
+  access_key_id     = garage_bucket_permission.test.access_key_id
+  secret_access_key = garage_key.test.secret_access_key
+  content           = jsonencode({ greeting = %[2]q })
+}
+
+data "garage_bucket" "test" {
+  id         = garage_bucket.test.id
+  depends_on = [garage_object.test]
+}
+`, bucketName, greeting)
+}
+
+func testAccObjectResourceConfig_headers(bucketName, cacheControl, release string) string {
+	return fmt.Sprintf(`
+resource "garage_bucket" "test" {
+  global_alias  = %[1]q
+  force_destroy = true
+}
+
+resource "garage_key" "test" {
+  name = "%[1]s-key"
+}
+
+resource "garage_bucket_permission" "test" {
+  bucket_id     = garage_bucket.test.id
+  access_key_id = garage_key.test.id
+  read          = true
+  write         = true
+}
+
+resource "garage_object" "test" {
+  bucket            = garage_bucket.test.global_alias
+  key               = "site/style.css"
+  access_key_id     = garage_bucket_permission.test.access_key_id
+  secret_access_key = garage_key.test.secret_access_key
+  content           = "body { margin: 0; }"
+  cache_control     = %[2]q
+  content_encoding  = "identity"
+
+  metadata = {
+    release = %[3]q
+  }
+}
+`, bucketName, cacheControl, release)
+}
+
+func testAccObjectResourceConfig_source(bucketName, source, sourceHash string) string {
+	return fmt.Sprintf(`
+resource "garage_bucket" "test" {
+  global_alias  = %[1]q
+  force_destroy = true
+}
+
+resource "garage_key" "test" {
+  name = "%[1]s-key"
+}
+
+resource "garage_bucket_permission" "test" {
+  bucket_id     = garage_bucket.test.id
+  access_key_id = garage_key.test.id
+  read          = true
+  write         = true
+}
+
+resource "garage_object" "test" {
+  bucket            = garage_bucket.test.global_alias
+  key               = "assets/logo.svg"
+  access_key_id     = garage_bucket_permission.test.access_key_id
+  secret_access_key = garage_key.test.secret_access_key
+  source            = %[2]q
+  source_hash       = %[3]q
+}
+`, bucketName, source, sourceHash)
+}
@@ -0,0 +1,217 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// permissionBatchWindow is how long permission changes to the same bucket are
+// collected before being sent to Garage.
+var permissionBatchWindow = 50 * time.Millisecond
+
+// permissionChanges coalesces permission changes made during an apply.
+var permissionChanges = &permissionBatcher{}
+
+// permissionChange is a set of permissions to grant and to revoke.
+type permissionChange struct {
+	Allow client.Permissions
+	Deny  client.Permissions
+}
+
+// merge applies a later change on top of c. A permission granted by the later
+// change is no longer revoked, and the other way round.
+func (c permissionChange) merge(later permissionChange) permissionChange {
+	merged := c
+
+	merged.Allow.Read = (c.Allow.Read && !later.Deny.Read) || later.Allow.Read
+	merged.Allow.Write = (c.Allow.Write && !later.Deny.Write) || later.Allow.Write
+	merged.Allow.Owner = (c.Allow.Owner && !later.Deny.Owner) || later.Allow.Owner
+
+	merged.Deny.Read = (c.Deny.Read && !later.Allow.Read) || later.Deny.Read
+	merged.Deny.Write = (c.Deny.Write && !later.Allow.Write) || later.Deny.Write
+	merged.Deny.Owner = (c.Deny.Owner && !later.Allow.Owner) || later.Deny.Owner
+
+	return merged
+}
+
+// permissionsDiff returns the change turning the permissions have into want.
+func permissionsDiff(have, want client.Permissions) permissionChange {
+	return permissionChange{
+		Allow: client.Permissions{
+			Read:  want.Read && !have.Read,
+			Write: want.Write && !have.Write,
+			Owner: want.Owner && !have.Owner,
+		},
+		Deny: client.Permissions{
+			Read:  have.Read && !want.Read,
+			Write: have.Write && !want.Write,
+			Owner: have.Owner && !want.Owner,
+		},
+	}
+}
+
+// permissionBucket identifies the permissions of the access keys on one
+// bucket.
+type permissionBucket struct {
+	client   *client.Client
+	bucketID string
+}
+
+// permissionBatch is a pending set of changes to the permissions of access
+// keys on one bucket, and their results once sent. The changes of each access
+// key are kept in the order they were queued, so that a caller giving up
+// before they are sent can take its change back.
+type permissionBatch struct {
+	changes map[string][]*permissionChange
+	done    chan struct{}
+	bucket  *client.Bucket
+	errs    map[string]error
+}
+
+// permissionBatcher merges the permission changes requested for the same
+// bucket within a short window. Each access key then receives at most one
+// AllowBucketKey call followed by at most one DenyBucketKey call, and the keys
+// are sent one after the other in the order of their IDs.
+type permissionBatcher struct {
+	mu      sync.Mutex
+	pending map[permissionBucket]*permissionBatch
+}
+
+// apply queues a permission change and waits until it has been sent, along
+// with the other changes to the same bucket. It returns the bucket as left by
+// the last call made, or nil if the merged changes had nothing to do. If ctx
+// ends before the changes are sent, the change is withdrawn from the batch.
+func (b *permissionBatcher) apply(ctx context.Context, c *client.Client, bucketID, accessKeyID string, change permissionChange) (*client.Bucket, error) {
+	target := permissionBucket{client: c, bucketID: bucketID}
+	queued := &change
+
+	b.mu.Lock()
+	batch, ok := b.pending[target]
+	if !ok {
+		batch = &permissionBatch{
+			changes: map[string][]*permissionChange{},
+			done:    make(chan struct{}),
+			errs:    map[string]error{},
+		}
+		if b.pending == nil {
+			b.pending = make(map[permissionBucket]*permissionBatch)
+		}
+		b.pending[target] = batch
+
+		// The batch is sent on behalf of every caller that joins it, so it
+		// must not end with the context of the first one
+		go b.send(context.WithoutCancel(ctx), target, batch)
+	}
+	batch.changes[accessKeyID] = append(batch.changes[accessKeyID], queued)
+	b.mu.Unlock()
+
+	select {
+	case <-batch.done:
+		return batch.bucket, batch.errs[accessKeyID]
+	case <-ctx.Done():
+		b.withdraw(target, batch, accessKeyID, queued)
+		return nil, ctx.Err()
+	}
+}
+
+// withdraw removes a queued change from a batch that has not been sent yet.
+func (b *permissionBatcher) withdraw(target permissionBucket, batch *permissionBatch, accessKeyID string, queued *permissionChange) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.pending[target] != batch {
+		return
+	}
+	batch.changes[accessKeyID] = slices.DeleteFunc(batch.changes[accessKeyID], func(c *permissionChange) bool {
+		return c == queued
+	})
+}
+
+// send waits for other resources changing the same bucket to join the batch,
+// then sends the merged changes of each access key. The calls of each access
+// key get their own deadline, so that a slow key does not fail the others.
+func (b *permissionBatcher) send(ctx context.Context, target permissionBucket, batch *permissionBatch) {
+	defer close(batch.done)
+
+	time.Sleep(permissionBatchWindow)
+
+	b.mu.Lock()
+	delete(b.pending, target)
+	changes := make(map[string]permissionChange, len(batch.changes))
+	for accessKeyID, queued := range batch.changes {
+		if len(queued) == 0 {
+			continue
+		}
+		merged := *queued[0]
+		for _, later := range queued[1:] {
+			merged = merged.merge(*later)
+		}
+		changes[accessKeyID] = merged
+	}
+	b.mu.Unlock()
+
+	// Granting retries while the access key replicates
+	timeout := keyConvergenceTimeout + 2*target.client.CallTimeout()
+
+	for _, accessKeyID := range slices.Sorted(maps.Keys(changes)) {
+		keyCtx, cancel := context.WithTimeout(ctx, timeout)
+		bucket, err := sendPermissionChange(keyCtx, target.client, target.bucketID, accessKeyID, changes[accessKeyID])
+		cancel()
+
+		if err != nil {
+			batch.errs[accessKeyID] = err
+			continue
+		}
+		if bucket != nil {
+			batch.bucket = bucket
+		}
+	}
+}
+
+// sendPermissionChange grants then revokes permissions of an access key on a
+// bucket, skipping the calls that have nothing to change.
+func sendPermissionChange(ctx context.Context, c *client.Client, bucketID, accessKeyID string, change permissionChange) (*client.Bucket, error) {
+	var bucket *client.Bucket
+	var err error
+
+	tflog.Debug(ctx, "Sending bucket permission changes", map[string]interface{}{
+		"bucket_id":     bucketID,
+		"access_key_id": accessKeyID,
+		"allow":         change.Allow,
+		"deny":          change.Deny,
+	})
+
+	if change.Allow.Read || change.Allow.Write || change.Allow.Owner {
+		bucket, err = allowBucketKeyWithRetry(ctx, c, client.BucketKeyPermRequest{
+			BucketID:    bucketID,
+			AccessKeyID: accessKeyID,
+			Permissions: change.Allow,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if change.Deny.Read || change.Deny.Write || change.Deny.Owner {
+		bucket, err = c.DenyBucketKey(ctx, client.BucketKeyPermRequest{
+			BucketID:    bucketID,
+			AccessKeyID: accessKeyID,
+			Permissions: change.Deny,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return bucket, nil
+}
@@ -0,0 +1,189 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"terraform-provider-garage/internal/client"
+)
+
+func TestPermissionChangeMerge(t *testing.T) {
+	first := permissionChange{
+		Allow: client.Permissions{Read: true, Write: true},
+		Deny:  client.Permissions{Owner: true},
+	}
+	later := permissionChange{
+		Allow: client.Permissions{Owner: true},
+		Deny:  client.Permissions{Write: true},
+	}
+
+	got := first.merge(later)
+	want := permissionChange{
+		Allow: client.Permissions{Read: true, Owner: true},
+		Deny:  client.Permissions{Write: true},
+	}
+
+	if got != want {
+		t.Errorf("merge() = %+v, want %+v", got, want)
+	}
+}
+
+func TestPermissionBatcher_coalesces(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	var requests []client.BucketKeyPermRequest
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req client.BucketKeyPermRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		mu.Lock()
+		calls = append(calls, r.URL.Path)
+		requests = append(requests, req)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(client.Bucket{ID: req.BucketID})
+	}))
+	defer server.Close()
+
+	c := client.NewClient(server.URL, "test-token")
+	batcher := &permissionBatcher{}
+
+	changes := []permissionChange{
+		{Allow: client.Permissions{Read: true}},
+		{Allow: client.Permissions{Write: true}},
+		{Deny: client.Permissions{Owner: true}},
+	}
+
+	var wg sync.WaitGroup
+	for _, change := range changes {
+		wg.Add(1)
+		go func(change permissionChange) {
+			defer wg.Done()
+			if _, err := batcher.apply(context.Background(), c, "bucket-1", "key-1", change); err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+		}(change)
+	}
+	wg.Wait()
+
+	if len(calls) != 2 || calls[0] != "/v2/AllowBucketKey" || calls[1] != "/v2/DenyBucketKey" {
+		t.Fatalf("Expected one AllowBucketKey then one DenyBucketKey call, got %v", calls)
+	}
+
+	if want := (client.Permissions{Read: true, Write: true}); requests[0].Permissions != want {
+		t.Errorf("Expected allowed permissions %+v, got %+v", want, requests[0].Permissions)
+	}
+
+	if want := (client.Permissions{Owner: true}); requests[1].Permissions != want {
+		t.Errorf("Expected denied permissions %+v, got %+v", want, requests[1].Permissions)
+	}
+}
+
+func TestPermissionBatcher_acrossKeys(t *testing.T) {
+	var mu sync.Mutex
+	var accessKeyIDs []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req client.BucketKeyPermRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		mu.Lock()
+		accessKeyIDs = append(accessKeyIDs, req.AccessKeyID)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(client.Bucket{ID: req.BucketID})
+	}))
+	defer server.Close()
+
+	c := client.NewClient(server.URL, "test-token")
+	batcher := &permissionBatcher{}
+
+	var wg sync.WaitGroup
+	for _, accessKeyID := range []string{"key-3", "key-1", "key-2"} {
+		wg.Add(1)
+		go func(accessKeyID string) {
+			defer wg.Done()
+			bucket, err := batcher.apply(context.Background(), c, "bucket-1", accessKeyID, permissionChange{
+				Allow: client.Permissions{Read: true},
+			})
+			if err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			if bucket == nil || bucket.ID != "bucket-1" {
+				t.Errorf("Expected bucket-1, got %+v", bucket)
+			}
+		}(accessKeyID)
+	}
+	wg.Wait()
+
+	if want := []string{"key-1", "key-2", "key-3"}; !slices.Equal(accessKeyIDs, want) {
+		t.Errorf("Expected calls for %v, got %v", want, accessKeyIDs)
+	}
+}
+
+func TestPermissionBatcher_withdraw(t *testing.T) {
+	var mu sync.Mutex
+	var accessKeyIDs []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req client.BucketKeyPermRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		mu.Lock()
+		accessKeyIDs = append(accessKeyIDs, req.AccessKeyID)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(client.Bucket{ID: req.BucketID})
+	}))
+	defer server.Close()
+
+	defer func(window time.Duration) { permissionBatchWindow = window }(permissionBatchWindow)
+	permissionBatchWindow = 200 * time.Millisecond
+
+	c := client.NewClient(server.URL, "test-token")
+	batcher := &permissionBatcher{}
+	change := permissionChange{Allow: client.Permissions{Read: true}}
+
+	// The first caller, whose context the batch was started with, gives up
+	// before the batch is sent
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := batcher.apply(ctx, c, "bucket-1", "key-1", change)
+		firstErr <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	joined := make(chan error, 1)
+	go func() {
+		_, err := batcher.apply(context.Background(), c, "bucket-1", "key-2", change)
+		joined <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	if err := <-firstErr; err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if err := <-joined; err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	if want := []string{"key-2"}; !slices.Equal(accessKeyIDs, want) {
+		t.Errorf("Expected calls for %v, got %v", want, accessKeyIDs)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// providerProfile holds the connection settings of one named profile in the
+// provider profiles file.
+type providerProfile struct {
+	Endpoint          string `toml:"endpoint"`
+	Token             string `toml:"token"`
+	S3Endpoint        string `toml:"s3_endpoint"`
+	S3Region          string `toml:"s3_region"`
+	S3AccessKeyID     string `toml:"s3_access_key_id"`
+	S3SecretAccessKey string `toml:"s3_secret_access_key"`
+	K2VEndpoint       string `toml:"k2v_endpoint"`
+}
+
+// providerProfilesFile is the layout of the provider profiles file:
+//
+//	[profiles.dev]
+//	endpoint = "http://dev.example.com:3903"
+//	token    = "..."
+type providerProfilesFile struct {
+	Profiles map[string]providerProfile `toml:"profiles"`
+}
+
+// defaultProfilesPath returns the default location of the profiles file,
+// $XDG_CONFIG_HOME/garage/profiles.toml or its platform equivalent.
+func defaultProfilesPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "garage", "profiles.toml"), nil
+}
+
+// loadProfile reads the named profile from the profiles file at path.
+func loadProfile(path, name string) (*providerProfile, error) {
+	var file providerProfilesFile
+	if _, err := toml.DecodeFile(path, &file); err != nil {
+		return nil, fmt.Errorf("unable to read profiles file %s: %w", path, err)
+	}
+
+	profile, ok := file.Profiles[name]
+	if !ok {
+		names := make([]string, 0, len(file.Profiles))
+		for n := range file.Profiles {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+
+		return nil, fmt.Errorf("profile %q not found in %s (available profiles: %s)", name, path, strings.Join(names, ", "))
+	}
+
+	return &profile, nil
+}
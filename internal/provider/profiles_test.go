@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.toml")
+	content := `
+[profiles.dev]
+endpoint    = "http://dev.example.com:3903"
+token       = "dev-token"
+s3_endpoint = "http://dev.example.com:3900"
+
+[profiles.prod]
+endpoint = "https://garage.example.com:3903"
+token    = "prod-token"
+s3_region = "eu-west"
+s3_access_key_id     = "GKprod"
+s3_secret_access_key = "prod-secret"
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	profile, err := loadProfile(path, "prod")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if profile.Endpoint != "https://garage.example.com:3903" {
+		t.Errorf("Expected prod endpoint, got %s", profile.Endpoint)
+	}
+	if profile.Token != "prod-token" {
+		t.Errorf("Expected prod token, got %s", profile.Token)
+	}
+	if profile.S3Region != "eu-west" {
+		t.Errorf("Expected S3 region eu-west, got %s", profile.S3Region)
+	}
+	if profile.S3AccessKeyID != "GKprod" || profile.S3SecretAccessKey != "prod-secret" {
+		t.Errorf("Expected S3 access key GKprod, got %s", profile.S3AccessKeyID)
+	}
+	if profile.S3Endpoint != "" {
+		t.Errorf("Expected empty S3 endpoint, got %s", profile.S3Endpoint)
+	}
+
+	_, err = loadProfile(path, "staging")
+	if err == nil {
+		t.Fatal("Expected error for unknown profile")
+	}
+	if !strings.Contains(err.Error(), "dev, prod") {
+		t.Errorf("Expected error to list available profiles, got %v", err)
+	}
+}
+
+func TestLoadProfile_missingFile(t *testing.T) {
+	_, err := loadProfile(filepath.Join(t.TempDir(), "missing.toml"), "dev")
+	if err == nil {
+		t.Fatal("Expected error for missing profiles file")
+	}
+}
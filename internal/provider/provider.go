@@ -5,14 +5,28 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net/url"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"terraform-provider-garage/internal/client"
@@ -29,12 +43,47 @@ type GarageProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// callStats records the Admin API calls made by the clients of the
+	// provider, so they can be summarized after each operation.
+	callStats *client.CallStats
+
+	// callSummary is set when call_summary is enabled, to report the Admin
+	// API calls of each operation as a warning.
+	callSummary atomic.Bool
 }
 
 // GarageProviderModel describes the provider data model.
 type GarageProviderModel struct {
-	Endpoint types.String `tfsdk:"endpoint"`
-	Token    types.String `tfsdk:"token"`
+	Endpoint           types.String `tfsdk:"endpoint"`
+	Endpoints          types.List   `tfsdk:"endpoints"`
+	Token              types.String `tfsdk:"token"`
+	TokenFile          types.String `tfsdk:"token_file"`
+	TokenCommand       types.List   `tfsdk:"token_command"`
+	S3Endpoint         types.String `tfsdk:"s3_endpoint"`
+	S3Region           types.String `tfsdk:"s3_region"`
+	S3AccessKeyID      types.String `tfsdk:"s3_access_key_id"`
+	S3SecretAccessKey  types.String `tfsdk:"s3_secret_access_key"`
+	K2VEndpoint        types.String `tfsdk:"k2v_endpoint"`
+	Profile            types.String `tfsdk:"profile"`
+	ProfilesFile       types.String `tfsdk:"profiles_file"`
+	GarageConfigFile   types.String `tfsdk:"garage_config_file"`
+	ValidateTokenScope types.Bool   `tfsdk:"validate_token_scope"`
+	MaxRetries         types.Int64  `tfsdk:"max_retries"`
+	RetryWaitMin       types.String `tfsdk:"retry_wait_min"`
+	RetryWaitMax       types.String `tfsdk:"retry_wait_max"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+	Headers            types.Map    `tfsdk:"headers"`
+	RequestTimeout     types.String `tfsdk:"request_timeout"`
+	DebugHTTP          types.Bool   `tfsdk:"debug_http"`
+	CallSummary        types.Bool   `tfsdk:"call_summary"`
+	KeyNamePrefix      types.String `tfsdk:"key_name_prefix"`
+	BucketAliasPrefix  types.String `tfsdk:"bucket_alias_prefix"`
 }
 
 func (p *GarageProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -49,11 +98,194 @@ func (p *GarageProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 			"endpoint": schema.StringAttribute{
 				MarkdownDescription: "The Garage Admin API endpoint URL. Can also be set via the GARAGE_ENDPOINT environment variable.",
 				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("endpoints")),
+				},
+			},
+			"endpoints": schema.ListAttribute{
+				MarkdownDescription: "The Admin API endpoint URLs of several nodes of the cluster, instead of `endpoint`. Before the first request they are ordered by the health each node reports, and a request fails over to the next endpoint when a node cannot be reached, so that a node being down does not fail the run. " +
+					"Can also be set via the GARAGE_ENDPOINTS environment variable, as a comma-separated list.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
 			},
 			"token": schema.StringAttribute{
 				MarkdownDescription: "The Garage Admin API bearer token. Can also be set via the GARAGE_TOKEN environment variable.",
 				Optional:            true,
 				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("token_file"), path.MatchRoot("token_command")),
+				},
+			},
+			"token_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file holding the admin token, such as a mounted secret, instead of `token`. Surrounding whitespace is ignored. " +
+					"Can also be set via the GARAGE_TOKEN_FILE environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("token_command")),
+				},
+			},
+			"token_command": schema.ListAttribute{
+				MarkdownDescription: "A program and its arguments printing the admin token on its standard output, run without a shell when the provider is configured, instead of `token` " +
+					"(e.g., `[\"vault\", \"kv\", \"get\", \"-field=token\", \"secret/garage\"]`). Surrounding whitespace is ignored.",
+				Optional:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"s3_endpoint": schema.StringAttribute{
+				MarkdownDescription: "The Garage S3 API endpoint URL, used by features that read or write objects. Can also be set via the GARAGE_S3_ENDPOINT environment variable.",
+				Optional:            true,
+			},
+			"s3_region": schema.StringAttribute{
+				MarkdownDescription: "The S3 region configured in Garage (`s3_region`). Defaults to `garage`. Can also be set via the GARAGE_S3_REGION environment variable.",
+				Optional:            true,
+			},
+			"s3_access_key_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the access key used by resources and data sources reading the S3 or K2V API when they are not given an access key of their own. Can also be set via the GARAGE_S3_ACCESS_KEY_ID environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("s3_secret_access_key")),
+				},
+			},
+			"s3_secret_access_key": schema.StringAttribute{
+				MarkdownDescription: "The secret of `s3_access_key_id`. Can also be set via the GARAGE_S3_SECRET_ACCESS_KEY environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("s3_access_key_id")),
+				},
+			},
+			"k2v_endpoint": schema.StringAttribute{
+				MarkdownDescription: "The Garage K2V API endpoint URL, used by the `garage_k2v_items` data source. Can also be set via the GARAGE_K2V_ENDPOINT environment variable.",
+				Optional:            true,
+			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "The name of a profile in the profiles file to read connection settings from. Settings given in the provider block or environment variables take precedence over the profile. Can also be set via the GARAGE_PROFILE environment variable.",
+				Optional:            true,
+			},
+			"profiles_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the TOML profiles file. Defaults to `garage/profiles.toml` in the user configuration directory (e.g., `~/.config/garage/profiles.toml`). Can also be set via the GARAGE_PROFILES_FILE environment variable.",
+				Optional:            true,
+			},
+			"garage_config_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a Garage daemon configuration file (e.g., `/etc/garage.toml`) to derive the admin endpoint, admin token and S3 endpoint from when running Terraform on a Garage host. " +
+					"Only used for settings not given in the provider block, environment variables or profile. Can also be set via the GARAGE_CONFIG_FILE environment variable.",
+				Optional: true,
+			},
+			"validate_token_scope": schema.BoolAttribute{
+				MarkdownDescription: "Check at plan time that the admin token's scope covers the endpoints each planned change will call, and fail with the missing scopes listed instead of partway through the apply. " +
+					"Can also be set via the GARAGE_VALIDATE_TOKEN_SCOPE environment variable.",
+				Optional: true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("How many times an Admin API request is retried when Garage is unreachable or answers with 429 or a 5xx error, as happens briefly during layout changes. Requests that create something are only retried when Garage could not be reached or answered 429. Defaults to `%d`; `0` disables retries. ", client.DefaultMaxRetries) +
+					"Can also be set via the GARAGE_MAX_RETRIES environment variable.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_wait_min": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The wait before the first retry (e.g., `500ms`), doubled on each following retry. Defaults to `%s`. ", client.DefaultRetryWaitMin) +
+					"Can also be set via the GARAGE_RETRY_WAIT_MIN environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					positiveDuration(),
+				},
+			},
+			"retry_wait_max": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The longest wait between two retries. Defaults to `%s`. ", client.DefaultRetryWaitMax) +
+					"Can also be set via the GARAGE_RETRY_WAIT_MAX environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					positiveDuration(),
+				},
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "A PEM-encoded CA certificate to trust, in addition to the system roots, when connecting to Garage over HTTPS, for instance behind a reverse proxy with a private CA. Conflicts with `ca_cert_file`. " +
+					"Can also be set via the GARAGE_CA_CERT_PEM environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("ca_cert_file")),
+				},
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM-encoded CA certificate file to trust, like `ca_cert_pem`. Can also be set via the GARAGE_CA_CERT_FILE environment variable.",
+				Optional:            true,
+			},
+			"client_cert": schema.StringAttribute{
+				MarkdownDescription: "A PEM-encoded client certificate to present to reverse proxies requiring mutual TLS. Requires `client_key`. Can also be set via the GARAGE_CLIENT_CERT environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key")),
+				},
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "The PEM-encoded private key of `client_cert`. Can also be set via the GARAGE_CLIENT_KEY environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert")),
+				},
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip the verification of the server certificate. Only use this for testing against self-signed certificates. " +
+					"Can also be set via the GARAGE_INSECURE_SKIP_VERIFY environment variable.",
+				Optional: true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "The URL of an HTTP(S) proxy to send Admin API and S3 requests through (e.g., `http://proxy.internal:3128`). Defaults to the proxy set by the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables. " +
+					"Can also be set via the GARAGE_PROXY_URL environment variable.",
+				Optional: true,
+			},
+			"headers": schema.MapAttribute{
+				MarkdownDescription: "Extra headers to send with every Admin API request, for instance the service token headers of Cloudflare Access or the credentials expected by oauth2-proxy in front of the Admin API. " +
+					"They cannot replace the `Authorization` header carrying the admin token.",
+				Optional:    true,
+				Sensitive:   true,
+				ElementType: types.StringType,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("The time limit of a single Admin API or S3 request (e.g., `30s`), after which it fails or is retried. Defaults to `%s`. ", client.DefaultRequestTimeout) +
+					"Can also be set via the GARAGE_REQUEST_TIMEOUT environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					positiveDuration(),
+				},
+			},
+			"debug_http": schema.BoolAttribute{
+				MarkdownDescription: "Log the method, path, status, duration and body of every Admin API and S3 request at TRACE level, with secrets redacted, to troubleshoot failing calls. Run Terraform with `TF_LOG=TRACE` (or `TF_LOG_PROVIDER=TRACE`) to see them. " +
+					"Can also be set via the GARAGE_DEBUG_HTTP environment variable.",
+				Optional: true,
+			},
+			"call_summary": schema.BoolAttribute{
+				MarkdownDescription: "Report how many Admin API calls each resource and data source operation made per endpoint, and how long they took, as a warning. " +
+					"The summary is always logged at DEBUG level. Can also be set via the GARAGE_CALL_SUMMARY environment variable.",
+				Optional: true,
+			},
+			"key_name_prefix": schema.StringAttribute{
+				MarkdownDescription: "A prefix prepended to the name of every `garage_key` resource and ephemeral resource (e.g., `staging-`), so that several environments sharing a cluster keep their keys apart. " +
+					"The `name` attribute holds the name without the prefix and `full_name` the name stored in Garage; changing the prefix renames the keys in place. " +
+					"Can also be set via the GARAGE_KEY_NAME_PREFIX environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[[:graph:]]*$`), "must only contain printable characters other than spaces"),
+				},
+			},
+			"bucket_alias_prefix": schema.StringAttribute{
+				MarkdownDescription: "A prefix prepended to the global aliases of every `garage_bucket` (e.g., `staging-`), so that several environments sharing a cluster keep their buckets apart. " +
+					"The `global_alias` and `global_aliases` attributes hold the aliases without the prefix and `full_global_alias` the alias stored in Garage; changing the prefix moves the aliases in place. " +
+					"Data sources and other resources take aliases as stored in Garage. Can also be set via the GARAGE_BUCKET_ALIAS_PREFIX environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					bucketAliasPrefix(),
+				},
 			},
 		},
 	}
@@ -69,14 +301,233 @@ func (p *GarageProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	}
 
 	// Check for environment variables if not set in config
-	endpoint := data.Endpoint.ValueString()
-	if endpoint == "" {
-		endpoint = os.Getenv("GARAGE_ENDPOINT")
+	endpoints, diags := endpointsFrom(ctx, data, os.Getenv)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	token := data.Token.ValueString()
-	if token == "" {
-		token = os.Getenv("GARAGE_TOKEN")
+	// The first endpoint is tried first until the nodes have been health checked
+	endpoint := ""
+	var failoverEndpoints []string
+	if len(endpoints) > 0 {
+		endpoint, failoverEndpoints = endpoints[0], endpoints[1:]
+	}
+
+	token, err := tokenFrom(ctx, data, os.Getenv)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Read Garage Token", err.Error())
+		return
+	}
+
+	s3Endpoint := data.S3Endpoint.ValueString()
+	if s3Endpoint == "" {
+		s3Endpoint = os.Getenv("GARAGE_S3_ENDPOINT")
+	}
+
+	s3Region := data.S3Region.ValueString()
+	if s3Region == "" {
+		s3Region = os.Getenv("GARAGE_S3_REGION")
+	}
+
+	s3AccessKeyID, s3SecretAccessKey := data.S3AccessKeyID.ValueString(), data.S3SecretAccessKey.ValueString()
+	if s3AccessKeyID == "" {
+		s3AccessKeyID, s3SecretAccessKey = os.Getenv("GARAGE_S3_ACCESS_KEY_ID"), os.Getenv("GARAGE_S3_SECRET_ACCESS_KEY")
+	}
+
+	k2vEndpoint := data.K2VEndpoint.ValueString()
+	if k2vEndpoint == "" {
+		k2vEndpoint = os.Getenv("GARAGE_K2V_ENDPOINT")
+	}
+
+	// Fill remaining settings from the selected profile, if any
+	profileName := data.Profile.ValueString()
+	if profileName == "" {
+		profileName = os.Getenv("GARAGE_PROFILE")
+	}
+
+	if profileName != "" {
+		profilesFile := data.ProfilesFile.ValueString()
+		if profilesFile == "" {
+			profilesFile = os.Getenv("GARAGE_PROFILES_FILE")
+		}
+		if profilesFile == "" {
+			var err error
+			profilesFile, err = defaultProfilesPath()
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Unable to Locate Profiles File",
+					fmt.Sprintf("The default profiles file location could not be determined, set profiles_file explicitly: %s", err),
+				)
+				return
+			}
+		}
+
+		profile, err := loadProfile(profilesFile, profileName)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Load Profile", err.Error())
+			return
+		}
+
+		if endpoint == "" {
+			endpoint = profile.Endpoint
+		}
+		if token == "" {
+			token = profile.Token
+		}
+		if s3Endpoint == "" {
+			s3Endpoint = profile.S3Endpoint
+		}
+		if s3Region == "" {
+			s3Region = profile.S3Region
+		}
+		if s3AccessKeyID == "" {
+			s3AccessKeyID, s3SecretAccessKey = profile.S3AccessKeyID, profile.S3SecretAccessKey
+		}
+		if k2vEndpoint == "" {
+			k2vEndpoint = profile.K2VEndpoint
+		}
+	}
+
+	// As a last resort, derive settings from the local Garage configuration
+	garageConfigFile := data.GarageConfigFile.ValueString()
+	if garageConfigFile == "" {
+		garageConfigFile = os.Getenv("GARAGE_CONFIG_FILE")
+	}
+
+	if garageConfigFile != "" {
+		garageConfig, err := loadGarageConfig(garageConfigFile)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to Load Garage Configuration", err.Error())
+			return
+		}
+
+		if endpoint == "" {
+			endpoint = garageConfig.Endpoint
+		}
+		if token == "" {
+			token = garageConfig.Token
+		}
+		if s3Endpoint == "" {
+			s3Endpoint = garageConfig.S3Endpoint
+		}
+		if s3Region == "" {
+			s3Region = garageConfig.S3Region
+		}
+		if k2vEndpoint == "" {
+			k2vEndpoint = garageConfig.K2VEndpoint
+		}
+	}
+
+	if s3Region == "" {
+		s3Region = client.DefaultS3Region
+	}
+
+	validateTokenScope := data.ValidateTokenScope.ValueBool()
+	if data.ValidateTokenScope.IsNull() {
+		if v := os.Getenv("GARAGE_VALIDATE_TOKEN_SCOPE"); v != "" {
+			var err error
+			validateTokenScope, err = strconv.ParseBool(v)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Invalid GARAGE_VALIDATE_TOKEN_SCOPE",
+					fmt.Sprintf("Unable to parse GARAGE_VALIDATE_TOKEN_SCOPE %q as a boolean: %s", v, err),
+				)
+				return
+			}
+		}
+	}
+
+	debugHTTP := data.DebugHTTP.ValueBool()
+	if data.DebugHTTP.IsNull() {
+		if v := os.Getenv("GARAGE_DEBUG_HTTP"); v != "" {
+			var err error
+			debugHTTP, err = strconv.ParseBool(v)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Invalid GARAGE_DEBUG_HTTP",
+					fmt.Sprintf("Unable to parse GARAGE_DEBUG_HTTP %q as a boolean: %s", v, err),
+				)
+				return
+			}
+		}
+	}
+
+	callSummary := data.CallSummary.ValueBool()
+	if data.CallSummary.IsNull() {
+		if v := os.Getenv("GARAGE_CALL_SUMMARY"); v != "" {
+			var err error
+			callSummary, err = strconv.ParseBool(v)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Invalid GARAGE_CALL_SUMMARY",
+					fmt.Sprintf("Unable to parse GARAGE_CALL_SUMMARY %q as a boolean: %s", v, err),
+				)
+				return
+			}
+		}
+	}
+
+	retries, err := retrySettingsFrom(data, os.Getenv)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Retry Settings", err.Error())
+		return
+	}
+
+	requestTimeout, err := requestTimeoutFrom(data, os.Getenv)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Request Timeout", err.Error())
+		return
+	}
+
+	tlsOpts, err := tlsSettingsFrom(data, os.Getenv)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid TLS Configuration", err.Error())
+		return
+	}
+
+	tlsConfig, err := buildTLSConfig(tlsOpts)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid TLS Configuration", err.Error())
+		return
+	}
+
+	proxyURL := data.ProxyURL.ValueString()
+	if proxyURL == "" {
+		proxyURL = os.Getenv("GARAGE_PROXY_URL")
+	}
+
+	var proxy *url.URL
+	if proxyURL != "" {
+		var err error
+		proxy, err = url.Parse(proxyURL)
+		if err != nil || proxy.Scheme == "" || proxy.Host == "" {
+			resp.Diagnostics.AddError("Invalid Proxy URL", fmt.Sprintf("The proxy URL %q must be an absolute URL such as http://proxy.internal:3128.", proxyURL))
+			return
+		}
+	}
+
+	headers := map[string]string{}
+	resp.Diagnostics.Append(data.Headers.ElementsAs(ctx, &headers, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keyNamePrefix := data.KeyNamePrefix.ValueString()
+	if data.KeyNamePrefix.IsNull() {
+		keyNamePrefix = os.Getenv("GARAGE_KEY_NAME_PREFIX")
+	}
+
+	bucketAliasPrefix := data.BucketAliasPrefix.ValueString()
+	if data.BucketAliasPrefix.IsNull() {
+		bucketAliasPrefix = os.Getenv("GARAGE_BUCKET_ALIAS_PREFIX")
+		if problem := bucketAliasPrefixProblem(bucketAliasPrefix); problem != "" {
+			resp.Diagnostics.AddError(
+				"Invalid GARAGE_BUCKET_ALIAS_PREFIX",
+				fmt.Sprintf("The prefix %q cannot start a valid bucket name: %s.", bucketAliasPrefix, problem),
+			)
+			return
+		}
 	}
 
 	// Validate required configuration
@@ -84,7 +535,7 @@ func (p *GarageProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		resp.Diagnostics.AddError(
 			"Missing Garage Endpoint",
 			"The provider cannot create the Garage API client as there is a missing or empty value for the Garage endpoint. "+
-				"Set the endpoint value in the configuration or use the GARAGE_ENDPOINT environment variable. "+
+				"Set the endpoint or endpoints value in the configuration, use the GARAGE_ENDPOINT or GARAGE_ENDPOINTS environment variable, or select a profile that sets it. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
@@ -93,8 +544,8 @@ func (p *GarageProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		resp.Diagnostics.AddError(
 			"Missing Garage Token",
 			"The provider cannot create the Garage API client as there is a missing or empty value for the Garage admin token. "+
-				"Set the token value in the configuration or use the GARAGE_TOKEN environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"Set the token, token_file or token_command value in the configuration, use the GARAGE_TOKEN or GARAGE_TOKEN_FILE environment variable, or select a profile that sets it. "+
+				"If one is already set, ensure the value is not empty.",
 		)
 	}
 
@@ -103,37 +554,205 @@ func (p *GarageProvider) Configure(ctx context.Context, req provider.ConfigureRe
 	}
 
 	// Create Garage API client
-	garageClient := client.NewClient(endpoint, token)
+	garageClient := client.NewClient(endpoint, token,
+		client.WithS3Endpoint(s3Endpoint, s3Region),
+		client.WithS3Credentials(s3AccessKeyID, s3SecretAccessKey),
+		client.WithK2VEndpoint(k2vEndpoint),
+		client.WithTokenScopeValidation(validateTokenScope),
+		client.WithCallStats(p.callStats),
+		client.WithRetries(retries.maxRetries, retries.waitMin, retries.waitMax),
+		client.WithTLSConfig(tlsConfig),
+		client.WithProxy(proxy),
+		client.WithHeaders(headers),
+		client.WithRequestTimeout(requestTimeout),
+		client.WithHTTPLogging(debugHTTP),
+		client.WithNamePrefixes(keyNamePrefix, bucketAliasPrefix),
+		client.WithFailoverEndpoints(failoverEndpoints...),
+	)
+	p.callSummary.Store(callSummary)
+
 	resp.DataSourceData = garageClient
 	resp.ResourceData = garageClient
+	resp.EphemeralResourceData = garageClient
 }
 
 func (p *GarageProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewBucketResource,
 		NewBucketPermissionResource,
+		NewBucketPolicyResource,
+		NewBucketWebsiteResource,
+		NewObjectResource,
+		NewBucketCorsConfigurationResource,
 		NewKeyResource,
+		NewStaticWebsiteResource,
+		NewClusterNodeResource,
+		NewClusterNodeRoleResource,
+		NewClusterLayoutResource,
+		NewAdminTokenResource,
+		NewBucketPrefixPurgeResource,
+		NewBucketUploadCleanupResource,
+		NewNodeMaintenanceResource,
+		NewRepairResource,
+		NewMetadataSnapshotResource,
 	}
 }
 
 func (p *GarageProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
-	return []func() ephemeral.EphemeralResource{}
+	return []func() ephemeral.EphemeralResource{
+		NewKeyEphemeralResource,
+		NewAdminTokenEphemeralResource,
+	}
 }
 
 func (p *GarageProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewBucketDataSource,
+		NewBucketAliasDataSource,
+		NewBucketAliasesDataSource,
+		NewBucketPermissionsDataSource,
+		NewS3ConnectionDataSource,
+		NewClusterPartitionBalanceDataSource,
+		NewClusterCapacityDataSource,
+		NewClusterZonesDataSource,
+		NewWorkerVariablesDataSource,
+		NewWorkerInfoDataSource,
+		NewNodeDataSource,
+		NewAdminAPIInfoDataSource,
+		NewAdminTokenDataSource,
+		NewKeyDataSource,
+		NewKeysDataSource,
+		NewDomainCheckDataSource,
+		NewBlockErrorsDataSource,
+		NewObjectInfoDataSource,
+		NewK2VItemsDataSource,
 	}
 }
 
 func (p *GarageProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewQuotaHeadroomFunction,
+		NewSplitImportIDFunction,
+		NewGenerateCredentialsFunction,
+	}
+}
+
+// retrySettings are the resolved retry settings of the provider.
+type retrySettings struct {
+	maxRetries int
+	waitMin    time.Duration
+	waitMax    time.Duration
+}
+
+// retrySettingsFrom resolves the retry settings from the provider
+// configuration, falling back to the environment variables read with getenv
+// and then to the defaults.
+func retrySettingsFrom(data GarageProviderModel, getenv func(string) string) (retrySettings, error) {
+	settings := retrySettings{
+		maxRetries: client.DefaultMaxRetries,
+		waitMin:    client.DefaultRetryWaitMin,
+		waitMax:    client.DefaultRetryWaitMax,
+	}
+
+	if !data.MaxRetries.IsNull() {
+		settings.maxRetries = int(data.MaxRetries.ValueInt64())
+	} else if v := getenv("GARAGE_MAX_RETRIES"); v != "" {
+		maxRetries, err := strconv.Atoi(v)
+		if err != nil || maxRetries < 0 {
+			return settings, fmt.Errorf("GARAGE_MAX_RETRIES must be a non-negative integer, got %q", v)
+		}
+		settings.maxRetries = maxRetries
+	}
+
+	durations := []struct {
+		value types.String
+		attr  string
+		env   string
+		dest  *time.Duration
+	}{
+		{data.RetryWaitMin, "retry_wait_min", "GARAGE_RETRY_WAIT_MIN", &settings.waitMin},
+		{data.RetryWaitMax, "retry_wait_max", "GARAGE_RETRY_WAIT_MAX", &settings.waitMax},
+	}
+	for _, d := range durations {
+		name, v := d.attr, d.value.ValueString()
+		if d.value.IsNull() {
+			name, v = d.env, getenv(d.env)
+		}
+		if v == "" {
+			continue
+		}
+
+		duration, err := time.ParseDuration(v)
+		if err != nil || duration <= 0 {
+			return settings, fmt.Errorf("%s must be a positive duration such as 2s, got %q", name, v)
+		}
+		*d.dest = duration
+	}
+
+	if settings.waitMin > settings.waitMax {
+		return settings, fmt.Errorf("retry_wait_min (%s) must not be longer than retry_wait_max (%s)", settings.waitMin, settings.waitMax)
+	}
+
+	return settings, nil
+}
+
+// requestTimeoutFrom resolves the request timeout from the provider
+// configuration, falling back to the GARAGE_REQUEST_TIMEOUT environment
+// variable read with getenv and then to the default.
+func requestTimeoutFrom(data GarageProviderModel, getenv func(string) string) (time.Duration, error) {
+	name, v := "request_timeout", data.RequestTimeout.ValueString()
+	if data.RequestTimeout.IsNull() {
+		name, v = "GARAGE_REQUEST_TIMEOUT", getenv("GARAGE_REQUEST_TIMEOUT")
+	}
+	if v == "" {
+		return client.DefaultRequestTimeout, nil
+	}
+
+	timeout, err := time.ParseDuration(v)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("%s must be a positive duration such as 30s, got %q", name, v)
+	}
+
+	return timeout, nil
+}
+
+// endpointsFrom resolves the admin endpoints from the endpoints or endpoint
+// attribute, falling back to the GARAGE_ENDPOINTS and then GARAGE_ENDPOINT
+// environment variables read with getenv. It returns nil when none is set.
+func endpointsFrom(ctx context.Context, data GarageProviderModel, getenv func(string) string) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	switch {
+	case !data.Endpoints.IsNull():
+		var endpoints []string
+		diags.Append(data.Endpoints.ElementsAs(ctx, &endpoints, false)...)
+		return endpoints, diags
+	case data.Endpoint.ValueString() != "":
+		return []string{data.Endpoint.ValueString()}, diags
+	}
+
+	var endpoints []string
+	for _, endpoint := range strings.Split(getenv("GARAGE_ENDPOINTS"), ",") {
+		if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	if len(endpoints) > 0 {
+		return endpoints, diags
+	}
+
+	if endpoint := getenv("GARAGE_ENDPOINT"); endpoint != "" {
+		return []string{endpoint}, diags
+	}
+
+	return nil, diags
 }
 
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &GarageProvider{
-			version: version,
+			version:   version,
+			callStats: &client.CallStats{},
 		}
 	}
 }
@@ -4,27 +4,33 @@
 package provider
 
 import (
+	"context"
 	"os"
+	"slices"
 	"testing"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+
+	"terraform-provider-garage/internal/client"
 )
 
 // testAccProtoV6ProviderFactories is used to instantiate a provider during acceptance testing.
 // The factory function is called for each Terraform CLI command to create a provider
 // server that the CLI can connect to and interact with.
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"garage": providerserver.NewProtocol6WithError(New("test")()),
+	"garage": func() (tfprotov6.ProviderServer, error) { return NewProtocol6Server("test")(), nil },
 }
 
 // testAccProtoV6ProviderFactoriesWithEcho includes the echo provider alongside the garage provider.
 // It allows for testing assertions on data returned by an ephemeral resource during Open.
 // The echoprovider is used to arrange tests by echoing ephemeral data into the Terraform state.
 // This lets the data be referenced in test assertions with state checks.
-var _ = map[string]func() (tfprotov6.ProviderServer, error){
-	"garage": providerserver.NewProtocol6WithError(New("test")()),
+var testAccProtoV6ProviderFactoriesWithEcho = map[string]func() (tfprotov6.ProviderServer, error){
+	"garage": func() (tfprotov6.ProviderServer, error) { return NewProtocol6Server("test")(), nil },
 	"echo":   echoprovider.NewProviderServer(),
 }
 
@@ -38,3 +44,203 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("GARAGE_TOKEN must be set for acceptance tests")
 	}
 }
+
+// testAccPreCheckS3 skips tests that need the Garage S3 API when no S3 endpoint is configured.
+func testAccPreCheckS3(t *testing.T) {
+	testAccPreCheck(t)
+
+	if v := os.Getenv("GARAGE_S3_ENDPOINT"); v == "" {
+		t.Skip("GARAGE_S3_ENDPOINT must be set for acceptance tests that use the S3 API")
+	}
+}
+
+// testAccPreCheckK2V skips tests that need the Garage K2V API when no K2V endpoint is configured.
+func testAccPreCheckK2V(t *testing.T) {
+	testAccPreCheck(t)
+
+	if v := os.Getenv("GARAGE_K2V_ENDPOINT"); v == "" {
+		t.Skip("GARAGE_K2V_ENDPOINT must be set for acceptance tests that use the K2V API")
+	}
+}
+
+// testAccPreCheckNode skips tests that change the role of a node unless a
+// node that may safely be reassigned and removed is provided.
+func testAccPreCheckNode(t *testing.T) {
+	testAccPreCheck(t)
+
+	if v := os.Getenv("GARAGE_TEST_NODE_ID"); v == "" {
+		t.Skip("GARAGE_TEST_NODE_ID must be set for acceptance tests that change the cluster layout")
+	}
+}
+
+// testAccPreCheckNodeAddress skips tests that connect a node unless the
+// address of a node the cluster can reach is provided.
+func testAccPreCheckNodeAddress(t *testing.T) {
+	testAccPreCheck(t)
+
+	if v := os.Getenv("GARAGE_TEST_NODE_ADDRESS"); v == "" {
+		t.Skip("GARAGE_TEST_NODE_ADDRESS must be set for acceptance tests that connect cluster nodes")
+	}
+}
+
+func TestRetrySettingsFrom(t *testing.T) {
+	config := func(maxRetries types.Int64, waitMin, waitMax types.String) GarageProviderModel {
+		return GarageProviderModel{MaxRetries: maxRetries, RetryWaitMin: waitMin, RetryWaitMax: waitMax}
+	}
+	unset := config(types.Int64Null(), types.StringNull(), types.StringNull())
+
+	tests := []struct {
+		name    string
+		data    GarageProviderModel
+		env     map[string]string
+		want    retrySettings
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			data: unset,
+			want: retrySettings{maxRetries: client.DefaultMaxRetries, waitMin: client.DefaultRetryWaitMin, waitMax: client.DefaultRetryWaitMax},
+		},
+		{
+			name: "configured",
+			data: config(types.Int64Value(0), types.StringValue("100ms"), types.StringValue("2s")),
+			env:  map[string]string{"GARAGE_MAX_RETRIES": "7"},
+			want: retrySettings{maxRetries: 0, waitMin: 100 * time.Millisecond, waitMax: 2 * time.Second},
+		},
+		{
+			name: "environment",
+			data: unset,
+			env:  map[string]string{"GARAGE_MAX_RETRIES": "7", "GARAGE_RETRY_WAIT_MIN": "2s", "GARAGE_RETRY_WAIT_MAX": "1m"},
+			want: retrySettings{maxRetries: 7, waitMin: 2 * time.Second, waitMax: time.Minute},
+		},
+		{
+			name:    "invalid environment",
+			data:    unset,
+			env:     map[string]string{"GARAGE_MAX_RETRIES": "-1"},
+			wantErr: true,
+		},
+		{
+			name:    "minimum above maximum",
+			data:    config(types.Int64Null(), types.StringValue("1m"), types.StringValue("10s")),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := retrySettingsFrom(tt.data, func(name string) string { return tt.env[name] })
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRequestTimeoutFrom(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   types.String
+		env     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "default", value: types.StringNull(), want: client.DefaultRequestTimeout},
+		{name: "configured", value: types.StringValue("30s"), env: "5m", want: 30 * time.Second},
+		{name: "environment", value: types.StringNull(), env: "5m", want: 5 * time.Minute},
+		{name: "invalid environment", value: types.StringNull(), env: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(name string) string {
+				if name == "GARAGE_REQUEST_TIMEOUT" {
+					return tt.env
+				}
+				return ""
+			}
+
+			got, err := requestTimeoutFrom(GarageProviderModel{RequestTimeout: tt.value}, getenv)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEndpointsFrom(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoint  types.String
+		endpoints types.List
+		env       map[string]string
+		want      []string
+	}{
+		{
+			name:      "none",
+			endpoint:  types.StringNull(),
+			endpoints: types.ListNull(types.StringType),
+		},
+		{
+			name:      "endpoint",
+			endpoint:  types.StringValue("http://node1:3903"),
+			endpoints: types.ListNull(types.StringType),
+			env:       map[string]string{"GARAGE_ENDPOINTS": "http://node2:3903"},
+			want:      []string{"http://node1:3903"},
+		},
+		{
+			name:     "endpoints",
+			endpoint: types.StringNull(),
+			endpoints: types.ListValueMust(types.StringType, []attr.Value{
+				types.StringValue("http://node1:3903"),
+				types.StringValue("http://node2:3903"),
+			}),
+			want: []string{"http://node1:3903", "http://node2:3903"},
+		},
+		{
+			name:      "environment list",
+			endpoint:  types.StringNull(),
+			endpoints: types.ListNull(types.StringType),
+			env:       map[string]string{"GARAGE_ENDPOINTS": " http://node1:3903, http://node2:3903,", "GARAGE_ENDPOINT": "http://node3:3903"},
+			want:      []string{"http://node1:3903", "http://node2:3903"},
+		},
+		{
+			name:      "environment",
+			endpoint:  types.StringNull(),
+			endpoints: types.ListNull(types.StringType),
+			env:       map[string]string{"GARAGE_ENDPOINT": "http://node3:3903"},
+			want:      []string{"http://node3:3903"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(name string) string { return tt.env[name] }
+
+			got, diags := endpointsFrom(context.Background(), GarageProviderModel{Endpoint: tt.endpoint, Endpoints: tt.endpoints}, getenv)
+			if diags.HasError() {
+				t.Fatalf("Expected no error, got %v", diags)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
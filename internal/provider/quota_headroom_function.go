@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &QuotaHeadroomFunction{}
+
+// quotaHeadroomAttributeTypes are the attributes of the quota_headroom result.
+var quotaHeadroomAttributeTypes = map[string]attr.Type{
+	"used_percent": types.Float64Type,
+	"remaining":    types.Int64Type,
+}
+
+func NewQuotaHeadroomFunction() function.Function {
+	return &QuotaHeadroomFunction{}
+}
+
+// QuotaHeadroomFunction defines the function implementation.
+type QuotaHeadroomFunction struct{}
+
+func (f *QuotaHeadroomFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "quota_headroom"
+}
+
+func (f *QuotaHeadroomFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Computes how much of a bucket quota is used",
+		MarkdownDescription: "Given the current usage of a bucket and its quota, such as the `bytes` and `max_size` attributes of the `garage_bucket` data source, " +
+			"returns an object with `used_percent`, the percentage of the quota in use, and `remaining`, what can still be stored before the quota is reached. " +
+			"Both are null when the quota is null, since the bucket is then unlimited.",
+
+		Parameters: []function.Parameter{
+			function.Int64Parameter{
+				Name:                "used",
+				MarkdownDescription: "The current usage, in bytes or objects.",
+			},
+			function.Int64Parameter{
+				Name:                "quota",
+				AllowNullValue:      true,
+				MarkdownDescription: "The quota in the same unit as `used`, or null when there is no quota.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: quotaHeadroomAttributeTypes,
+		},
+	}
+}
+
+func (f *QuotaHeadroomFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var used int64
+	var quota types.Int64
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &used, &quota))
+	if resp.Error != nil {
+		return
+	}
+
+	if used < 0 {
+		resp.Error = function.NewArgumentFuncError(0, "The usage cannot be negative.")
+		return
+	}
+
+	usedPercent := types.Float64Null()
+	remaining := types.Int64Null()
+
+	if !quota.IsNull() {
+		if quota.ValueInt64() <= 0 {
+			resp.Error = function.NewArgumentFuncError(1, "The quota must be positive, or null when there is no quota.")
+			return
+		}
+
+		percent, left := quotaHeadroom(used, quota.ValueInt64())
+		usedPercent = types.Float64Value(percent)
+		remaining = types.Int64Value(left)
+	}
+
+	result, diags := types.ObjectValue(quotaHeadroomAttributeTypes, map[string]attr.Value{
+		"used_percent": usedPercent,
+		"remaining":    remaining,
+	})
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
+
+// quotaHeadroom returns the percentage of the quota in use and what remains
+// before the quota is reached. Usage above the quota leaves nothing.
+func quotaHeadroom(used, quota int64) (float64, int64) {
+	return float64(used) * 100 / float64(quota), max(quota-used, 0)
+}
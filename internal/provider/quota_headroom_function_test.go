@@ -0,0 +1,76 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestQuotaHeadroomFunction(t *testing.T) {
+	tests := []struct {
+		name          string
+		used          attr.Value
+		quota         attr.Value
+		wantPercent   types.Float64
+		wantRemaining types.Int64
+		wantError     bool
+	}{
+		{
+			name:          "quarter",
+			used:          types.Int64Value(250),
+			quota:         types.Int64Value(1000),
+			wantPercent:   types.Float64Value(25),
+			wantRemaining: types.Int64Value(750),
+		},
+		{
+			name:          "over quota",
+			used:          types.Int64Value(1200),
+			quota:         types.Int64Value(1000),
+			wantPercent:   types.Float64Value(120),
+			wantRemaining: types.Int64Value(0),
+		},
+		{
+			name:          "unlimited",
+			used:          types.Int64Value(250),
+			quota:         types.Int64Null(),
+			wantPercent:   types.Float64Null(),
+			wantRemaining: types.Int64Null(),
+		},
+		{name: "negative usage", used: types.Int64Value(-1), quota: types.Int64Value(1000), wantError: true},
+		{name: "zero quota", used: types.Int64Value(0), quota: types.Int64Value(0), wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{tt.used, tt.quota}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ObjectUnknown(quotaHeadroomAttributeTypes)),
+			}
+
+			NewQuotaHeadroomFunction().Run(context.Background(), req, resp)
+
+			if (resp.Error != nil) != tt.wantError {
+				t.Fatalf("Expected error %v, got %v", tt.wantError, resp.Error)
+			}
+			if tt.wantError {
+				return
+			}
+
+			result := resp.Result.Value().(types.Object).Attributes()
+			if !result["used_percent"].Equal(tt.wantPercent) {
+				t.Errorf("Expected used_percent %s, got %s", tt.wantPercent, result["used_percent"])
+			}
+			if !result["remaining"].Equal(tt.wantRemaining) {
+				t.Errorf("Expected remaining %s, got %s", tt.wantRemaining, result["remaining"])
+			}
+		})
+	}
+}
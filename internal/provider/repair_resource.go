@@ -0,0 +1,400 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// repairTypes are the repair operations garage_repair can launch, by the
+// name used in the configuration.
+var repairTypes = map[string]string{
+	"tables":            client.RepairTables,
+	"blocks":            client.RepairBlocks,
+	"versions":          client.RepairVersions,
+	"multipart_uploads": client.RepairMultipartUploads,
+	"block_refs":        client.RepairBlockRefs,
+	"block_rc":          client.RepairBlockRc,
+	"rebalance":         client.RepairRebalance,
+	"scrub":             client.RepairScrub,
+}
+
+// repairPollInterval is how often the workers of a repair are checked while
+// waiting for it to complete.
+var repairPollInterval = 10 * time.Second
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RepairResource{}
+var _ resource.ResourceWithModifyPlan = &RepairResource{}
+
+func NewRepairResource() resource.Resource {
+	return &RepairResource{}
+}
+
+// RepairResource defines the resource implementation.
+type RepairResource struct {
+	client *client.Client
+}
+
+// RepairResourceModel describes the resource data model.
+type RepairResourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	Node              types.String `tfsdk:"node"`
+	RepairType        types.String `tfsdk:"repair_type"`
+	ScrubCommand      types.String `tfsdk:"scrub_command"`
+	Triggers          types.Map    `tfsdk:"triggers"`
+	WaitForCompletion types.Bool   `tfsdk:"wait_for_completion"`
+	LaunchedNodes     types.List   `tfsdk:"launched_nodes"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *RepairResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_repair"
+}
+
+func (r *RepairResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	repairTypeNames := make([]string, 0, len(repairTypes))
+	for name := range repairTypes {
+		repairTypeNames = append(repairTypeNames, name)
+	}
+	sort.Strings(repairTypeNames)
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Launches a repair operation in the background of one or all nodes, like `garage repair`, so recurring maintenance such as scrubs or block resynchronization can be scheduled with the infrastructure. " +
+			"The repair is launched when the resource is created and every time it is updated, for instance when `triggers` change. Destroying the resource does not stop a running repair.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The identifier of the repair (same as `node`).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"node": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(client.AllNodes),
+				MarkdownDescription: "The node to repair: a node ID, `self` for the node the provider talks to, or `*` for every node. Defaults to `*`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"repair_type": schema.StringAttribute{
+				Required: true,
+				MarkdownDescription: "The repair operation: `tables` (resynchronize the metadata tables), `blocks` (fetch missing and delete unneeded data blocks), " +
+					"`versions` and `multipart_uploads` (delete object versions and uploads of deleted objects), `block_refs` and `block_rc` (recompute block references and reference counts), " +
+					"`rebalance` (move data blocks to the right data directories) or `scrub` (verify the integrity of every data block).",
+				Validators: []validator.String{
+					stringvalidator.OneOf(repairTypeNames...),
+				},
+			},
+			"scrub_command": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "What to do with the scrub: `start`, `pause`, `resume` or `cancel`. Required when `repair_type` is `scrub`, and only allowed then.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(client.ScrubStart, client.ScrubPause, client.ScrubResume, client.ScrubCancel),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Arbitrary values that launch the repair again when they change, such as a timestamp from a `time_rotating` resource.",
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				MarkdownDescription: "Wait until the workers started by the repair have finished, logging their progress, so that dependent resources run against a repaired cluster. " +
+					"The wait is bounded by the `create` and `update` timeouts. `tables` and `scrub` repairs run on workers that are always present, and are not waited for. Defaults to `false`.",
+			},
+			"launched_nodes": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "The IDs of the nodes that launched the repair the last time it ran.",
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			// Read and Delete make no API call, so they take no timeout
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+func (r *RepairResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RepairResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if !req.Plan.Raw.IsNull() {
+		var data RepairResourceModel
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+		if !data.RepairType.IsUnknown() && !data.ScrubCommand.IsUnknown() {
+			isScrub := data.RepairType.ValueString() == "scrub"
+			switch {
+			case isScrub && data.ScrubCommand.IsNull():
+				resp.Diagnostics.AddAttributeError(path.Root("scrub_command"), "Missing Scrub Command", "scrub_command must be set when repair_type is scrub.")
+			case !isScrub && !data.ScrubCommand.IsNull():
+				resp.Diagnostics.AddAttributeError(path.Root("scrub_command"), "Unexpected Scrub Command", "scrub_command can only be set when repair_type is scrub.")
+			}
+		}
+	}
+
+	scopes := resourceScopes{
+		Create: []string{"LaunchRepairOperation"},
+		Update: []string{"LaunchRepairOperation"},
+	}
+
+	// Waiting for the repair lists the workers before and after launching it
+	if !req.Plan.Raw.IsNull() {
+		var wait types.Bool
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("wait_for_completion"), &wait)...)
+		if wait.ValueBool() {
+			scopes.Create = append(scopes.Create, "ListWorkers")
+			scopes.Update = append(scopes.Update, "ListWorkers")
+		}
+	}
+
+	validateTokenScope(ctx, r.client, scopes, req, resp)
+}
+
+func (r *RepairResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RepairResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	r.launch(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.Node
+
+	tflog.Trace(ctx, "Created repair resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RepairResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// A launched repair leaves nothing to refresh
+	var data RepairResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RepairResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RepairResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	r.launch(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Updated repair resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RepairResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Nothing to undo: a scrub is stopped with scrub_command = "cancel"
+	tflog.Trace(ctx, "Deleted repair resource")
+}
+
+// launch launches the repair operation and records the nodes that started it.
+// With wait_for_completion, it then waits for the workers the repair started.
+func (r *RepairResource) launch(ctx context.Context, data *RepairResourceModel, diags *diag.Diagnostics) {
+	node := data.Node.ValueString()
+
+	// The workers running before the launch are not part of the repair
+	var before *client.ListWorkersResponse
+	if data.WaitForCompletion.ValueBool() {
+		var err error
+		before, err = r.listWorkers(ctx, node)
+		if err != nil {
+			addClientError(diags, "list workers", err)
+			return
+		}
+	}
+
+	tflog.Debug(ctx, "Launching repair operation", map[string]interface{}{
+		"node":          node,
+		"repair_type":   data.RepairType.ValueString(),
+		"scrub_command": data.ScrubCommand.ValueString(),
+	})
+
+	result, err := r.client.LaunchRepairOperation(ctx, node, client.LaunchRepairOperationRequest{
+		RepairType:   repairTypes[data.RepairType.ValueString()],
+		ScrubCommand: data.ScrubCommand.ValueString(),
+	})
+	if err != nil {
+		addClientError(diags, "launch repair operation", err)
+		return
+	}
+
+	for nodeID, message := range result.Error {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to launch the %s repair on node %s, got error: %s", data.RepairType.ValueString(), nodeID, message))
+	}
+	if diags.HasError() {
+		return
+	}
+
+	launched := make([]string, 0, len(result.Success))
+	for nodeID := range result.Success {
+		launched = append(launched, nodeID)
+	}
+	sort.Strings(launched)
+
+	launchedNodes, d := types.ListValueFrom(ctx, types.StringType, launched)
+	diags.Append(d...)
+	data.LaunchedNodes = launchedNodes
+
+	if before != nil {
+		if err := r.waitForRepair(ctx, node, before); err != nil {
+			diags.AddError("Repair Not Completed", fmt.Sprintf("The %s repair was launched but did not complete: %s", data.RepairType.ValueString(), err))
+		}
+	}
+}
+
+// listWorkers lists the workers of the node, failing if a node did not answer.
+func (r *RepairResource) listWorkers(ctx context.Context, node string) (*client.ListWorkersResponse, error) {
+	result, err := r.client.ListWorkers(ctx, node, client.ListWorkersRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Error) > 0 {
+		nodeIDs := make([]string, 0, len(result.Error))
+		for nodeID := range result.Error {
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+		sort.Strings(nodeIDs)
+		return nil, fmt.Errorf("unable to list the workers of node %s: %s", nodeIDs[0], result.Error[nodeIDs[0]])
+	}
+
+	return result, nil
+}
+
+// waitForRepair polls the workers of the node until those started since
+// before have finished, logging their progress.
+func (r *RepairResource) waitForRepair(ctx context.Context, node string, before *client.ListWorkersResponse) error {
+	ticker := time.NewTicker(repairPollInterval)
+	defer ticker.Stop()
+
+	for {
+		after, err := r.listWorkers(ctx, node)
+		if err != nil {
+			return err
+		}
+
+		running := runningRepairWorkers(before, after)
+		if len(running) == 0 {
+			return nil
+		}
+
+		for nodeID, workers := range running {
+			for _, worker := range workers {
+				fields := map[string]interface{}{
+					"node_id": nodeID,
+					"worker":  worker.Name,
+					"state":   worker.State.Name,
+				}
+				if worker.Progress != nil {
+					fields["progress"] = *worker.Progress
+				}
+				if worker.QueueLength != nil {
+					fields["queue_length"] = *worker.QueueLength
+				}
+				tflog.Info(ctx, "Waiting for repair to complete", fields)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for the repair workers to finish: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// runningRepairWorkers returns the workers of each node that were not listed
+// before the repair was launched and are still busy or throttled.
+func runningRepairWorkers(before, after *client.ListWorkersResponse) map[string][]client.WorkerInfo {
+	running := map[string][]client.WorkerInfo{}
+	for nodeID, workers := range after.Success {
+		known := map[int64]bool{}
+		for _, worker := range before.Success[nodeID] {
+			known[worker.ID] = true
+		}
+
+		for _, worker := range workers {
+			if known[worker.ID] || (worker.State.Name != "busy" && worker.State.Name != "throttled") {
+				continue
+			}
+			running[nodeID] = append(running[nodeID], worker)
+		}
+	}
+	return running
+}
@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"terraform-provider-garage/internal/client"
+)
+
+func TestAccRepairResource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Launch a table repair on every node
+			{
+				Config: testAccRepairResourceConfig("1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_repair.test", "id", "*"),
+					resource.TestCheckResourceAttr("garage_repair.test", "node", "*"),
+					resource.TestCheckResourceAttr("garage_repair.test", "repair_type", "tables"),
+					resource.TestCheckResourceAttrSet("garage_repair.test", "launched_nodes.0"),
+				),
+			},
+			// Changing the triggers launches the repair again
+			{
+				Config: testAccRepairResourceConfig("2"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_repair.test", "triggers.run", "2"),
+					resource.TestCheckResourceAttrSet("garage_repair.test", "launched_nodes.0"),
+				),
+			},
+			{
+				Config:      testAccRepairResourceConfig_scrubWithoutCommand,
+				ExpectError: regexp.MustCompile("Missing Scrub Command"),
+			},
+		},
+	})
+}
+
+func TestAccRepairResource_waitForCompletion(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRepairResourceConfig_wait,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_repair.test", "repair_type", "versions"),
+					resource.TestCheckResourceAttr("garage_repair.test", "wait_for_completion", "true"),
+					resource.TestCheckResourceAttrSet("garage_repair.test", "launched_nodes.0"),
+				),
+			},
+		},
+	})
+}
+
+func TestRunningRepairWorkers(t *testing.T) {
+	before := &client.ListWorkersResponse{Success: map[string][]client.WorkerInfo{
+		"node-1": {{ID: 1, Name: "Block resync worker #1", State: client.WorkerState{Name: "busy"}}},
+	}}
+	after := &client.ListWorkersResponse{Success: map[string][]client.WorkerInfo{
+		"node-1": {
+			{ID: 1, Name: "Block resync worker #1", State: client.WorkerState{Name: "busy"}},
+			{ID: 7, Name: "Version repair worker", State: client.WorkerState{Name: "busy"}},
+			{ID: 8, Name: "Block refcount repair worker", State: client.WorkerState{Name: "done"}},
+		},
+		"node-2": {
+			{ID: 3, Name: "Version repair worker", State: client.WorkerState{Name: "throttled"}},
+			{ID: 4, Name: "Block repair worker", State: client.WorkerState{Name: "idle"}},
+		},
+	}}
+
+	running := runningRepairWorkers(before, after)
+
+	if len(running["node-1"]) != 1 || running["node-1"][0].ID != 7 {
+		t.Errorf("Expected worker 7 to be running on node-1, got %+v", running["node-1"])
+	}
+	if len(running["node-2"]) != 1 || running["node-2"][0].ID != 3 {
+		t.Errorf("Expected worker 3 to be running on node-2, got %+v", running["node-2"])
+	}
+
+	// Once the new workers are done, the repair is complete
+	for nodeID, workers := range after.Success {
+		for i := range workers {
+			after.Success[nodeID][i].State = client.WorkerState{Name: "done"}
+		}
+	}
+	if running := runningRepairWorkers(before, after); len(running) != 0 {
+		t.Errorf("Expected no running repair worker, got %+v", running)
+	}
+}
+
+func testAccRepairResourceConfig(run string) string {
+	return fmt.Sprintf(`
+resource "garage_repair" "test" {
+  repair_type = "tables"
+
+  triggers = {
+    run = %[1]q
+  }
+}
+`, run)
+}
+
+const testAccRepairResourceConfig_scrubWithoutCommand = `
+resource "garage_repair" "test" {
+  repair_type = "scrub"
+}
+`
+
+const testAccRepairResourceConfig_wait = `
+resource "garage_repair" "test" {
+  repair_type         = "versions"
+  wait_for_completion = true
+
+  timeouts = {
+    create = "5m"
+  }
+}
+`
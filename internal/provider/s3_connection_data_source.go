@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &S3ConnectionDataSource{}
+
+func NewS3ConnectionDataSource() datasource.DataSource {
+	return &S3ConnectionDataSource{}
+}
+
+// S3ConnectionDataSource defines the data source implementation.
+type S3ConnectionDataSource struct {
+	client *client.Client
+}
+
+// S3ConnectionDataSourceModel describes the data source data model.
+type S3ConnectionDataSourceModel struct {
+	Bucket         types.String `tfsdk:"bucket"`
+	AccessKeyID    types.String `tfsdk:"access_key_id"`
+	BucketID       types.String `tfsdk:"bucket_id"`
+	Endpoint       types.String `tfsdk:"endpoint"`
+	Region         types.String `tfsdk:"region"`
+	ForcePathStyle types.Bool   `tfsdk:"force_path_style"`
+}
+
+func (d *S3ConnectionDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_s3_connection"
+}
+
+func (d *S3ConnectionDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Assembles the settings an S3 client needs to reach a Garage bucket (endpoint, region, bucket and access key ID), " +
+			"ready to pass to application configuration or to the `s3` backend of another Terraform configuration. Requires the provider `s3_endpoint` to be set.",
+
+		Attributes: map[string]schema.Attribute{
+			"bucket": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The global alias of the bucket. The bucket must exist.",
+			},
+			"access_key_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The access key the client will use. When set, it must have access to the bucket.",
+			},
+			"bucket_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the bucket.",
+			},
+			"endpoint": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The S3 API endpoint URL.",
+			},
+			"region": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The S3 region.",
+			},
+			"force_path_style": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether clients should use path-style addressing. Always `true`, since virtual-host addressing depends on the Garage `root_domain` setting.",
+			},
+		},
+	}
+}
+
+func (d *S3ConnectionDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *S3ConnectionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data S3ConnectionDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !d.client.HasS3Endpoint() {
+		resp.Diagnostics.AddError(
+			"Missing S3 Endpoint",
+			"The garage_s3_connection data source requires the provider s3_endpoint (or GARAGE_S3_ENDPOINT) to be configured.",
+		)
+		return
+	}
+
+	alias := data.Bucket.ValueString()
+	bucket, err := d.client.GetBucketInfo(ctx, client.GetBucketInfoRequest{GlobalAlias: &alias})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read bucket", err)
+		return
+	}
+
+	if bucket == nil {
+		resp.Diagnostics.AddError(
+			"Bucket Not Found",
+			fmt.Sprintf("No bucket has the global alias %q.", alias),
+		)
+		return
+	}
+
+	if !data.AccessKeyID.IsNull() && !bucketHasKey(bucket, data.AccessKeyID.ValueString()) {
+		resp.Diagnostics.AddError(
+			"Access Key Not Allowed",
+			fmt.Sprintf("The access key %s has no permissions on bucket %q.", data.AccessKeyID.ValueString(), alias),
+		)
+		return
+	}
+
+	data.BucketID = types.StringValue(bucket.ID)
+	data.Endpoint = types.StringValue(d.client.S3Endpoint())
+	data.Region = types.StringValue(d.client.S3Region())
+	data.ForcePathStyle = types.BoolValue(true)
+
+	tflog.Trace(ctx, "Read S3 connection data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// bucketHasKey reports whether the access key has any permission on the bucket.
+func bucketHasKey(bucket *client.Bucket, accessKeyID string) bool {
+	for _, key := range bucket.Keys {
+		if key.AccessKeyID == accessKeyID {
+			return key.Permissions.Read || key.Permissions.Write || key.Permissions.Owner
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccS3ConnectionDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckS3(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccS3ConnectionDataSourceConfig("test-bucket-s3-connection"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.garage_s3_connection.test", "bucket", "test-bucket-s3-connection"),
+					resource.TestCheckResourceAttrPair(
+						"data.garage_s3_connection.test", "bucket_id",
+						"garage_bucket.test", "id",
+					),
+					resource.TestCheckResourceAttrPair(
+						"data.garage_s3_connection.test", "access_key_id",
+						"garage_key.test", "id",
+					),
+					resource.TestCheckResourceAttr("data.garage_s3_connection.test", "endpoint", os.Getenv("GARAGE_S3_ENDPOINT")),
+					resource.TestCheckResourceAttrSet("data.garage_s3_connection.test", "region"),
+					resource.TestCheckResourceAttr("data.garage_s3_connection.test", "force_path_style", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccS3ConnectionDataSourceConfig(bucketName string) string {
+	return fmt.Sprintf(`
+resource "garage_bucket" "test" {
+  global_alias = %[1]q
+}
+
+resource "garage_key" "test" {
+  name = "%[1]s-key"
+}
+
+resource "garage_bucket_permission" "test" {
+  bucket_id     = garage_bucket.test.id
+  access_key_id = garage_key.test.id
+  read          = true
+}
+
+data "garage_s3_connection" "test" {
+  bucket        = garage_bucket.test.global_alias
+  access_key_id = garage_bucket_permission.test.access_key_id
+}
+`, bucketName)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// withTemporaryBucketKey creates a short-lived access key with the given
+// permissions on a bucket, runs fn with an S3 client authenticated as that key,
+// and deletes the key afterwards. It lets the provider touch objects without
+// requiring users to hand it S3 credentials.
+func withTemporaryBucketKey(ctx context.Context, c *client.Client, bucketID string, perms client.Permissions, fn func(*client.S3Client) error) (err error) {
+	if !c.HasS3Endpoint() {
+		return errors.New("the provider s3_endpoint (or GARAGE_S3_ENDPOINT) must be configured to access objects")
+	}
+
+	name := c.KeyNamePrefix() + "terraform-temporary-" + bucketID
+	key, err := c.CreateKey(ctx, client.CreateKeyRequest{Name: &name})
+	if err != nil {
+		return fmt.Errorf("unable to create temporary access key: %w", err)
+	}
+
+	defer func() {
+		deleteErr := c.DeleteKey(ctx, client.DeleteKeyRequest{ID: key.AccessKeyID})
+		if deleteErr != nil && err == nil {
+			err = fmt.Errorf("unable to delete temporary access key %s: %w", key.AccessKeyID, deleteErr)
+		}
+	}()
+
+	if key.SecretAccessKey == nil {
+		return fmt.Errorf("garage did not return a secret for temporary access key %s", key.AccessKeyID)
+	}
+
+	tflog.Debug(ctx, "Created temporary access key", map[string]interface{}{
+		"bucket_id":     bucketID,
+		"access_key_id": key.AccessKeyID,
+	})
+
+	_, err = c.AllowBucketKey(ctx, client.BucketKeyPermRequest{
+		BucketID:    bucketID,
+		AccessKeyID: key.AccessKeyID,
+		Permissions: perms,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to grant temporary access key permissions: %w", err)
+	}
+
+	s3, err := c.NewS3Client(key.AccessKeyID, *key.SecretAccessKey)
+	if err != nil {
+		return err
+	}
+
+	return fn(s3)
+}
+
+// bucketS3Name returns the global alias the S3 API knows the bucket by.
+func bucketS3Name(ctx context.Context, c *client.Client, bucketID string) (string, error) {
+	bucket, err := c.GetBucketInfo(ctx, client.GetBucketInfoRequest{ID: &bucketID})
+	if err != nil {
+		return "", err
+	}
+	if bucket == nil {
+		return "", fmt.Errorf("bucket %s does not exist", bucketID)
+	}
+	if len(bucket.GlobalAliases) == 0 {
+		return "", fmt.Errorf("bucket %s has no global alias, so its objects cannot be reached through the S3 API", bucketID)
+	}
+	return bucket.GlobalAliases[0], nil
+}
@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &SplitImportIDFunction{}
+
+// splitImportIDAttributeTypes are the attributes of the split_import_id result.
+var splitImportIDAttributeTypes = map[string]attr.Type{
+	"bucket_id":     types.StringType,
+	"access_key_id": types.StringType,
+}
+
+func NewSplitImportIDFunction() function.Function {
+	return &SplitImportIDFunction{}
+}
+
+// SplitImportIDFunction defines the function implementation.
+type SplitImportIDFunction struct{}
+
+func (f *SplitImportIDFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "split_import_id"
+}
+
+func (f *SplitImportIDFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Splits a bucket_id/access_key_id composite ID",
+		MarkdownDescription: "Parses a composite ID in the `bucket_id/access_key_id` format, such as the `id` of a `garage_bucket_permission` resource or its import ID, " +
+			"and returns an object with `bucket_id` and `access_key_id`.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "id",
+				MarkdownDescription: "The composite ID to split.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: splitImportIDAttributeTypes,
+		},
+	}
+}
+
+func (f *SplitImportIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &id))
+	if resp.Error != nil {
+		return
+	}
+
+	bucketID, accessKeyID, ok := parseImportID(id)
+	if !ok || bucketID == "" || accessKeyID == "" {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Expected an ID in the format bucket_id/access_key_id, got: %s", id))
+		return
+	}
+
+	result, diags := types.ObjectValue(splitImportIDAttributeTypes, map[string]attr.Value{
+		"bucket_id":     types.StringValue(bucketID),
+		"access_key_id": types.StringValue(accessKeyID),
+	})
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSplitImportIDFunction(t *testing.T) {
+	tests := []struct {
+		name            string
+		id              string
+		wantBucketID    string
+		wantAccessKeyID string
+		wantError       bool
+	}{
+		{
+			name:            "bucket permission",
+			id:              "e6a14cd6a27f48684579ec6b381c078ab11697e6bc8513b72b2f5307e25fff9b/GK31c2f218a2e44f485b94239e",
+			wantBucketID:    "e6a14cd6a27f48684579ec6b381c078ab11697e6bc8513b72b2f5307e25fff9b",
+			wantAccessKeyID: "GK31c2f218a2e44f485b94239e",
+		},
+		{name: "no separator", id: "e6a14cd6a27f4868", wantError: true},
+		{name: "missing bucket", id: "/GK31c2f218a2e44f485b94239e", wantError: true},
+		{name: "missing key", id: "e6a14cd6a27f4868/", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(tt.id)}),
+			}
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.ObjectUnknown(splitImportIDAttributeTypes)),
+			}
+
+			NewSplitImportIDFunction().Run(context.Background(), req, resp)
+
+			if (resp.Error != nil) != tt.wantError {
+				t.Fatalf("Expected error %v, got %v", tt.wantError, resp.Error)
+			}
+			if tt.wantError {
+				return
+			}
+
+			result := resp.Result.Value().(types.Object).Attributes()
+			if !result["bucket_id"].Equal(types.StringValue(tt.wantBucketID)) {
+				t.Errorf("Expected bucket_id %s, got %s", tt.wantBucketID, result["bucket_id"])
+			}
+			if !result["access_key_id"].Equal(types.StringValue(tt.wantAccessKeyID)) {
+				t.Errorf("Expected access_key_id %s, got %s", tt.wantAccessKeyID, result["access_key_id"])
+			}
+		})
+	}
+}
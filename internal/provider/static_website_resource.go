@@ -0,0 +1,470 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &StaticWebsiteResource{}
+var _ resource.ResourceWithModifyPlan = &StaticWebsiteResource{}
+
+func NewStaticWebsiteResource() resource.Resource {
+	return &StaticWebsiteResource{}
+}
+
+// StaticWebsiteResource defines the resource implementation.
+type StaticWebsiteResource struct {
+	client *client.Client
+}
+
+// StaticWebsiteResourceModel describes the resource data model.
+type StaticWebsiteResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Domain          types.String `tfsdk:"domain"`
+	CustomDomains   types.Set    `tfsdk:"custom_domains"`
+	VerifyDomains   types.Bool   `tfsdk:"verify_domains"`
+	IndexDocument   types.String `tfsdk:"index_document"`
+	ErrorDocument   types.String `tfsdk:"error_document"`
+	BucketID        types.String `tfsdk:"bucket_id"`
+	AccessKeyID     types.String `tfsdk:"access_key_id"`
+	SecretAccessKey types.String `tfsdk:"secret_access_key"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (r *StaticWebsiteResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_static_website"
+}
+
+func (r *StaticWebsiteResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Hosts a static website on Garage. Creates a bucket whose global alias matches the domain, " +
+			"enables website hosting on it, and creates a publish key with read and write access to the bucket.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier of the website (same as `bucket_id`).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "The domain the website is served on (e.g., 'www.example.com'). Garage serves websites by matching the request host against bucket global aliases, so this is used as the bucket's global alias and, after the provider's `key_name_prefix`, as the publish key's name. " +
+					"The global alias does not get the provider's `bucket_alias_prefix`, as the website would no longer match its domain.",
+				Required: true,
+				Validators: []validator.String{
+					bucketAlias(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"custom_domains": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Additional domains the website is served on (e.g., 'example.com'). Each domain is added as a global alias of the bucket, since Garage serves websites by alias.",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(bucketAlias()),
+				},
+			},
+			"verify_domains": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Check with Garage that `domain` and every custom domain are served once the website is configured, and fail the apply otherwise. Defaults to `false`.",
+			},
+			"index_document": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("index.html"),
+				MarkdownDescription: "The index document for the website. Defaults to 'index.html'.",
+			},
+			"error_document": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The error document for the website (e.g., 'error.html').",
+			},
+			"bucket_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the bucket holding the website content.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"access_key_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the publish key, which has read and write access to the bucket.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"secret_access_key": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The secret of the publish key.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeoutsBlock(ctx),
+		},
+	}
+}
+
+func (r *StaticWebsiteResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *StaticWebsiteResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	scopes := resourceScopes{
+		Create: []string{"CreateBucket", "GetBucketInfo", "UpdateBucket", "CreateKey", "AllowBucketKey"},
+		Update: []string{"UpdateBucket"},
+		Delete: []string{"DeleteKey", "DeleteBucket"},
+	}
+
+	var planned, current []string
+	if !req.Plan.Raw.IsNull() {
+		var data StaticWebsiteResourceModel
+		resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+		planned = r.customDomains(ctx, data, &resp.Diagnostics)
+
+		if slices.Contains(planned, data.Domain.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("custom_domains"),
+				"Invalid Custom Domain",
+				fmt.Sprintf("%s is already the domain of the website and cannot also be a custom domain.", data.Domain.ValueString()),
+			)
+		}
+	}
+	if !req.State.Raw.IsNull() {
+		var data StaticWebsiteResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+		current = r.customDomains(ctx, data, &resp.Diagnostics)
+	}
+
+	// Custom domains are global aliases of the bucket
+	if len(planned) > 0 || len(current) > 0 {
+		scopes.Create = append(scopes.Create, "AddBucketAlias")
+		scopes.Update = append(scopes.Update, "AddBucketAlias", "RemoveBucketAlias")
+	}
+
+	validateTokenScope(ctx, r.client, scopes, req, resp)
+}
+
+func (r *StaticWebsiteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data StaticWebsiteResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Create, defaultCreateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	domain := data.Domain.ValueString()
+
+	tflog.Debug(ctx, "Creating static website", map[string]interface{}{
+		"domain": domain,
+	})
+
+	bucket, err := r.client.CreateBucket(ctx, client.CreateBucketRequest{
+		GlobalAlias: &domain,
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "create website bucket", err)
+		return
+	}
+
+	data.ID = types.StringValue(bucket.ID)
+	data.BucketID = types.StringValue(bucket.ID)
+	data.AccessKeyID = types.StringNull()
+	data.SecretAccessKey = types.StringNull()
+
+	// Save the bucket right away so it is tracked even if a later step fails.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if err := waitForBucket(ctx, r.client, bucket.ID, domain); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Bucket %s was created but did not become visible, got error: %s", bucket.ID, err))
+		return
+	}
+
+	_, err = r.client.UpdateBucket(ctx, bucket.ID, client.UpdateBucketRequest{
+		WebsiteAccess: r.websiteAccess(data),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "enable website hosting", err)
+		return
+	}
+
+	if err := updateGlobalAliases(ctx, r.client, bucket.ID, nil, r.customDomains(ctx, data, &resp.Diagnostics)); err != nil {
+		addClientError(&resp.Diagnostics, "add custom domain", err)
+		return
+	}
+
+	// The bucket alias must stay the domain for Garage to route requests to
+	// the website, but the key is named like every key the provider creates
+	keyName := r.client.KeyNamePrefix() + domain
+	key, err := r.client.CreateKey(ctx, client.CreateKeyRequest{
+		Name: &keyName,
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "create publish key", err)
+		return
+	}
+
+	data.AccessKeyID = types.StringValue(key.AccessKeyID)
+	if key.SecretAccessKey != nil {
+		data.SecretAccessKey = types.StringValue(*key.SecretAccessKey)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	_, err = r.client.AllowBucketKey(ctx, client.BucketKeyPermRequest{
+		BucketID:    bucket.ID,
+		AccessKeyID: key.AccessKeyID,
+		Permissions: client.Permissions{
+			Read:  true,
+			Write: true,
+		},
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "grant publish key access to the bucket", err)
+		return
+	}
+
+	if data.VerifyDomains.ValueBool() {
+		resp.Diagnostics.Append(r.verifyDomains(ctx, data)...)
+	}
+
+	tflog.Trace(ctx, "Created static website resource")
+}
+
+func (r *StaticWebsiteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data StaticWebsiteResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Read, defaultReadTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	bucketID := data.BucketID.ValueString()
+	bucket, err := r.client.GetBucketInfo(ctx, client.GetBucketInfoRequest{
+		ID: &bucketID,
+	})
+
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read website bucket", err)
+		return
+	}
+
+	if bucket == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if bucket.WebsiteConfig != nil {
+		data.IndexDocument = types.StringValue(bucket.WebsiteConfig.IndexDocument)
+		if bucket.WebsiteConfig.ErrorDocument != "" {
+			data.ErrorDocument = types.StringValue(bucket.WebsiteConfig.ErrorDocument)
+		} else {
+			data.ErrorDocument = types.StringNull()
+		}
+	}
+
+	// Only track the custom domains managed here, dropping those removed
+	// outside Terraform so they are added back
+	if !data.CustomDomains.IsNull() {
+		var customDomains []string
+		for _, domain := range r.customDomains(ctx, data, &resp.Diagnostics) {
+			if slices.Contains(bucket.GlobalAliases, domain) {
+				customDomains = append(customDomains, domain)
+			}
+		}
+
+		value, diags := types.SetValueFrom(ctx, types.StringType, customDomains)
+		resp.Diagnostics.Append(diags...)
+		data.CustomDomains = value
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *StaticWebsiteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state StaticWebsiteResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Update, defaultUpdateTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	_, err := r.client.UpdateBucket(ctx, data.BucketID.ValueString(), client.UpdateBucketRequest{
+		WebsiteAccess: r.websiteAccess(data),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "update website configuration", err)
+		return
+	}
+
+	previous := r.customDomains(ctx, state, &resp.Diagnostics)
+	if err := updateGlobalAliases(ctx, r.client, data.BucketID.ValueString(), previous, r.customDomains(ctx, data, &resp.Diagnostics)); err != nil {
+		addClientError(&resp.Diagnostics, "update custom domains", err)
+		return
+	}
+
+	tflog.Trace(ctx, "Updated static website resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	if data.VerifyDomains.ValueBool() {
+		resp.Diagnostics.Append(r.verifyDomains(ctx, data)...)
+	}
+}
+
+func (r *StaticWebsiteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data StaticWebsiteResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := withTimeout(ctx, data.Timeouts.Delete, defaultDeleteTimeout, &resp.Diagnostics)
+	defer cancel()
+
+	tflog.Debug(ctx, "Deleting static website", map[string]interface{}{
+		"bucket_id":     data.BucketID.ValueString(),
+		"access_key_id": data.AccessKeyID.ValueString(),
+	})
+
+	// Keep the publish key if the bucket cannot be deleted
+	resp.Diagnostics.Append(checkBucketEmpty(ctx, r.client, data.BucketID.ValueString(), "Delete the objects and abort the uploads, then destroy the website again.")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.AccessKeyID.IsNull() {
+		err := r.client.DeleteKey(ctx, client.DeleteKeyRequest{
+			ID: data.AccessKeyID.ValueString(),
+		})
+		if err != nil {
+			addClientError(&resp.Diagnostics, "delete publish key", err)
+			return
+		}
+	}
+
+	err := r.client.DeleteBucket(ctx, client.DeleteBucketRequest{
+		ID: data.BucketID.ValueString(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "delete website bucket", err)
+		return
+	}
+
+	tflog.Trace(ctx, "Deleted static website resource")
+}
+
+// websiteAccess builds the website settings for the bucket from the model.
+func (r *StaticWebsiteResource) websiteAccess(data StaticWebsiteResourceModel) *client.UpdateBucketWebsiteAccess {
+	indexDoc := data.IndexDocument.ValueString()
+	websiteAccess := &client.UpdateBucketWebsiteAccess{
+		Enabled:       true,
+		IndexDocument: &indexDoc,
+	}
+
+	if !data.ErrorDocument.IsNull() {
+		errorDoc := data.ErrorDocument.ValueString()
+		websiteAccess.ErrorDocument = &errorDoc
+	}
+
+	return websiteAccess
+}
+
+// customDomains returns the custom domains of the model.
+func (r *StaticWebsiteResource) customDomains(ctx context.Context, data StaticWebsiteResourceModel, diags *diag.Diagnostics) []string {
+	var domains []string
+	if !data.CustomDomains.IsNull() && !data.CustomDomains.IsUnknown() {
+		diags.Append(data.CustomDomains.ElementsAs(ctx, &domains, false)...)
+	}
+	return domains
+}
+
+// verifyDomains checks with Garage that the domain and every custom domain of
+// the website are served.
+func (r *StaticWebsiteResource) verifyDomains(ctx context.Context, data StaticWebsiteResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	domains := append([]string{data.Domain.ValueString()}, r.customDomains(ctx, data, &diags)...)
+
+	var notServed []string
+	for _, domain := range domains {
+		served, err := r.client.CheckDomain(ctx, domain)
+		if err != nil {
+			addClientError(&diags, "check domain "+domain, err)
+			return diags
+		}
+		if !served {
+			notServed = append(notServed, domain)
+		}
+	}
+
+	if len(notServed) > 0 {
+		diags.AddError(
+			"Domain Not Served",
+			fmt.Sprintf("Garage does not serve the website on: %s. Check that the web endpoint of the cluster is configured.", strings.Join(notServed, ", ")),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,115 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccStaticWebsiteResource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccStaticWebsiteResourceConfig_basic("test-site.example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_static_website.test", "domain", "test-site.example.com"),
+					resource.TestCheckResourceAttr("garage_static_website.test", "index_document", "index.html"),
+					resource.TestCheckResourceAttrSet("garage_static_website.test", "bucket_id"),
+					resource.TestCheckResourceAttrSet("garage_static_website.test", "access_key_id"),
+					resource.TestCheckResourceAttrSet("garage_static_website.test", "secret_access_key"),
+					resource.TestCheckResourceAttr("data.garage_bucket.test", "website_enabled", "true"),
+				),
+			},
+			// Update website documents in place
+			{
+				Config: testAccStaticWebsiteResourceConfig_documents("test-site.example.com", "home.html", "404.html"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_static_website.test", "index_document", "home.html"),
+					resource.TestCheckResourceAttr("garage_static_website.test", "error_document", "404.html"),
+					resource.TestCheckResourceAttr("data.garage_bucket.test", "website_index_document", "home.html"),
+					resource.TestCheckResourceAttr("data.garage_bucket.test", "website_error_document", "404.html"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccStaticWebsiteResource_customDomains(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Serve the website on two more domains
+			{
+				Config: testAccStaticWebsiteResourceConfig_customDomains("test-site-domains.example.com", "test-site-domains.example.org", "test-site-domains.example.net"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_static_website.test", "custom_domains.#", "2"),
+					resource.TestCheckResourceAttr("garage_static_website.test", "verify_domains", "true"),
+					resource.TestCheckResourceAttr("data.garage_bucket.test", "global_aliases.#", "3"),
+				),
+			},
+			// Drop one of them
+			{
+				Config: testAccStaticWebsiteResourceConfig_customDomains("test-site-domains.example.com", "test-site-domains.example.org"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("garage_static_website.test", "custom_domains.#", "1"),
+					resource.TestCheckTypeSetElemAttr("garage_static_website.test", "custom_domains.*", "test-site-domains.example.org"),
+					resource.TestCheckResourceAttr("data.garage_bucket.test", "global_aliases.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+// Test configuration functions
+
+func testAccStaticWebsiteResourceConfig_basic(domain string) string {
+	return fmt.Sprintf(`
+resource "garage_static_website" "test" {
+  domain = %[1]q
+}
+
+data "garage_bucket" "test" {
+  id = garage_static_website.test.bucket_id
+}
+`, domain)
+}
+
+func testAccStaticWebsiteResourceConfig_documents(domain, indexDoc, errorDoc string) string {
+	return fmt.Sprintf(`
+resource "garage_static_website" "test" {
+  domain         = %[1]q
+  index_document = %[2]q
+  error_document = %[3]q
+}
+
+data "garage_bucket" "test" {
+  id = garage_static_website.test.bucket_id
+}
+`, domain, indexDoc, errorDoc)
+}
+
+func testAccStaticWebsiteResourceConfig_customDomains(domain string, customDomains ...string) string {
+	return fmt.Sprintf(`
+resource "garage_static_website" "test" {
+  domain         = %[1]q
+  custom_domains = ["%[2]s"]
+  verify_domains = true
+}
+
+data "garage_bucket" "test" {
+  id = garage_static_website.test.bucket_id
+
+  depends_on = [garage_static_website.test]
+}
+`, domain, strings.Join(customDomains, `", "`))
+}
@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// tlsSettings are the TLS settings of the provider, resolved from the
+// configuration and environment variables.
+type tlsSettings struct {
+	CACertPEM          string
+	CACertFile         string
+	ClientCert         string
+	ClientKey          string
+	InsecureSkipVerify bool
+}
+
+// tlsSettingsFrom resolves the TLS settings from the provider configuration,
+// falling back to the environment variables read with getenv.
+func tlsSettingsFrom(data GarageProviderModel, getenv func(string) string) (tlsSettings, error) {
+	setting := func(value types.String, env string) string {
+		if !value.IsNull() {
+			return value.ValueString()
+		}
+		return getenv(env)
+	}
+
+	settings := tlsSettings{
+		CACertPEM:          setting(data.CACertPEM, "GARAGE_CA_CERT_PEM"),
+		CACertFile:         setting(data.CACertFile, "GARAGE_CA_CERT_FILE"),
+		ClientCert:         setting(data.ClientCert, "GARAGE_CLIENT_CERT"),
+		ClientKey:          setting(data.ClientKey, "GARAGE_CLIENT_KEY"),
+		InsecureSkipVerify: data.InsecureSkipVerify.ValueBool(),
+	}
+
+	if v := getenv("GARAGE_INSECURE_SKIP_VERIFY"); data.InsecureSkipVerify.IsNull() && v != "" {
+		var err error
+		settings.InsecureSkipVerify, err = strconv.ParseBool(v)
+		if err != nil {
+			return settings, fmt.Errorf("unable to parse GARAGE_INSECURE_SKIP_VERIFY %q as a boolean: %w", v, err)
+		}
+	}
+
+	return settings, nil
+}
+
+// buildTLSConfig returns the TLS configuration for the settings, or nil when
+// none is set so that the system defaults apply.
+func buildTLSConfig(settings tlsSettings) (*tls.Config, error) {
+	if settings == (tlsSettings{}) {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: settings.InsecureSkipVerify,
+	}
+
+	if settings.CACertPEM != "" && settings.CACertFile != "" {
+		return nil, errors.New("only one of ca_cert_pem and ca_cert_file can be set")
+	}
+
+	caCertPEM := []byte(settings.CACertPEM)
+	if settings.CACertFile != "" {
+		var err error
+		caCertPEM, err = os.ReadFile(settings.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA certificate file: %w", err)
+		}
+	}
+
+	if len(caCertPEM) > 0 {
+		// Trust the CA in addition to the system roots
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(caCertPEM) {
+			return nil, errors.New("the CA certificate contains no valid PEM-encoded certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	switch {
+	case settings.ClientCert != "" && settings.ClientKey != "":
+		cert, err := tls.X509KeyPair([]byte(settings.ClientCert), []byte(settings.ClientKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	case settings.ClientCert != "" || settings.ClientKey != "":
+		return nil, errors.New("client_cert and client_key must be set together")
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testCertificate returns a self-signed PEM-encoded certificate and its key.
+func testCertificate(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "garage-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(certPEM), string(keyPEM)
+}
+
+func TestBuildTLSConfig(t *testing.T) {
+	certPEM, keyPEM := testCertificate(t)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caFile, []byte(certPEM), 0o600); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	t.Run("unset", func(t *testing.T) {
+		tlsConfig, err := buildTLSConfig(tlsSettings{})
+		if err != nil || tlsConfig != nil {
+			t.Errorf("Expected no TLS configuration, got %v, %v", tlsConfig, err)
+		}
+	})
+
+	t.Run("CA certificate", func(t *testing.T) {
+		for _, settings := range []tlsSettings{{CACertPEM: certPEM}, {CACertFile: caFile}} {
+			tlsConfig, err := buildTLSConfig(settings)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if tlsConfig.RootCAs == nil {
+				t.Error("Expected the CA certificate to be trusted")
+			}
+		}
+	})
+
+	t.Run("client certificate", func(t *testing.T) {
+		tlsConfig, err := buildTLSConfig(tlsSettings{ClientCert: certPEM, ClientKey: keyPEM, InsecureSkipVerify: true})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(tlsConfig.Certificates) != 1 || !tlsConfig.InsecureSkipVerify {
+			t.Errorf("Expected a client certificate and skipped verification, got %+v", tlsConfig)
+		}
+	})
+
+	invalid := map[string]tlsSettings{
+		"invalid CA":       {CACertPEM: "not a certificate"},
+		"missing CA file":  {CACertFile: filepath.Join(t.TempDir(), "missing.pem")},
+		"both CA settings": {CACertPEM: certPEM, CACertFile: caFile},
+		"cert without key": {ClientCert: certPEM},
+		"mismatched key":   {ClientCert: certPEM, ClientKey: "not a key"},
+	}
+	for name, settings := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if _, err := buildTLSConfig(settings); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestTLSSettingsFrom(t *testing.T) {
+	env := map[string]string{
+		"GARAGE_CA_CERT_FILE":         "/etc/garage/ca.pem",
+		"GARAGE_INSECURE_SKIP_VERIFY": "true",
+	}
+	getenv := func(name string) string { return env[name] }
+
+	settings, err := tlsSettingsFrom(GarageProviderModel{InsecureSkipVerify: types.BoolValue(false)}, getenv)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if settings.CACertFile != "/etc/garage/ca.pem" || settings.InsecureSkipVerify {
+		t.Errorf("Expected the CA file from the environment and the configured verification, got %+v", settings)
+	}
+
+	env["GARAGE_INSECURE_SKIP_VERIFY"] = "maybe"
+	if _, err := tlsSettingsFrom(GarageProviderModel{}, getenv); err == nil {
+		t.Error("Expected an error for an invalid GARAGE_INSECURE_SKIP_VERIFY")
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// tokenScopeCache holds the scope of the admin token of each configured
+// client, so that it is fetched once per run rather than once per resource.
+var tokenScopeCache sync.Map
+
+// resourceScopes lists the Admin API endpoints a resource calls when it is
+// created, updated or deleted.
+type resourceScopes struct {
+	Create []string
+	Update []string
+	Delete []string
+}
+
+// validateTokenScope fails the plan when token scope validation is enabled and
+// the admin token is not allowed to call the endpoints the planned change needs.
+func validateTokenScope(ctx context.Context, c *client.Client, scopes resourceScopes, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// The client is not configured yet when provider settings are unknown
+	if c == nil || !c.ValidatesTokenScope() {
+		return
+	}
+
+	var operation string
+	var required []string
+	switch {
+	case req.Plan.Raw.IsNull():
+		operation, required = "delete", scopes.Delete
+	case req.State.Raw.IsNull():
+		operation, required = "create", scopes.Create
+	case req.Plan.Raw.Equal(req.State.Raw):
+		return
+	default:
+		operation, required = "update", scopes.Update
+	}
+
+	scope, err := currentTokenScope(ctx, c)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Validate Admin Token Scope",
+			fmt.Sprintf("Unable to read the scope of the admin token, got error: %s", err),
+		)
+		return
+	}
+
+	missing := missingScopes(scope, required)
+	if len(missing) > 0 {
+		resp.Diagnostics.AddError(
+			"Insufficient Admin Token Scope",
+			fmt.Sprintf("The admin token is not allowed to call the endpoints needed to %s this resource. Missing scopes: %s", operation, strings.Join(missing, ", ")),
+		)
+	}
+}
+
+// currentTokenScope returns the scope of the admin token used by the client.
+func currentTokenScope(ctx context.Context, c *client.Client) ([]string, error) {
+	if scope, ok := tokenScopeCache.Load(c); ok {
+		return scope.([]string), nil
+	}
+
+	info, err := c.GetCurrentAdminTokenInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tflog.Debug(ctx, "Read admin token scope", map[string]interface{}{
+		"name":  info.Name,
+		"scope": info.Scope,
+	})
+
+	tokenScopeCache.Store(c, info.Scope)
+
+	return info.Scope, nil
+}
+
+// missingScopes returns the required endpoints that are not granted by scope.
+func missingScopes(scope, required []string) []string {
+	granted := make(map[string]bool, len(scope))
+	for _, s := range scope {
+		if s == "*" {
+			return nil
+		}
+		granted[s] = true
+	}
+
+	var missing []string
+	for _, r := range required {
+		if !granted[r] {
+			missing = append(missing, r)
+		}
+	}
+
+	return missing
+}
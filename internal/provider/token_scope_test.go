@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMissingScopes(t *testing.T) {
+	tests := []struct {
+		name     string
+		scope    []string
+		required []string
+		want     []string
+	}{
+		{
+			name:     "all granted",
+			scope:    []string{"CreateBucket", "GetBucketInfo", "UpdateBucket"},
+			required: []string{"CreateBucket", "UpdateBucket"},
+		},
+		{
+			name:     "wildcard",
+			scope:    []string{"*"},
+			required: []string{"CreateBucket", "DeleteBucket"},
+		},
+		{
+			name:     "missing",
+			scope:    []string{"GetBucketInfo"},
+			required: []string{"CreateBucket", "GetBucketInfo", "UpdateBucket"},
+			want:     []string{"CreateBucket", "UpdateBucket"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := missingScopes(tt.scope, tt.required)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("missingScopes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
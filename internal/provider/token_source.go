@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// tokenCommandTimeout bounds how long token_command may run.
+const tokenCommandTimeout = 1 * time.Minute
+
+// tokenFrom resolves the admin token from the token, token_file or
+// token_command attribute, falling back to the GARAGE_TOKEN and then
+// GARAGE_TOKEN_FILE environment variables read with getenv. It returns an
+// empty token when none is set, so that profiles can provide one.
+func tokenFrom(ctx context.Context, data GarageProviderModel, getenv func(string) string) (string, error) {
+	switch {
+	case data.Token.ValueString() != "":
+		return data.Token.ValueString(), nil
+	case data.TokenFile.ValueString() != "":
+		return readTokenFile(data.TokenFile.ValueString())
+	case len(data.TokenCommand.Elements()) > 0:
+		var command []string
+		if diags := data.TokenCommand.ElementsAs(ctx, &command, false); diags.HasError() {
+			return "", errors.New("token_command must be a list of strings")
+		}
+		return runTokenCommand(ctx, command)
+	}
+
+	if token := getenv("GARAGE_TOKEN"); token != "" {
+		return token, nil
+	}
+
+	if tokenFile := getenv("GARAGE_TOKEN_FILE"); tokenFile != "" {
+		return readTokenFile(tokenFile)
+	}
+
+	return "", nil
+}
+
+// readTokenFile reads an admin token from a file, such as a mounted secret,
+// ignoring surrounding whitespace.
+func readTokenFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read token file: %w", err)
+	}
+
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", path)
+	}
+
+	return token, nil
+}
+
+// runTokenCommand runs a program with its arguments, without a shell, and
+// returns what it prints on its standard output as the admin token.
+func runTokenCommand(ctx context.Context, command []string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, tokenCommandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("token command %s failed: %w: %s", command[0], err, message)
+		}
+		return "", fmt.Errorf("token command %s failed: %w", command[0], err)
+	}
+
+	token := strings.TrimSpace(stdout.String())
+	if token == "" {
+		return "", fmt.Errorf("token command %s printed no token", command[0])
+	}
+
+	return token, nil
+}
@@ -0,0 +1,111 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTokenFrom(t *testing.T) {
+	dir := t.TempDir()
+
+	tokenFile := filepath.Join(dir, "token")
+	if err := os.WriteFile(tokenFile, []byte("file-token\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	emptyFile := filepath.Join(dir, "empty")
+	if err := os.WriteFile(emptyFile, []byte("\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	command := func(args ...string) types.List {
+		values, _ := types.ListValueFrom(context.Background(), types.StringType, args)
+		return values
+	}
+
+	tests := []struct {
+		name      string
+		data      GarageProviderModel
+		env       map[string]string
+		want      string
+		wantError string
+	}{
+		{name: "unset"},
+		{
+			name: "token",
+			data: GarageProviderModel{Token: types.StringValue("config-token")},
+			env:  map[string]string{"GARAGE_TOKEN": "env-token"},
+			want: "config-token",
+		},
+		{
+			name: "token file",
+			data: GarageProviderModel{TokenFile: types.StringValue(tokenFile)},
+			env:  map[string]string{"GARAGE_TOKEN": "env-token"},
+			want: "file-token",
+		},
+		{
+			name:      "missing token file",
+			data:      GarageProviderModel{TokenFile: types.StringValue(filepath.Join(dir, "missing"))},
+			wantError: "unable to read token file",
+		},
+		{
+			name:      "empty token file",
+			data:      GarageProviderModel{TokenFile: types.StringValue(emptyFile)},
+			wantError: "is empty",
+		},
+		{
+			name: "token command",
+			data: GarageProviderModel{TokenCommand: command("sh", "-c", "echo command-token")},
+			env:  map[string]string{"GARAGE_TOKEN": "env-token"},
+			want: "command-token",
+		},
+		{
+			name:      "failing token command",
+			data:      GarageProviderModel{TokenCommand: command("sh", "-c", "echo permission denied >&2; exit 2")},
+			wantError: "permission denied",
+		},
+		{
+			name:      "silent token command",
+			data:      GarageProviderModel{TokenCommand: command("true")},
+			wantError: "printed no token",
+		},
+		{
+			name: "environment",
+			env:  map[string]string{"GARAGE_TOKEN": "env-token", "GARAGE_TOKEN_FILE": tokenFile},
+			want: "env-token",
+		},
+		{
+			name: "environment token file",
+			env:  map[string]string{"GARAGE_TOKEN_FILE": tokenFile},
+			want: "file-token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(name string) string { return tt.env[name] }
+
+			got, err := tokenFrom(context.Background(), tt.data, getenv)
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+					t.Fatalf("Expected an error containing %q, got %v", tt.wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
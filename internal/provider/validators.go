@@ -0,0 +1,407 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure validators fully satisfy framework interfaces.
+var _ validator.String = timestampValidator{}
+var _ validator.String = bucketAliasValidator{}
+var _ validator.String = bucketAliasPrefixValidator{}
+var _ validator.Int64 = quotaValidator{}
+var _ validator.String = durationValidator{}
+var _ validator.String = nodeAddressValidator{}
+var _ validator.String = regularExpressionValidator{}
+
+// timestampValidator checks that a string is an RFC3339 timestamp. Whether
+// an expiration lies in the future is checked when planning, see
+// planFutureExpiration, as a validator would keep failing once it has passed.
+type timestampValidator struct{}
+
+// timestamp returns a validator for expiration attributes.
+func timestamp() validator.String {
+	return timestampValidator{}
+}
+
+func (v timestampValidator) Description(ctx context.Context) string {
+	return "value must be an RFC3339 timestamp"
+}
+
+func (v timestampValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timestampValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Timestamp",
+			fmt.Sprintf("Expected an RFC3339 timestamp such as 2030-01-01T00:00:00Z, got %q.", value),
+		)
+	}
+}
+
+// planFutureExpiration fails the plan when it sets an expiration that is not
+// in the future, so that credentials are not created or updated already
+// expired. An expiration that has passed since it was applied is left alone,
+// so that the resource can still be planned and destroyed.
+func planFutureExpiration(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var expiration, previous types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("expiration"), &expiration)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("expiration"), &previous)...)
+	}
+
+	if expirationInPast(expiration, previous, time.Now()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("expiration"),
+			"Timestamp In The Past",
+			fmt.Sprintf("The expiration %s is not in the future. Credentials with this expiration would be unusable as soon as they are applied.", expiration.ValueString()),
+		)
+	}
+}
+
+// expirationInPast reports whether a new or changed expiration is not after
+// now. An expiration equal to the previous one is not checked.
+func expirationInPast(expiration, previous types.String, now time.Time) bool {
+	if expiration.IsNull() || expiration.IsUnknown() {
+		return false
+	}
+	if !previous.IsNull() && sameInstant(expiration.ValueString(), previous.ValueString()) {
+		return false
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, expiration.ValueString())
+	if err != nil {
+		// Reported by the timestamp validator
+		return false
+	}
+	return !timestamp.After(now)
+}
+
+// bucketAliasValidator checks that a string is a bucket alias Garage accepts.
+// The rules mirror Garage's own bucket name check, so that invalid names fail
+// during plan instead of when the bucket is created.
+type bucketAliasValidator struct{}
+
+// bucketAlias returns a validator for global and local alias attributes.
+func bucketAlias() validator.String {
+	return bucketAliasValidator{}
+}
+
+func (v bucketAliasValidator) Description(ctx context.Context) string {
+	return "value must be 3 to 63 lowercase letters, digits, dots and hyphens, starting and ending with a letter or digit"
+}
+
+func (v bucketAliasValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v bucketAliasValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	if problem := bucketAliasProblem(value); problem != "" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Bucket Alias",
+			fmt.Sprintf("The alias %q is not a valid bucket name: %s.", value, problem),
+		)
+	}
+}
+
+// bucketAliasProblem describes why Garage would reject an alias, or returns
+// an empty string if the alias is valid.
+func bucketAliasProblem(alias string) string {
+	if len(alias) < 3 || len(alias) > 63 {
+		return fmt.Sprintf("it must be between 3 and 63 characters long, got %d", len(alias))
+	}
+
+	for _, c := range alias {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '.' || c == '-') {
+			return fmt.Sprintf("it must only contain lowercase letters, digits, dots and hyphens, got %q", c)
+		}
+	}
+
+	if strings.HasPrefix(alias, ".") || strings.HasPrefix(alias, "-") ||
+		strings.HasSuffix(alias, ".") || strings.HasSuffix(alias, "-") {
+		return "it must start and end with a letter or digit"
+	}
+
+	if net.ParseIP(alias) != nil {
+		return "it must not be formatted as an IP address"
+	}
+
+	if strings.HasPrefix(alias, "xn--") {
+		return `it must not start with "xn--"`
+	}
+
+	if strings.HasSuffix(alias, "-s3alias") {
+		return `it must not end with "-s3alias"`
+	}
+
+	return ""
+}
+
+// bucketAliasPrefixValidator checks that a string can start a bucket alias,
+// so that prefixed aliases are not rejected by Garage because of the prefix.
+// The length of a prefixed alias is checked when planning the bucket.
+type bucketAliasPrefixValidator struct{}
+
+// bucketAliasPrefix returns a validator for the bucket_alias_prefix attribute.
+func bucketAliasPrefix() validator.String {
+	return bucketAliasPrefixValidator{}
+}
+
+func (v bucketAliasPrefixValidator) Description(ctx context.Context) string {
+	return "value must be at most 60 lowercase letters, digits, dots and hyphens, starting with a letter or digit"
+}
+
+func (v bucketAliasPrefixValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v bucketAliasPrefixValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	if problem := bucketAliasPrefixProblem(value); problem != "" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Bucket Alias Prefix",
+			fmt.Sprintf("The prefix %q cannot start a valid bucket name: %s.", value, problem),
+		)
+	}
+}
+
+// bucketAliasPrefixProblem describes why Garage would reject every alias
+// starting with prefix, or returns an empty string if the prefix is valid.
+// An empty prefix is valid.
+func bucketAliasPrefixProblem(prefix string) string {
+	if prefix == "" {
+		return ""
+	}
+
+	// Leave room for the shortest alias
+	if len(prefix) > 60 {
+		return fmt.Sprintf("it must be at most 60 characters long, got %d", len(prefix))
+	}
+
+	for _, c := range prefix {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '.' || c == '-') {
+			return fmt.Sprintf("it must only contain lowercase letters, digits, dots and hyphens, got %q", c)
+		}
+	}
+
+	if strings.HasPrefix(prefix, ".") || strings.HasPrefix(prefix, "-") {
+		return "it must start with a letter or digit"
+	}
+
+	if strings.HasPrefix(prefix, "xn--") {
+		return `it must not start with "xn--"`
+	}
+
+	return ""
+}
+
+// Upper bounds of the quota validators. Larger values are almost certainly
+// unit mistakes rather than real limits.
+const (
+	maxQuotaSize    = int64(1) << 60 // 1 EiB
+	maxQuotaObjects = int64(1) << 40
+)
+
+// quotaValidator checks that a bucket quota is positive and below a sanity
+// bound. A zero quota would reject every write, and negative values fail to
+// decode as unsigned integers in Garage with an unhelpful error.
+type quotaValidator struct {
+	max  int64
+	unit string
+}
+
+// quota returns a validator for quota attributes, where unit names what the
+// quota counts (e.g., "bytes").
+func quota(max int64, unit string) validator.Int64 {
+	return quotaValidator{max: max, unit: unit}
+}
+
+func (v quotaValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be between 1 and %d %s", v.max, v.unit)
+}
+
+func (v quotaValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v quotaValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueInt64()
+
+	switch {
+	case value <= 0:
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Quota",
+			fmt.Sprintf("The quota must be at least 1 %s, got %d. To remove the quota, leave the attribute unset.", v.unit, value),
+		)
+	case value > v.max:
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Quota",
+			fmt.Sprintf("The quota of %d %s is larger than the maximum of %d %s. Check the unit of the value.", value, v.unit, v.max, v.unit),
+		)
+	}
+}
+
+// durationValidator checks that a string is a positive Go duration such as
+// "720h".
+type durationValidator struct{}
+
+// positiveDuration returns a validator for duration attributes.
+func positiveDuration() validator.String {
+	return durationValidator{}
+}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return "value must be a positive duration such as 720h"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("Expected a positive duration with a unit such as 720h or 90m, got %q.", value),
+		)
+	}
+}
+
+// nodeAddressValidator checks that a string is a node address of the form
+// <node ID>@<host>:<port>, as printed by `garage node id`.
+type nodeAddressValidator struct{}
+
+// nodeAddress returns a validator for node address attributes.
+func nodeAddress() validator.String {
+	return nodeAddressValidator{}
+}
+
+func (v nodeAddressValidator) Description(ctx context.Context) string {
+	return "value must be a node ID followed by @ and the host and port of its RPC address"
+}
+
+func (v nodeAddressValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v nodeAddressValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	if _, err := nodeAddressID(value); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Node Address",
+			fmt.Sprintf("The address %q is not a valid node address: %s. Use the output of `garage node id`.", value, err),
+		)
+	}
+}
+
+// nodeAddressID returns the node ID of a <node ID>@<host>:<port> address.
+func nodeAddressID(address string) (string, error) {
+	nodeID, hostPort, found := strings.Cut(address, "@")
+	if !found {
+		return "", fmt.Errorf("it must contain @ between the node ID and the host")
+	}
+
+	if len(nodeID) != 64 {
+		return "", fmt.Errorf("the node ID must be 64 hexadecimal characters, got %d characters", len(nodeID))
+	}
+	if _, err := hex.DecodeString(nodeID); err != nil {
+		return "", fmt.Errorf("the node ID must be 64 hexadecimal characters")
+	}
+
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil || host == "" || port == "" {
+		return "", fmt.Errorf("the host must be followed by a port, such as 10.0.0.1:3901")
+	}
+
+	return nodeID, nil
+}
+
+// regularExpressionValidator checks that a string is a regular expression in
+// the syntax of Go's regexp package.
+type regularExpressionValidator struct{}
+
+// regularExpression returns a validator for attributes holding a pattern.
+func regularExpression() validator.String {
+	return regularExpressionValidator{}
+}
+
+func (v regularExpressionValidator) Description(ctx context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+func (v regularExpressionValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regularExpressionValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Regular Expression",
+			fmt.Sprintf("The value %q is not a valid regular expression: %s.", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTimestampValidator(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     types.String
+		wantError bool
+	}{
+		{name: "future", value: types.StringValue(time.Now().Add(24 * time.Hour).Format(time.RFC3339))},
+		{name: "past", value: types.StringValue("2020-01-01T00:00:00Z")},
+		{name: "malformed", value: types.StringValue("2030-01-01"), wantError: true},
+		{name: "null", value: types.StringNull()},
+		{name: "unknown", value: types.StringUnknown()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("expiration"), ConfigValue: tt.value}
+			resp := &validator.StringResponse{}
+
+			timestamp().ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("Expected error %v, got %v", tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestExpirationInPast(t *testing.T) {
+	now := time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		expiration types.String
+		previous   types.String
+		want       bool
+	}{
+		{name: "future", expiration: types.StringValue("2030-07-01T00:00:00Z"), previous: types.StringNull()},
+		{name: "past", expiration: types.StringValue("2030-05-01T00:00:00Z"), previous: types.StringNull(), want: true},
+		{name: "changed to past", expiration: types.StringValue("2030-05-01T00:00:00Z"), previous: types.StringValue("2030-04-01T00:00:00Z"), want: true},
+		{name: "passed since applied", expiration: types.StringValue("2030-05-01T00:00:00Z"), previous: types.StringValue("2030-05-01T02:00:00+02:00")},
+		{name: "null", expiration: types.StringNull(), previous: types.StringValue("2030-05-01T00:00:00Z")},
+		{name: "unknown", expiration: types.StringUnknown(), previous: types.StringNull()},
+		{name: "malformed", expiration: types.StringValue("2030-05-01"), previous: types.StringNull()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := expirationInPast(tt.expiration, tt.previous, now); got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBucketAliasProblem(t *testing.T) {
+	tests := []struct {
+		alias string
+		valid bool
+	}{
+		{alias: "my-bucket", valid: true},
+		{alias: "www.example.com", valid: true},
+		{alias: "abc", valid: true},
+		{alias: "ab"},
+		{alias: strings.Repeat("a", 64)},
+		{alias: "My-Bucket"},
+		{alias: "my_bucket"},
+		{alias: "-bucket"},
+		{alias: "bucket."},
+		{alias: "192.168.1.1"},
+		{alias: "xn--bucket"},
+		{alias: "bucket-s3alias"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.alias, func(t *testing.T) {
+			problem := bucketAliasProblem(tt.alias)
+			if (problem == "") != tt.valid {
+				t.Errorf("Expected valid %v, got problem %q", tt.valid, problem)
+			}
+		})
+	}
+}
+
+func TestBucketAliasPrefixProblem(t *testing.T) {
+	tests := []struct {
+		prefix string
+		valid  bool
+	}{
+		{prefix: "", valid: true},
+		{prefix: "staging-", valid: true},
+		{prefix: "a", valid: true},
+		{prefix: strings.Repeat("a", 61)},
+		{prefix: "Staging-"},
+		{prefix: "staging_"},
+		{prefix: "-staging"},
+		{prefix: ".staging"},
+		{prefix: "xn--staging"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prefix, func(t *testing.T) {
+			problem := bucketAliasPrefixProblem(tt.prefix)
+			if (problem == "") != tt.valid {
+				t.Errorf("Expected valid %v, got problem %q", tt.valid, problem)
+			}
+		})
+	}
+}
+
+func TestQuotaValidator(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     types.Int64
+		wantError bool
+	}{
+		{name: "valid", value: types.Int64Value(1073741824)},
+		{name: "one", value: types.Int64Value(1)},
+		{name: "maximum", value: types.Int64Value(maxQuotaSize)},
+		{name: "zero", value: types.Int64Value(0), wantError: true},
+		{name: "negative", value: types.Int64Value(-1), wantError: true},
+		{name: "too large", value: types.Int64Value(maxQuotaSize + 1), wantError: true},
+		{name: "null", value: types.Int64Null()},
+		{name: "unknown", value: types.Int64Unknown()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.Int64Request{Path: path.Root("max_size"), ConfigValue: tt.value}
+			resp := &validator.Int64Response{}
+
+			quota(maxQuotaSize, "bytes").ValidateInt64(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("Expected error %v, got %v", tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestDurationValidator(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     types.String
+		wantError bool
+	}{
+		{name: "hours", value: types.StringValue("720h")},
+		{name: "mixed", value: types.StringValue("1h30m")},
+		{name: "no unit", value: types.StringValue("30"), wantError: true},
+		{name: "days", value: types.StringValue("30d"), wantError: true},
+		{name: "zero", value: types.StringValue("0s"), wantError: true},
+		{name: "negative", value: types.StringValue("-1h"), wantError: true},
+		{name: "null", value: types.StringNull()},
+		{name: "unknown", value: types.StringUnknown()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("rotate_after"), ConfigValue: tt.value}
+			resp := &validator.StringResponse{}
+
+			positiveDuration().ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("Expected error %v, got %v", tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestNodeAddressID(t *testing.T) {
+	nodeID := strings.Repeat("ab", 32)
+
+	tests := []struct {
+		name      string
+		address   string
+		wantError bool
+	}{
+		{name: "ipv4", address: nodeID + "@10.0.0.1:3901"},
+		{name: "ipv6", address: nodeID + "@[fd00::1]:3901"},
+		{name: "hostname", address: nodeID + "@garage-2.internal:3901"},
+		{name: "no node id", address: "10.0.0.1:3901", wantError: true},
+		{name: "short node id", address: "abcd@10.0.0.1:3901", wantError: true},
+		{name: "non hex node id", address: strings.Repeat("zz", 32) + "@10.0.0.1:3901", wantError: true},
+		{name: "no port", address: nodeID + "@10.0.0.1", wantError: true},
+		{name: "no host", address: nodeID + "@:3901", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := nodeAddressID(tt.address)
+			if (err != nil) != tt.wantError {
+				t.Fatalf("Expected error %v, got %v", tt.wantError, err)
+			}
+			if err == nil && got != nodeID {
+				t.Errorf("Expected node ID %s, got %s", nodeID, got)
+			}
+		})
+	}
+}
+
+func TestRegularExpressionValidator(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     types.String
+		wantError bool
+	}{
+		{name: "prefix", value: types.StringValue("^ci-")},
+		{name: "unbalanced", value: types.StringValue("ci-("), wantError: true},
+		{name: "null", value: types.StringNull()},
+		{name: "unknown", value: types.StringUnknown()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("name_regex"), ConfigValue: tt.value}
+			resp := &validator.StringResponse{}
+
+			regularExpression().ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("Expected error %v, got %v", tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
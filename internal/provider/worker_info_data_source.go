@@ -0,0 +1,199 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &WorkerInfoDataSource{}
+
+func NewWorkerInfoDataSource() datasource.DataSource {
+	return &WorkerInfoDataSource{}
+}
+
+// WorkerInfoDataSource defines the data source implementation.
+type WorkerInfoDataSource struct {
+	client *client.Client
+}
+
+// WorkerInfoDataSourceModel describes the data source data model.
+type WorkerInfoDataSourceModel struct {
+	Node              types.String `tfsdk:"node"`
+	WorkerID          types.Int64  `tfsdk:"worker_id"`
+	NodeID            types.String `tfsdk:"node_id"`
+	Name              types.String `tfsdk:"name"`
+	State             types.String `tfsdk:"state"`
+	Errors            types.Int64  `tfsdk:"errors"`
+	ConsecutiveErrors types.Int64  `tfsdk:"consecutive_errors"`
+	LastError         types.String `tfsdk:"last_error"`
+	LastErrorSecsAgo  types.Int64  `tfsdk:"last_error_secs_ago"`
+	Tranquility       types.Int64  `tfsdk:"tranquility"`
+	QueueLength       types.Int64  `tfsdk:"queue_length"`
+	PersistentErrors  types.Int64  `tfsdk:"persistent_errors"`
+	Progress          types.String `tfsdk:"progress"`
+}
+
+func (d *WorkerInfoDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_worker_info"
+}
+
+func (d *WorkerInfoDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the status of a single background worker (such as a block resync worker) on one node, for monitoring checks.",
+
+		Attributes: map[string]schema.Attribute{
+			"node": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The node running the worker: a node ID, or `self` for the node the provider talks to. Defaults to `self`.",
+			},
+			"worker_id": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the worker on the node, as shown by `garage worker list`.",
+			},
+			"node_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the node that reported the worker.",
+			},
+			"name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the worker.",
+			},
+			"state": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The state of the worker: `busy`, `throttled`, `idle` or `done`.",
+			},
+			"errors": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of errors the worker encountered since the node started.",
+			},
+			"consecutive_errors": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of errors since the last successful iteration.",
+			},
+			"last_error": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The message of the last error, or null if the worker never failed.",
+			},
+			"last_error_secs_ago": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "How many seconds ago the last error happened, or null if the worker never failed.",
+			},
+			"tranquility": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The tranquility of the worker, or null if it does not use one.",
+			},
+			"queue_length": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of items waiting in the queue of the worker, or null if it has no queue.",
+			},
+			"persistent_errors": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of items the worker keeps failing on, or null if it does not track them.",
+			},
+			"progress": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The progress reported by the worker, or null if it does not report any.",
+			},
+		},
+	}
+}
+
+func (d *WorkerInfoDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *WorkerInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data WorkerInfoDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Node.IsNull() {
+		data.Node = types.StringValue(client.LocalNode)
+	}
+
+	if data.Node.ValueString() == client.AllNodes {
+		resp.Diagnostics.AddError("Invalid Node", "A worker ID only identifies a worker on a single node, so node cannot be `*`.")
+		return
+	}
+
+	tflog.Debug(ctx, "Reading worker info", map[string]interface{}{
+		"node":      data.Node.ValueString(),
+		"worker_id": data.WorkerID.ValueInt64(),
+	})
+
+	result, err := d.client.GetWorkerInfo(ctx, data.Node.ValueString(), client.GetWorkerInfoRequest{
+		ID: data.WorkerID.ValueInt64(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read worker info", err)
+		return
+	}
+
+	for nodeID, message := range result.Error {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read worker %d on node %s, got error: %s", data.WorkerID.ValueInt64(), nodeID, message))
+		return
+	}
+
+	if len(result.Success) != 1 {
+		resp.Diagnostics.AddError(
+			"Unexpected Worker Info",
+			fmt.Sprintf("Expected information from exactly one node, got %d.", len(result.Success)),
+		)
+		return
+	}
+
+	for nodeID, info := range result.Success {
+		data.NodeID = types.StringValue(nodeID)
+		data.Name = types.StringValue(info.Name)
+		data.State = types.StringValue(info.State.Name)
+		data.Errors = types.Int64Value(info.Errors)
+		data.ConsecutiveErrors = types.Int64Value(info.ConsecutiveErrors)
+		data.Tranquility = types.Int64PointerValue(info.Tranquility)
+		data.QueueLength = types.Int64PointerValue(info.QueueLength)
+		data.PersistentErrors = types.Int64PointerValue(info.PersistentErrors)
+		data.Progress = types.StringPointerValue(info.Progress)
+
+		if info.LastError != nil {
+			data.LastError = types.StringValue(info.LastError.Message)
+			data.LastErrorSecsAgo = types.Int64Value(info.LastError.SecsAgo)
+		} else {
+			data.LastError = types.StringNull()
+			data.LastErrorSecsAgo = types.Int64Null()
+		}
+	}
+
+	tflog.Trace(ctx, "Read worker info data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,35 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccWorkerInfoDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWorkerInfoDataSourceConfig_basic,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.garage_worker_info.test", "node", "self"),
+					resource.TestCheckResourceAttr("data.garage_worker_info.test", "worker_id", "1"),
+					resource.TestCheckResourceAttrSet("data.garage_worker_info.test", "node_id"),
+					resource.TestCheckResourceAttrSet("data.garage_worker_info.test", "name"),
+					resource.TestCheckResourceAttrSet("data.garage_worker_info.test", "state"),
+				),
+			},
+		},
+	})
+}
+
+const testAccWorkerInfoDataSourceConfig_basic = `
+data "garage_worker_info" "test" {
+  worker_id = 1
+}
+`
@@ -0,0 +1,131 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &WorkerVariablesDataSource{}
+
+func NewWorkerVariablesDataSource() datasource.DataSource {
+	return &WorkerVariablesDataSource{}
+}
+
+// WorkerVariablesDataSource defines the data source implementation.
+type WorkerVariablesDataSource struct {
+	client *client.Client
+}
+
+// WorkerVariablesDataSourceModel describes the data source data model.
+type WorkerVariablesDataSourceModel struct {
+	Node     types.String `tfsdk:"node"`
+	Variable types.String `tfsdk:"variable"`
+	Values   types.Map    `tfsdk:"values"`
+}
+
+func (d *WorkerVariablesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_worker_variables"
+}
+
+func (d *WorkerVariablesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieves the current values of the background worker variables (such as `resync-tranquility`) on one or all nodes.",
+
+		Attributes: map[string]schema.Attribute{
+			"node": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The node to read the variables from: a node ID, `self` for the node the provider talks to, or `*` for all nodes. Defaults to `*`.",
+			},
+			"variable": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The name of the variable to read. When unset, all variables are returned.",
+			},
+			"values": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.MapType{ElemType: types.StringType},
+				MarkdownDescription: "The variable values, keyed by node ID and then by variable name.",
+			},
+		},
+	}
+}
+
+func (d *WorkerVariablesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*client.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *WorkerVariablesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data WorkerVariablesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Node.IsNull() {
+		data.Node = types.StringValue(client.AllNodes)
+	}
+
+	tflog.Debug(ctx, "Reading worker variables", map[string]interface{}{
+		"node":     data.Node.ValueString(),
+		"variable": data.Variable.ValueString(),
+	})
+
+	result, err := d.client.GetWorkerVariable(ctx, data.Node.ValueString(), client.GetWorkerVariableRequest{
+		Variable: data.Variable.ValueStringPointer(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, "read worker variables", err)
+		return
+	}
+
+	// Nodes that could not be reached are reported but do not fail the read
+	failedNodes := make([]string, 0, len(result.Error))
+	for nodeID := range result.Error {
+		failedNodes = append(failedNodes, nodeID)
+	}
+	sort.Strings(failedNodes)
+
+	for _, nodeID := range failedNodes {
+		resp.Diagnostics.AddWarning(
+			"Worker Variables Unavailable",
+			fmt.Sprintf("Unable to read worker variables on node %s: %s", nodeID, result.Error[nodeID]),
+		)
+	}
+
+	values, diags := types.MapValueFrom(ctx, types.MapType{ElemType: types.StringType}, result.Success)
+	resp.Diagnostics.Append(diags...)
+	data.Values = values
+
+	tflog.Trace(ctx, "Read worker variables data source")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,44 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccWorkerVariablesDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWorkerVariablesDataSourceConfig_allNodes,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.garage_worker_variables.test", "node", "*"),
+					resource.TestCheckResourceAttrSet("data.garage_worker_variables.test", "values.%"),
+				),
+			},
+			{
+				Config: testAccWorkerVariablesDataSourceConfig_variable,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.garage_worker_variables.test", "node", "self"),
+					resource.TestCheckResourceAttr("data.garage_worker_variables.test", "values.%", "1"),
+				),
+			},
+		},
+	})
+}
+
+const testAccWorkerVariablesDataSourceConfig_allNodes = `
+data "garage_worker_variables" "test" {}
+`
+
+const testAccWorkerVariablesDataSourceConfig_variable = `
+data "garage_worker_variables" "test" {
+  node     = "self"
+  variable = "resync-tranquility"
+}
+`